@@ -2,74 +2,142 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"log/slog"
-	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
-	"time"
 
-	"villainrsty-ecommerce-server/internal/adapters/http/router"
-	"villainrsty-ecommerce-server/internal/app"
 	"villainrsty-ecommerce-server/internal/config"
+	appLogger "villainrsty-ecommerce-server/pkg/logger"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 )
 
+const usage = `Usage: api [command] [flags]
+
+Commands:
+  serve              run the HTTP server, job worker and outbox dispatcher (default)
+  migrate up         apply all pending migrations
+  migrate down [N]   roll back the last N migrations (default 1)
+  migrate status     show the schema version and pending migrations
+  migrate force V    mark the schema as version V without running SQL (-1 = empty)
+  seed               insert development data
+  user create        create a user (-admin for the admin role)
+  tokens purge       delete expired or used tokens and expired email data
+  config print       print the effective config with secrets redacted
+
+Config flags (-config file.yml, -database.url=..., etc.) go after the command flags,
+e.g. api user create -email a@b.com -admin -- -config prod.yml`
+
 func main() {
 	// Load .env
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found")
 	}
 
-	cfg := config.MustLoad()
-	db := config.ConnectDB(cfg.DatabaseUrl)
-	defer db.Close()
-
-	// =======================================================
-	//                     using pretty_slog
-	// =======================================================
-	// var logHandler slog.Handler
+	args := os.Args[1:]
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		serve(args)
+		return
+	}
 
-	// if os.Getenv("APP_ENV") == "production" {
-	// 	logHandler = slog.NewJSONHandler(os.Stdout, nil)
-	// } else {
-	// 	logHandler = logger.NewPrettyHandler(os.Stdout, nil)
-	// }
+	switch args[0] {
+	case "serve":
+		serve(args[1:])
+	case "migrate":
+		runMigrate(args[1:])
+	case "seed":
+		runSeed(args[1:])
+	case "user":
+		runUser(args[1:])
+	case "tokens":
+		runTokens(args[1:])
+	case "config":
+		if len(args) < 2 || args[1] != "print" {
+			usageExit()
+		}
+		printConfig(args[2:])
+	case "help":
+		fmt.Println(usage)
+	default:
+		usageExit()
+	}
+}
 
-	// logger := slog.New(logHandler)
-	// =======================================================
+func usageExit() {
+	fmt.Fprintln(os.Stderr, usage)
+	os.Exit(2)
+}
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+func mustLoadConfig(args []string) config.Config {
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
 
-	container := app.New(cfg, db, logger)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
 
-	r := router.New(container)
+	return cfg
+}
 
-	srv := &http.Server{
-		Addr:              cfg.Addr,
-		Handler:           r,
-		ReadHeaderTimeout: 5 * time.Second,
+// parseCommand membaca flag milik command, sisa argumennya diteruskan ke config.Load
+func parseCommand(fs *flag.FlagSet, args []string) config.Config {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(2)
 	}
 
-	go func() {
-		log.Printf("🚀 Server running on http://localhost%s", cfg.Addr)
-		if err := srv.ListenAndServe(); err != nil {
-			log.Fatalf("server error: %v", err)
-		}
-	}()
+	return mustLoadConfig(fs.Args())
+}
+
+// cliLogger log command operator ditulis ke stderr, stdout khusus untuk hasil command
+func cliLogger(cfg config.Config) *slog.Logger {
+	logger := appLogger.New(os.Stderr, cfg.App.Env, cfg.App.LogLevel)
+	slog.SetDefault(logger)
+	return logger
+}
+
+func connectDB(cfg config.Config) *pgxpool.Pool {
+	return config.ConnectDB(cfg.Database.URL, nil)
+}
+
+// signalContext dibatalkan saat Ctrl+C supaya command yang lama (migrate, seed) berhenti rapi
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
 
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
-	<-stop
+// fatal mencetak error command lalu keluar dengan kode 1
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	os.Exit(1)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// printConfig menampilkan config efektif dengan secret disensor: api config print [-config file] [flag...]
+// Config tetap dicetak walau tidak valid supaya mudah melihat nilai mana yang salah.
+func printConfig(args []string) {
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Printf("shutdown error: %v", err)
+	if perr := cfg.Print(os.Stdout); perr != nil {
+		fmt.Fprintln(os.Stderr, perr)
+		os.Exit(1)
 	}
 
-	log.Println("shutdown compolete")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
 }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	migrations "villainrsty-ecommerce-server/db"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/migrate"
+)
+
+// runMigrate: api migrate up|down [N]|status|force V [flag config...]
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		usageExit()
+	}
+
+	action, args := args[0], args[1:]
+
+	// argumen angka (jumlah step / versi) boleh ditulis sebelum flag config
+	var n int64
+	hasN := false
+	if len(args) > 0 {
+		if v, err := strconv.ParseInt(args[0], 10, 64); err == nil {
+			n, hasN, args = v, true, args[1:]
+		}
+	}
+
+	cfg := mustLoadConfig(args)
+	logger := cliLogger(cfg)
+
+	db := connectDB(cfg)
+	defer db.Close()
+
+	migrator, err := migrate.New(db, migrations.Files(), logger)
+	if err != nil {
+		fatal(err)
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, mig := range applied {
+			fmt.Println("applied", mig)
+		}
+		if err != nil {
+			fatal(err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+
+	case "down":
+		steps := 1
+		if hasN {
+			steps = int(n)
+		}
+		if steps < 1 {
+			fatal(errors.New("steps must be at least 1"))
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, mig := range reverted {
+			fmt.Println("reverted", mig)
+		}
+		if err != nil {
+			fatal(err)
+		}
+
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			fatal(err)
+		}
+
+		fmt.Printf("version: %d\ndirty:   %t\nlatest:  %d\n", status.Version, status.Dirty, status.Latest)
+		for _, mig := range status.Pending {
+			fmt.Println("pending", mig)
+		}
+
+	case "force":
+		if !hasN {
+			usageExit()
+		}
+
+		if err := migrator.Force(ctx, n); err != nil {
+			fatal(err)
+		}
+		fmt.Println("schema version forced to", n)
+
+	default:
+		usageExit()
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"villainrsty-ecommerce-server/internal/app"
+	"villainrsty-ecommerce-server/internal/seed"
+)
+
+// runSeed: api seed [-file F] [-generate N] [-rand-seed S] [-password P] [-force] [-- flag config...].
+// Aman dijalankan berulang, user yang sudah ada dilewati.
+func runSeed(args []string) {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	file := fs.String("file", seed.DefaultFixture, "YAML fixture file (built-in dev fixture by default, empty to skip)")
+	generate := fs.Int("generate", 0, "number of fake users to generate on top of the fixture")
+	randSeed := fs.Uint64("rand-seed", 1, "seed for -generate, the same seed yields the same users")
+	password := fs.String("password", "Passw0rd123", "password for users without one in the fixture")
+	force := fs.Bool("force", false, "allow seeding when app.env is production")
+
+	cfg := parseCommand(fs, args)
+	logger := cliLogger(cfg)
+
+	if cfg.App.Env == "production" && !*force {
+		fatal(errors.New("refusing to seed a production environment, pass -force to override"))
+	}
+
+	if *generate < 0 {
+		fatal(errors.New("-generate must not be negative"))
+	}
+
+	var users []seed.UserFixture
+	if *file != "" {
+		fixture, err := seed.LoadFile(*file)
+		if err != nil {
+			fatal(err)
+		}
+		users = fixture.Users
+	}
+	users = append(users, seed.Generate(*generate, *randSeed)...)
+
+	db := connectDB(cfg)
+	defer db.Close()
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	result, err := app.New(cfg, db, logger).Seeder.SeedUsers(ctx, users, *password)
+	if err != nil {
+		fatal(err)
+	}
+
+	fmt.Printf("users: %d created, %d already existed\n", result.Created, result.Skipped)
+}
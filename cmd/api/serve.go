@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	migrations "villainrsty-ecommerce-server/db"
+	"villainrsty-ecommerce-server/internal/adapters/http/router"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/migrate"
+	"villainrsty-ecommerce-server/internal/adapters/telemetry"
+	"villainrsty-ecommerce-server/internal/app"
+	"villainrsty-ecommerce-server/internal/config"
+	appLogger "villainrsty-ecommerce-server/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// serve: api [serve] [flag config...]
+func serve(args []string) {
+	cfg := mustLoadConfig(args)
+
+	// JSON untuk production, PrettyHandler berwarna untuk development
+	logger := appLogger.New(os.Stdout, cfg.App.Env, cfg.App.LogLevel)
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := telemetry.Setup(context.Background(), telemetry.Options{
+		ServiceName: cfg.Tracing.ServiceName,
+		Env:         cfg.App.Env,
+		Exporter:    cfg.Tracing.Exporter,
+		FilePath:    cfg.Tracing.File,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logger.Error("failed to setup tracing", "error", err)
+		os.Exit(1)
+	}
+
+	db := config.ConnectDB(cfg.Database.URL, telemetry.NewQueryTracer())
+	defer db.Close()
+
+	// Schema yang tertinggal membuat query gagal di tengah request, lebih baik gagal saat start
+	if err := checkSchema(db, logger); err != nil {
+		logger.Error("database schema is not up to date, run `api migrate up` first", "error", err)
+		os.Exit(1)
+	}
+
+	container := app.New(cfg, db, logger)
+
+	r := router.New(container)
+
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	workers.Add(2)
+	go func() {
+		defer workers.Done()
+		container.JobWorker.Run(workerCtx)
+	}()
+	go func() {
+		defer workers.Done()
+		container.Dispatcher.Run(workerCtx)
+	}()
+
+	workerDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workerDone)
+	}()
+
+	srv := &http.Server{
+		Addr:              cfg.App.Addr,
+		Handler:           r,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		logger.Info("🚀 Server running", "addr", cfg.App.Addr, "env", cfg.App.Env)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("server error", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	// metrics (memstats, proses, runtime) di listener terpisah supaya tidak ikut terbuka ke publik
+	var metricsSrv *http.Server
+	if cfg.App.MetricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("GET /metrics", container.Metrics.Handler())
+		metricsSrv = &http.Server{
+			Addr:              cfg.App.MetricsAddr,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+
+		go func() {
+			logger.Info("metrics server running", "addr", cfg.App.MetricsAddr)
+			if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("metrics server error", "error", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	// Readiness gagal lebih dulu, beri waktu load balancer berhenti mengirim traffic baru
+	// sebelum listener ditutup
+	container.Health.MarkShuttingDown()
+	logger.Info("shutting down, draining traffic", "delay", cfg.App.ShutdownDrainDelay.String())
+	time.Sleep(cfg.App.ShutdownDrainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error("shutdown error", "error", err)
+	}
+
+	if metricsSrv != nil {
+		if err := metricsSrv.Shutdown(ctx); err != nil {
+			logger.Error("metrics shutdown error", "error", err)
+		}
+	}
+
+	// Worker & dispatcher dihentikan setelah HTTP server, job yang sedang jalan dibiarkan selesai
+	stopWorker()
+	select {
+	case <-workerDone:
+	case <-ctx.Done():
+		logger.Warn("background workers did not stop in time")
+	}
+
+	// Flush span terakhir (termasuk milik worker) sebelum proses keluar
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("failed to flush traces", "error", err)
+	}
+
+	logger.Info("shutdown complete")
+}
+
+// checkSchema menolak start kalau ada migration tertunda atau migration terakhir gagal (dirty)
+func checkSchema(db *pgxpool.Pool, logger *slog.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	migrator, err := migrate.New(db, migrations.Files(), logger)
+	if err != nil {
+		return err
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	return status.Err()
+}
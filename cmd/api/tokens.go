@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"villainrsty-ecommerce-server/internal/app"
+)
+
+// runTokens: api tokens purge [flag config...], cocok dijadwalkan lewat cron
+func runTokens(args []string) {
+	if len(args) == 0 || args[0] != "purge" {
+		usageExit()
+	}
+
+	cfg := mustLoadConfig(args[1:])
+	logger := cliLogger(cfg)
+
+	db := connectDB(cfg)
+	defer db.Close()
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	container := app.New(cfg, db, logger)
+	result, err := container.AuthService.PurgeExpiredTokens(ctx)
+	if err != nil {
+		fatal(err)
+	}
+
+	emailData, err := container.EmailService.PurgeData(ctx)
+	if err != nil {
+		fatal(err)
+	}
+
+	fmt.Printf("refresh tokens:        %d\n", result.RefreshTokens)
+	fmt.Printf("two factor otps:       %d\n", result.TwoFactorOTPs)
+	fmt.Printf("password reset tokens: %d\n", result.PasswordResetTokens)
+	fmt.Printf("email message data:    %d\n", emailData)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"villainrsty-ecommerce-server/internal/app"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+// runUser: api user create -email E -name N [-password P] [-admin] [-- flag config...]
+// Tanpa -password, password dibaca dari baris pertama stdin supaya tidak tercatat di shell history.
+func runUser(args []string) {
+	if len(args) == 0 || args[0] != "create" {
+		usageExit()
+	}
+
+	fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+	email := fs.String("email", "", "user email (required)")
+	name := fs.String("name", "", "display name, defaults to the local part of the email")
+	password := fs.String("password", "", "password, read from stdin when empty")
+	admin := fs.Bool("admin", false, "create the user with the admin role")
+
+	cfg := parseCommand(fs, args[1:])
+	logger := cliLogger(cfg)
+
+	if *email == "" {
+		fatal(errors.New("-email is required"))
+	}
+
+	if *name == "" {
+		*name, _, _ = strings.Cut(*email, "@")
+	}
+
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			fatal(fmt.Errorf("read password: %w", err))
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	role := models.RoleUser
+	if *admin {
+		role = models.RoleAdmin
+	}
+
+	db := connectDB(cfg)
+	defer db.Close()
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	container := app.New(cfg, db, logger)
+	user, err := container.AuthService.CreateUser(ctx, *email, *password, *name, role)
+	if err != nil {
+		fatal(err)
+	}
+
+	fmt.Printf("created %s user %s (%s)\n", user.Role, user.Email, user.ID)
+}
@@ -0,0 +1,37 @@
+// apiclient-gen menghasilkan client Go typed (pkg/apiclient) dari dokumen OpenAPI satu versi
+// (openapi/<versi>.yml).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/openapi"
+)
+
+func main() {
+	spec := flag.String("spec", "openapi/v1.yml", "path to the OpenAPI document of one API version")
+	out := flag.String("out", "api.gen.go", "output file")
+	pkg := flag.String("package", "apiclient", "package name of the generated file")
+	flag.Parse()
+
+	if err := run(*spec, *out, *pkg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(spec, out, pkg string) error {
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		return err
+	}
+
+	src, err := openapi.GenerateClient(data, pkg)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(out, src, 0o644)
+}
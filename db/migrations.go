@@ -0,0 +1,21 @@
+package db
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// Migrations berisi file migration yang ikut dikompilasi ke binary
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// Files folder migrations sebagai root filesystem, formatnya yang dibaca migrator
+func Files() fs.FS {
+	sub, err := fs.Sub(Migrations, "migrations")
+	if err != nil {
+		panic(err)
+	}
+
+	return sub
+}
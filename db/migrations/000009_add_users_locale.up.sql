@@ -0,0 +1,2 @@
+-- user lama tetap menerima email dalam bahasa Indonesia seperti sebelumnya
+ALTER TABLE users ADD COLUMN locale VARCHAR(8) NOT NULL DEFAULT 'id';
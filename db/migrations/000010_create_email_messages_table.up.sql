@@ -0,0 +1,21 @@
+-- satu baris per email, attempts/last_error diperbarui setiap kali worker mencoba kirim.
+-- data berisi variabel template (link reset, kode OTP), sama sensitifnya dengan payload jobs,
+-- karena itu dikosongkan (data_purged_at) setelah terkirim atau setelah link/kodenya kedaluwarsa.
+CREATE TABLE IF NOT EXISTS email_messages (
+    id VARCHAR(36) PRIMARY KEY,
+    template VARCHAR(100) NOT NULL,
+    to_email VARCHAR(255) NOT NULL,
+    locale VARCHAR(8) NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    attempts INT NOT NULL DEFAULT 0,
+    provider_message_id VARCHAR(255),
+    last_error TEXT,
+    sent_at TIMESTAMP,
+    data_purged_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_messages_status_created_at ON email_messages(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_email_messages_to_email ON email_messages(to_email);
@@ -0,0 +1,2 @@
+-- admin sebelumnya hanya lewat ADMIN_EMAILS, user lama tetap user biasa
+ALTER TABLE users ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'user';
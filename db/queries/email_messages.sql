@@ -0,0 +1,42 @@
+-- name: CreateEmailMessage :exec
+INSERT INTO email_messages (id, template, to_email, locale, data, status, attempts, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetEmailMessageByID :one
+SELECT id, template, to_email, locale, data, status, attempts, provider_message_id, last_error, sent_at, data_purged_at, created_at, updated_at
+FROM email_messages
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListEmailMessages :many
+SELECT id, template, to_email, locale, data, status, attempts, provider_message_id, last_error, sent_at, data_purged_at, created_at, updated_at
+FROM email_messages
+WHERE sqlc.arg(status)::text = '' OR status = sqlc.arg(status)::text
+ORDER BY created_at DESC
+LIMIT sqlc.arg(limit_count)::int OFFSET sqlc.arg(offset_count)::int;
+
+-- name: CountEmailMessages :one
+SELECT COUNT(*) FROM email_messages
+WHERE sqlc.arg(status)::text = '' OR status = sqlc.arg(status)::text;
+
+-- name: RecordEmailAttempt :exec
+UPDATE email_messages
+SET status = $2,
+    attempts = attempts + 1,
+    provider_message_id = $3,
+    last_error = $4,
+    sent_at = CASE WHEN $2 = 'sent' THEN NOW() ELSE sent_at END,
+    data = CASE WHEN $2 = 'sent' THEN '{}'::jsonb ELSE data END,
+    data_purged_at = CASE WHEN $2 = 'sent' THEN COALESCE(data_purged_at, NOW()) ELSE data_purged_at END,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: RequeueEmailMessage :execrows
+UPDATE email_messages
+SET status = 'queued', last_error = NULL, updated_at = NOW()
+WHERE id = $1 AND status = 'failed' AND data_purged_at IS NULL;
+
+-- name: PurgeEmailMessageData :execrows
+UPDATE email_messages
+SET data = '{}'::jsonb, data_purged_at = NOW(), updated_at = NOW()
+WHERE data_purged_at IS NULL AND created_at < $1;
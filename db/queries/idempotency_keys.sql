@@ -0,0 +1,42 @@
+-- name: AcquireIdempotencyKey :one
+INSERT INTO idempotency_keys (scope, key, method, path, fingerprint, status, locked_at, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, 'processing', $6, $7, $8)
+ON CONFLICT (scope, key) DO UPDATE
+SET method = EXCLUDED.method,
+    path = EXCLUDED.path,
+    fingerprint = EXCLUDED.fingerprint,
+    status = 'processing',
+    response_status = NULL,
+    response_headers = NULL,
+    response_body = NULL,
+    locked_at = EXCLUDED.locked_at,
+    completed_at = NULL,
+    expires_at = EXCLUDED.expires_at,
+    created_at = EXCLUDED.created_at
+WHERE idempotency_keys.expires_at <= EXCLUDED.locked_at
+   OR (idempotency_keys.status = 'processing' AND idempotency_keys.locked_at <= sqlc.arg(stale_before)::timestamp)
+RETURNING scope, key, method, path, fingerprint, status, response_status, response_headers, response_body, locked_at, completed_at, expires_at, created_at;
+
+-- name: GetIdempotencyKey :one
+SELECT scope, key, method, path, fingerprint, status, response_status, response_headers, response_body, locked_at, completed_at, expires_at, created_at
+FROM idempotency_keys
+WHERE scope = $1 AND key = $2
+LIMIT 1;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status = 'completed',
+    response_status = $3,
+    response_headers = $4,
+    response_body = $5,
+    completed_at = $6,
+    expires_at = $7
+WHERE scope = $1 AND key = $2;
+
+-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE scope = $1 AND key = $2 AND status = 'processing';
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1;
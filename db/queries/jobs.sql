@@ -0,0 +1,53 @@
+-- name: CreateJob :exec
+INSERT INTO jobs (id, type, payload, status, attempts, max_attempts, run_at, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: ClaimJobs :many
+UPDATE jobs
+SET status = 'running', locked_at = sqlc.arg(now)::timestamp, attempts = attempts + 1, updated_at = NOW()
+WHERE id IN (
+    SELECT j.id FROM jobs j
+    WHERE (j.status = 'pending' AND j.run_at <= sqlc.arg(now)::timestamp)
+       OR (j.status = 'running' AND j.locked_at < sqlc.arg(stale_before)::timestamp)
+    ORDER BY j.run_at
+    LIMIT sqlc.arg(batch_size)::int
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, type, payload, status, attempts, max_attempts, run_at, locked_at, last_error, created_at, updated_at;
+
+-- name: CompleteJob :exec
+UPDATE jobs
+SET status = 'succeeded', locked_at = NULL, last_error = NULL, updated_at = NOW()
+WHERE id = $1;
+
+-- name: RescheduleJob :exec
+UPDATE jobs
+SET status = 'pending', run_at = $2, last_error = $3, locked_at = NULL, updated_at = NOW()
+WHERE id = $1;
+
+-- name: MarkJobDead :exec
+UPDATE jobs
+SET status = 'dead', last_error = $2, locked_at = NULL, updated_at = NOW()
+WHERE id = $1;
+
+-- name: RetryDeadJob :execrows
+UPDATE jobs
+SET status = 'pending', attempts = 0, run_at = $2, last_error = NULL, locked_at = NULL, updated_at = NOW()
+WHERE id = $1 AND status = 'dead';
+
+-- name: GetJobByID :one
+SELECT id, type, payload, status, attempts, max_attempts, run_at, locked_at, last_error, created_at, updated_at
+FROM jobs
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListJobsByStatus :many
+SELECT id, type, payload, status, attempts, max_attempts, run_at, locked_at, last_error, created_at, updated_at
+FROM jobs
+WHERE status = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountJobsByStatus :one
+SELECT COUNT(*) FROM jobs
+WHERE status = $1;
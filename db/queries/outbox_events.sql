@@ -0,0 +1,23 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload, occurred_at, available_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ClaimOutboxEvents :many
+SELECT id, aggregate_type, aggregate_id, event_type, payload, occurred_at, published_at, attempts, last_error, available_at, created_at
+FROM outbox_events
+WHERE published_at IS NULL
+  AND available_at <= sqlc.arg(now)::timestamp
+  AND attempts < sqlc.arg(max_attempts)::int
+ORDER BY occurred_at
+LIMIT sqlc.arg(batch_size)::int
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE outbox_events
+SET published_at = $2, last_error = NULL
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE outbox_events
+SET attempts = attempts + 1, last_error = $2, available_at = $3
+WHERE id = $1;
@@ -10,9 +10,9 @@ LIMIT 1;
 
 -- name: MarkPasswordResetTokenUsed :exec
 UPDATE password_reset_tokens
-SET used_at = NOW()
+SET used_at = $2
 WHERE id = $1;
 
--- name: DeleteExpirePasswordResetToken :exec
+-- name: DeleteExpirePasswordResetToken :execrows
 DELETE FROM password_reset_tokens
-WHERE expires_at < NOW() OR used_at IS NOT NULL;
\ No newline at end of file
+WHERE expires_at < $1 OR used_at IS NOT NULL;
\ No newline at end of file
@@ -10,14 +10,14 @@ WHERE token_hash = $1;
 -- name: GetRefreshTokensByUserID :many
 SELECT id, user_id, token_hash, expires_at, revoked_at, created_at, updated_at
 FROM refresh_tokens
-WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
 ORDER BY created_at DESC;
 
 -- name: RevokeRefreshToken :exec
 UPDATE refresh_tokens
-SET revoked_at = $2, updated_at = NOW()
+SET revoked_at = $2, updated_at = $2
 WHERE id = $1;
 
--- name: DeleteExpiredRefreshTokens :exec
+-- name: DeleteExpiredRefreshTokens :execrows
 DELETE FROM refresh_tokens
 WHERE expires_at < $1;
\ No newline at end of file
@@ -10,9 +10,9 @@ LIMIT 1;
 
 -- name: MarkTwoFactorOTPUsed :exec
 UPDATE two_factor_otps
-SET used_at = NOW()
+SET used_at = $2
 WHERE id = $1;
 
--- name: DeleteExpiresTwoFactorOTP :exec
+-- name: DeleteExpiresTwoFactorOTP :execrows
 DELETE FROM two_factor_otps
-WHERE expires_at < NOW() OR used_at IS NOT NULL;
\ No newline at end of file
+WHERE expires_at < $1 OR used_at IS NOT NULL;
\ No newline at end of file
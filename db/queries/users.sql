@@ -1,17 +1,17 @@
 -- name: GetUserByEmail :one
-SELECT id, email, password, name, created_at, updated_at
+SELECT id, email, password, name, locale, role, created_at, updated_at
 FROM users
 WHERE email = $1;
 
 -- name: GetUserByID :one
-SELECT id, email, password, name, created_at, updated_at
+SELECT id, email, password, name, locale, role, created_at, updated_at
 FROM users
 WHERE id = $1
 LIMIT 1;
 
 -- name: CreateUser :exec
-INSERT INTO users (id, email, password, name, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO users (id, email, password, name, locale, role, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 
 -- name: UpdateUser :exec
 UPDATE users
@@ -28,4 +28,9 @@ SELECT EXISTS(SELECT 1 FROM users WHERE email = $1);
 -- name: UpdateUserPassword :exec
 UPDATE users
 SET password = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: UpdateUserLocale :exec
+UPDATE users
+SET locale = $2, updated_at = NOW()
 WHERE id = $1;
\ No newline at end of file
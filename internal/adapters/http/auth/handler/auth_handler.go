@@ -1,16 +1,17 @@
 package handler
 
 import (
-	"fmt"
 	"log/slog"
 	"net/http"
 
 	"villainrsty-ecommerce-server/internal/adapters/http/auth/models"
 	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	"villainrsty-ecommerce-server/internal/adapters/http/middleware"
 	"villainrsty-ecommerce-server/internal/core/auth/ports"
 	"villainrsty-ecommerce-server/internal/core/shared/errors"
 
 	sharedModel "villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/logger"
 )
 
 type AuthHandler struct {
@@ -25,19 +26,19 @@ func NewAuthHandler(service ports.AuthService, logger *slog.Logger) *AuthHandler
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req models.LoginRequest
 	if !httpx.DecodeJSON(w, r, &req) {
-		h.logger.Warn("failed to decode login json body")
+		h.log(r).Warn("failed to decode login json body")
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
 	user, accessToken, refreshToken, err := h.authService.Login(r.Context(), req.Email, req.Password, req.RememberMe)
 	if err != nil {
-		h.logger.Warn("login failed", "email", req.Email, "error", err.Error())
-		h.handlerError(w, err)
+		h.log(r).Warn("login failed", "email", req.Email, "error", err.Error())
+		h.handlerError(w, r, err)
 		return
 	}
 
@@ -57,13 +58,13 @@ func (h *AuthHandler) Login2FA(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := req.Validate(); err != nil {
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
 	challengeID, err := h.authService.LoginWith2FA(r.Context(), req.Email, req.Password, req.RememberMe)
 	if err != nil {
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
@@ -77,22 +78,15 @@ func (h *AuthHandler) VerifyLogin2FA(w http.ResponseWriter, r *http.Request) {
 	if !httpx.DecodeJSON(w, r, &req) {
 		return
 	}
-	h.logger.Info("isinya",
-		"challenge_id", req.ChallengeID,
-		"otp_code", req.OTPCode,
-		"remember", req.RememberMe,
-	)
 
 	if err := req.Validate(); err != nil {
-		h.logger.Info("error validate", "error", err)
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
 	user, accessToken, refreshToken, err := h.authService.VerifyLogin2FA(r.Context(), req.ChallengeID, req.OTPCode, req.RememberMe)
 	if err != nil {
-		h.logger.Info("ke error verify", "error", err)
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
@@ -113,13 +107,13 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := req.Validate(); err != nil {
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
 	user, err := h.authService.Register(r.Context(), req.Email, req.Password, req.Name)
 	if err != nil {
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
@@ -136,12 +130,12 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := req.Validate(); err != nil {
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
 	if err := h.authService.Logout(r.Context(), req.RefreshToken); err != nil {
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
@@ -150,23 +144,18 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 
 func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	var req models.RefreshTokenRequest
-	// h.logger.Info("req di handler", "validate diluar", req.Validate())
-
 	if !httpx.DecodeJSON(w, r, &req) {
 		return
 	}
-	h.logger.Info("req di handler", "refresh token", req.RefreshToken)
 
 	if err := req.Validate(); err != nil {
-		h.logger.Info("req di handler", "validate di dalam", req.Validate())
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
 	accessToken, refreshToken, err := h.authService.RefreshToken(r.Context(), req.RefreshToken)
 	if err != nil {
-		fmt.Println("masuk ke sini")
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
@@ -186,12 +175,13 @@ func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := req.Validate(); err != nil {
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
 	if err := h.authService.RequestPasswordReset(r.Context(), req.Email); err != nil {
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
+		return
 	}
 
 	httpx.Success(w, http.StatusOK, "Request berhasil, link akan segera dikirim", "")
@@ -205,86 +195,72 @@ func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := req.Validate(); err != nil {
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
 	if err := h.authService.ConfirmPasswordReset(r.Context(), req.Token, req.NewPassword); err != nil {
-		h.handlerError(w, err)
+		h.handlerError(w, r, err)
 		return
 	}
 
 	httpx.Success(w, http.StatusOK, "Password berhasil direset", "")
 }
 
-func (h *AuthHandler) handlerError(w http.ResponseWriter, err error) {
-	appErr, ok := errors.AsAppError(err)
-	if !ok {
-		h.logger.Error("internal server error", "error", err.Error())
-		httpx.ErrorWithDetails(
-			w,
-			http.StatusInternalServerError,
-			"Internal Server Error",
-			"INTERNAL_ERROR",
-			err.Error(),
-		)
+// UpdateLocale mengganti bahasa pilihan user, dipakai untuk email dan response tanpa Accept-Language
+func (h *AuthHandler) UpdateLocale(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateLocaleRequest
+	if !httpx.DecodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.handlerError(w, r, err)
+		return
+	}
+
+	user := middleware.GetUserFromContext(*r)
+	if err := h.authService.UpdateLocale(r.Context(), user.ID.String(), req.Locale); err != nil {
+		h.handlerError(w, r, err)
 		return
 	}
 
-	switch appErr.Kind {
-	case errors.ErrNotFound, errors.ErrValidation, errors.ErrUnauthorized, errors.ErrForbidden, errors.ErrConflict:
-		h.logger.Warn("client error response",
+	httpx.Success(w, http.StatusOK, "Locale updated", "")
+}
+
+// log mengambil logger request dari ctx supaya log handler membawa request_id
+func (h *AuthHandler) log(r *http.Request) *slog.Logger {
+	return logger.FromContext(r.Context(), h.logger)
+}
+
+func (h *AuthHandler) handlerError(w http.ResponseWriter, r *http.Request, err error) {
+	appErr, ok := errors.AsAppError(err)
+	switch {
+	case !ok:
+		h.log(r).Error("internal server error", "error", err.Error())
+	case appErr.Kind == errors.ErrInternal:
+		h.log(r).Error("server error response",
 			"kind", appErr.Kind,
+			"code", appErr.ErrorCode(),
 			"message", appErr.Error(),
 		)
 	default:
-		h.logger.Error("server error response",
+		h.log(r).Warn("client error response",
 			"kind", appErr.Kind,
+			"code", appErr.ErrorCode(),
 			"message", appErr.Error(),
 		)
 	}
 
-	switch appErr.Kind {
-	case errors.ErrNotFound:
-		httpx.Error(w, http.StatusNotFound, "Resource not found", "NOT_FOUND")
-	case errors.ErrValidation:
-		fieldErrors := make([]httpx.FieldError, 0)
-		for field, msg := range appErr.Fields {
-			fieldErrors = append(fieldErrors, httpx.FieldError{
-				Field:   field,
-				Message: msg,
-			})
-		}
-		httpx.ValidationError(w, fieldErrors)
-	case errors.ErrUnauthorized:
-		httpx.Error(w, http.StatusUnauthorized, "invalid credentials", "UNAUTHORIZED")
-	case errors.ErrForbidden:
-		httpx.Error(w, http.StatusForbidden, "access denied", "FORBIDDEN")
-	case errors.ErrConflict:
-		httpx.ErrorWithDetails(w, http.StatusConflict, "resource already exists", "CONFLICT", err.Error())
-	default:
-		httpx.ErrorWithDetails(
-			w,
-			http.StatusInternalServerError,
-			"internal server error",
-			"INTERNAL_ERROR",
-			appErr.Message,
-		)
-
-	}
+	httpx.WriteError(w, r, err)
 }
 
 func mapUserToDTO(user *sharedModel.User) models.UserDTO {
 	return models.UserDTO{
-		ID:    user.ID.String(),
-		Email: user.Email,
-		Name:  user.Name,
-	}
-}
-
-func maskToken(t string) string {
-	if len(t) <= 16 {
-		return t
+		ID:     user.ID.String(),
+		Email:  user.Email,
+		Name:   user.Name,
+		Locale: string(user.Locale),
+		Role:   string(user.Role),
 	}
-	return t[:8] + "..." + t[len(t)-8:]
 }
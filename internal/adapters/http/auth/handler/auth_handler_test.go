@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"villainrsty-ecommerce-server/internal/core/auth/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+)
+
+// stubAuthService hanya mengimplementasikan method yang dipakai test, sisanya panic lewat interface nil
+type stubAuthService struct {
+	ports.AuthService
+	resetErr error
+}
+
+func (s *stubAuthService) RequestPasswordReset(context.Context, string) error {
+	return s.resetErr
+}
+
+func TestForgotPassword_ErrorWritesOnlyTheProblem(t *testing.T) {
+	h := NewAuthHandler(&stubAuthService{resetErr: errors.New(errors.ErrInternal, "database down")}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/forgot-password", strings.NewReader(`{"email":"budi@mail.com"}`))
+	h.ForgotPassword(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+
+	// body harus satu dokumen problem+json, tanpa envelope sukses yang menempel di belakangnya
+	body := rec.Body.String()
+	var problem map[string]any
+	decoder := json.NewDecoder(strings.NewReader(body))
+	if err := decoder.Decode(&problem); err != nil || problem["status"] != float64(http.StatusInternalServerError) {
+		t.Fatalf("problem = %v, %v", problem, err)
+	}
+	if decoder.More() {
+		t.Errorf("body continues after the problem document: %s", body)
+	}
+}
@@ -6,27 +6,27 @@ import (
 
 type (
 	LoginRequest struct {
-		Email      string `json:"email" validate:"required,email"`
-		Password   string `json:"password" validate:"required,min=8"`
-		RememberMe bool   `json:"remember_me"`
+		Email      string `json:"email" validate:"required,email" example:"user@mail.com"`
+		Password   string `json:"password" validate:"required,min=8" example:"Passw0rd123"`
+		RememberMe bool   `json:"remember_me" doc:"Issue a longer-lived refresh token"`
 	}
 
 	Login2FARequest struct {
-		Email      string `json:"email" validate:"required,email"`
-		Password   string `json:"password" validate:"required,min=8"`
-		RememberMe bool   `json:"remember_me"`
+		Email      string `json:"email" validate:"required,email" example:"user@mail.com"`
+		Password   string `json:"password" validate:"required,min=8" example:"Passw0rd123"`
+		RememberMe bool   `json:"remember_me" doc:"Issue a longer-lived refresh token"`
 	}
 
 	VerifyLogin2FARequest struct {
 		ChallengeID string `json:"challenge_id" validate:"required"`
-		OTPCode     string `json:"otp_code" validate:"required,len=6"`
+		OTPCode     string `json:"otp_code" validate:"required,len=6" example:"123456"`
 		RememberMe  bool   `json:"remember_me"`
 	}
 
 	RegisterRequest struct {
-		Email    string `json:"email" validate:"required,email"`
-		Password string `json:"password" validate:"required,min=8"`
-		Name     string `json:"name" validate:"required,min=1,max=100"`
+		Email    string `json:"email" validate:"required,email" example:"user@mail.com"`
+		Password string `json:"password" validate:"required,min=8" example:"Passw0rd123" doc:"Must contain an uppercase letter, a lowercase letter and a number"`
+		Name     string `json:"name" validate:"required,min=1,max=100" example:"Budi"`
 	}
 
 	RefreshTokenRequest struct {
@@ -38,18 +38,24 @@ type (
 	}
 
 	ForgotPasswordRequest struct {
-		Email string `json:"email" validate:"required,email"`
+		Email string `json:"email" validate:"required,email" example:"user@mail.com"`
 	}
 
 	ResetPasswordRequest struct {
 		Token       string `json:"token" validate:"required"`
-		NewPassword string `json:"new_password" validate:"required,min=8"`
+		NewPassword string `json:"new_password" validate:"required,min=8" example:"NewPass123" doc:"Must contain an uppercase letter, a lowercase letter and a number"`
+	}
+
+	UpdateLocaleRequest struct {
+		Locale string `json:"locale" validate:"required" enum:"en id" example:"id"`
 	}
 
 	UserDTO struct {
-		ID    string `json:"id"`
-		Email string `json:"email"`
-		Name  string `json:"name"`
+		ID     string `json:"id"`
+		Email  string `json:"email" format:"email"`
+		Name   string `json:"name"`
+		Locale string `json:"locale" enum:"en id"`
+		Role   string `json:"role" enum:"user admin"`
 	}
 
 	RegisterResponse struct {
@@ -58,7 +64,7 @@ type (
 
 	LoginResponse struct {
 		User         UserDTO `json:"user"`
-		Token        string  `json:"token"`
+		Token        string  `json:"token" doc:"Access token (JWT)"`
 		RefreshToken string  `json:"refresh_token"`
 	}
 
@@ -127,3 +133,8 @@ func (r *ResetPasswordRequest) Validate() error {
 
 	return v.ValidatePassword(r.NewPassword)
 }
+
+func (r *UpdateLocaleRequest) Validate() error {
+	v := pkgValidator.NewValidate()
+	return v.ValidateStruct(r)
+}
@@ -1,20 +1,104 @@
 package routes
 
 import (
+	"net/http"
+
 	"villainrsty-ecommerce-server/internal/adapters/http/auth/handler"
+	"villainrsty-ecommerce-server/internal/adapters/http/auth/models"
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	"villainrsty-ecommerce-server/internal/adapters/http/middleware"
+	"villainrsty-ecommerce-server/internal/adapters/http/openapi"
+)
 
-	"github.com/go-chi/chi/v5"
+// Nama policy rate limit untuk route auth, limit-nya diisi dari config di app.Container
+const (
+	RateLimitAuth     = "auth"
+	RateLimitRegister = "auth.register"
+	RateLimitEmail    = "auth.email"
+	// RateLimitOTP dibatasi per challenge_id supaya kode OTP tidak bisa ditebak dari banyak IP
+	RateLimitOTP = "auth.otp"
 )
 
-func RegisterRoute(r chi.Router, handler *handler.AuthHandler) {
-	r.Route("/auth", func(r chi.Router) {
-		r.Post("/login", handler.Login)
-		r.Post("/login-2fa", handler.Login2FA)
-		r.Post("/verify-login-2fa", handler.VerifyLogin2FA)
-		r.Post("/register", handler.Register)
-		r.Post("/refresh", handler.RefreshToken)
-		r.Post("/logout", handler.Logout)
-		r.Post("/forgot-password", handler.ForgotPassword)
-		r.Post("/reset-password", handler.ResetPassword)
+// idempotent hanya dipasang di route yang response-nya aman disimpan (tanpa token/kredensial)
+func RegisterRoute(r *openapi.Router, handler *handler.AuthHandler, limiter *middleware.RateLimiter, idempotent, authenticated func(http.Handler) http.Handler) {
+	r.Route("/auth", func(r *openapi.Router) {
+		r.Use(limiter.Limit(RateLimitAuth))
+
+		r = r.Describe(openapi.Doc{
+			Tags:   []string{"Auth"},
+			Errors: []int{http.StatusBadRequest, http.StatusTooManyRequests, http.StatusInternalServerError},
+		})
+		perEmail := limiter.Limit(RateLimitEmail)
+
+		r.With(perEmail).Post("/login", handler.Login, openapi.Doc{
+			ID:        "login",
+			Summary:   "Log in with email and password",
+			Request:   models.LoginRequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Description: "Logged in", Body: httpx.BaseResponse[models.LoginResponse]{}}},
+			Errors:    []int{http.StatusUnauthorized},
+		})
+		r.With(perEmail).Post("/login-2fa", handler.Login2FA, openapi.Doc{
+			ID:        "login2FA",
+			Summary:   "Start a login that is confirmed with an emailed OTP",
+			Request:   models.Login2FARequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Description: "OTP sent, confirm with /auth/verify-login-2fa", Body: httpx.BaseResponse[models.Login2FAResponse]{}}},
+			Errors:    []int{http.StatusUnauthorized},
+		})
+		r.With(limiter.Limit(RateLimitOTP)).Post("/verify-login-2fa", handler.VerifyLogin2FA, openapi.Doc{
+			ID:        "verifyLogin2FA",
+			Summary:   "Finish a 2FA login with the emailed OTP",
+			Request:   models.VerifyLogin2FARequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Description: "Logged in", Body: httpx.BaseResponse[models.LoginResponse]{}}},
+			Errors:    []int{http.StatusUnauthorized},
+		})
+		r.With(limiter.Limit(RateLimitRegister), idempotent).Post("/register", handler.Register, openapi.Doc{
+			ID:         "register",
+			Summary:    "Register a new user",
+			Idempotent: true,
+			Request:    models.RegisterRequest{},
+			Responses:  []openapi.Response{{Status: http.StatusOK, Description: "User registered", Body: httpx.BaseResponse[models.RegisterResponse]{}}},
+			Errors:     []int{http.StatusConflict},
+		})
+		r.Post("/refresh", handler.RefreshToken, openapi.Doc{
+			ID:          "refreshToken",
+			Summary:     "Rotate a refresh token",
+			Description: "The old refresh token is revoked; reusing it returns 401.",
+			Request:     models.RefreshTokenRequest{},
+			Responses:   []openapi.Response{{Status: http.StatusOK, Description: "New token pair", Body: httpx.BaseResponse[models.RefreshTokenResponse]{}}},
+			Errors:      []int{http.StatusUnauthorized},
+		})
+		r.Post("/logout", handler.Logout, openapi.Doc{
+			ID:        "logout",
+			Summary:   "Revoke a refresh token",
+			Request:   models.LogoutRequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Description: "Logged out", Body: httpx.BaseResponse[string]{}}},
+			Errors:    []int{http.StatusUnauthorized},
+		})
+		r.With(perEmail, idempotent).Post("/forgot-password", handler.ForgotPassword, openapi.Doc{
+			ID:         "forgotPassword",
+			Summary:    "Request password reset link",
+			Idempotent: true,
+			Request:    models.ForgotPasswordRequest{},
+			Responses:  []openapi.Response{{Status: http.StatusOK, Description: "Request accepted", Body: httpx.BaseResponse[string]{}}},
+			Errors:     []int{http.StatusConflict},
+		})
+		r.With(idempotent).Post("/reset-password", handler.ResetPassword, openapi.Doc{
+			ID:         "resetPassword",
+			Summary:    "Confirm password reset",
+			Idempotent: true,
+			Request:    models.ResetPasswordRequest{},
+			Responses:  []openapi.Response{{Status: http.StatusOK, Description: "Password reset success", Body: httpx.BaseResponse[string]{}}},
+			Errors:     []int{http.StatusUnauthorized, http.StatusConflict},
+		})
+		r.With(authenticated).Put("/locale", handler.UpdateLocale, openapi.Doc{
+			ID:      "updateLocale",
+			Summary: "Set preferred language for emails and responses",
+			Description: "Responses follow `Accept-Language` first, then this stored preference, then `en`.\n" +
+				"Emails are always sent in the stored preference.\n",
+			Auth:      true,
+			Request:   models.UpdateLocaleRequest{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Description: "Locale updated", Body: httpx.BaseResponse[string]{}}},
+			Errors:    []int{http.StatusUnauthorized},
+		})
 	})
 }
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/emails/models"
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	"villainrsty-ecommerce-server/internal/adapters/notifications/templates"
+	"villainrsty-ecommerce-server/internal/core/emails/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	sharedModel "villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/i18n"
+	"villainrsty-ecommerce-server/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TemplateRenderer bagian dari templates.Renderer yang dipakai handler
+type TemplateRenderer interface {
+	IDs() []string
+	Sample(id string) map[string]any
+	Render(locale i18n.Locale, id string, data map[string]any) (*templates.Email, error)
+}
+
+type EmailHandler struct {
+	emailService ports.EmailService
+	renderer     TemplateRenderer
+	logger       *slog.Logger
+}
+
+func NewEmailHandler(service ports.EmailService, renderer TemplateRenderer, logger *slog.Logger) *EmailHandler {
+	return &EmailHandler{emailService: service, renderer: renderer, logger: logger}
+}
+
+// List query: status (queued, sent, failed; kosong = semua), page, limit
+func (h *EmailHandler) List(w http.ResponseWriter, r *http.Request) {
+	status := sharedModel.EmailStatus(r.URL.Query().Get("status"))
+	page := queryInt(r, "page", 1)
+	limit := min(queryInt(r, "limit", 20), 100)
+
+	msgs, total, err := h.emailService.List(r.Context(), status, page, limit)
+	if err != nil {
+		h.handlerError(w, r, err)
+		return
+	}
+
+	resp := make([]models.EmailMessageDTO, len(msgs))
+	for i, msg := range msgs {
+		resp[i] = mapEmailMessageToDTO(msg)
+	}
+
+	totalPage := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPage++
+	}
+
+	httpx.SuccessWithMeta(w, http.StatusOK, "Email messages fetched successfully", resp, &httpx.Meta{
+		Page:      page,
+		Limit:     limit,
+		Total:     int(total),
+		TotalPage: totalPage,
+	})
+}
+
+func (h *EmailHandler) Get(w http.ResponseWriter, r *http.Request) {
+	msg, err := h.emailService.Get(r.Context(), sharedModel.ID(chi.URLParam(r, "id")))
+	if err != nil {
+		h.handlerError(w, r, err)
+		return
+	}
+
+	httpx.Success(w, http.StatusOK, "Email message fetched successfully", mapEmailMessageToDTO(msg))
+}
+
+func (h *EmailHandler) Resend(w http.ResponseWriter, r *http.Request) {
+	id := sharedModel.ID(chi.URLParam(r, "id"))
+	if err := h.emailService.Resend(r.Context(), id); err != nil {
+		h.handlerError(w, r, err)
+		return
+	}
+
+	logger.FromContext(r.Context(), h.logger).Info("email message requeued", "message_id", id.String())
+	httpx.Success(w, http.StatusOK, "Email message requeued successfully", "")
+}
+
+func (h *EmailHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	ids := h.renderer.IDs()
+	resp := make([]models.EmailTemplateDTO, len(ids))
+	for i, id := range ids {
+		resp[i] = models.EmailTemplateDTO{ID: id, Locales: i18n.Supported, Sample: h.renderer.Sample(id)}
+	}
+
+	httpx.Success(w, http.StatusOK, "Email templates fetched successfully", resp)
+}
+
+// Preview merender template dengan data contoh.
+// Query: locale (default bahasa request), format html (default), text atau json.
+func (h *EmailHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	locale := i18n.FromContext(r.Context())
+	if raw := r.URL.Query().Get("locale"); raw != "" {
+		parsed, ok := i18n.Parse(raw)
+		if !ok {
+			appErr := errors.NewCode(errors.CodeUnsupportedLocale, "unsupported locale "+raw)
+			appErr.Params = map[string]string{"supported": i18n.SupportedList()}
+			h.handlerError(w, r, appErr)
+			return
+		}
+		locale = parsed
+	}
+
+	sample := h.renderer.Sample(id)
+	if sample == nil {
+		h.handlerError(w, r, errors.New(errors.ErrNotFound, "email template not found"))
+		return
+	}
+
+	email, err := h.renderer.Render(locale, id, sample)
+	if err != nil {
+		h.handlerError(w, r, err)
+		return
+	}
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(email.HTML))
+	case "text":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(email.Text))
+	case "json":
+		httpx.Success(w, http.StatusOK, "Email template rendered successfully", email)
+	default:
+		h.handlerError(w, r, errors.New(errors.ErrValidation, "format must be html, text or json"))
+	}
+}
+
+func (h *EmailHandler) handlerError(w http.ResponseWriter, r *http.Request, err error) {
+	if appErr, ok := errors.AsAppError(err); !ok || appErr.Kind == errors.ErrInternal {
+		logger.FromContext(r.Context(), h.logger).Error("server error response", "error", err.Error())
+	}
+
+	httpx.WriteError(w, r, err)
+}
+
+func mapEmailMessageToDTO(msg *sharedModel.EmailMessage) models.EmailMessageDTO {
+	return models.EmailMessageDTO{
+		ID:                msg.ID.String(),
+		Template:          msg.Template,
+		ToEmail:           msg.ToEmail,
+		Locale:            msg.Locale,
+		Status:            string(msg.Status),
+		Attempts:          msg.Attempts,
+		ProviderMessageID: msg.ProviderMessageID,
+		LastError:         msg.LastError,
+		SentAt:            msg.SentAt,
+		CreatedAt:         msg.CreatedAt,
+		UpdatedAt:         msg.UpdatedAt,
+		DataPurgedAt:      msg.DataPurgedAt,
+	}
+}
+
+func queryInt(r *http.Request, key string, def int) int {
+	v, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil || v < 1 {
+		return def
+	}
+
+	return v
+}
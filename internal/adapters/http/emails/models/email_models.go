@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+type (
+	// ListEmailMessagesQuery query GET /admin/emails, hanya untuk dokumentasi (handler membaca query sendiri)
+	ListEmailMessagesQuery struct {
+		Status string `query:"status" validate:"omitempty,oneof=queued retrying sent failed" doc:"Empty returns every status"`
+		Page   int    `query:"page" validate:"omitempty,min=1" default:"1"`
+		Limit  int    `query:"limit" validate:"omitempty,min=1,max=100" default:"20"`
+	}
+
+	// PreviewQuery parameter GET /admin/emails/templates/{id}/preview
+	PreviewQuery struct {
+		ID     string `path:"id" example:"password_reset"`
+		Locale string `query:"locale" enum:"en id" doc:"Defaults to the request language (Accept-Language)"`
+		Format string `query:"format" validate:"omitempty,oneof=html text json" default:"html"`
+	}
+
+	// EmailMessageDTO sengaja tanpa data template karena berisi link reset dan kode OTP
+	EmailMessageDTO struct {
+		ID                string      `json:"id"`
+		Template          string      `json:"template" example:"password_reset"`
+		ToEmail           string      `json:"to_email" format:"email"`
+		Locale            i18n.Locale `json:"locale" enum:"en id"`
+		Status            string      `json:"status" enum:"queued retrying sent failed" doc:"retrying means the last attempt failed and the delivery job will retry; failed means it gave up and the message can be resent"`
+		Attempts          int         `json:"attempts"`
+		ProviderMessageID string      `json:"provider_message_id,omitempty"`
+		LastError         string      `json:"last_error,omitempty"`
+		SentAt            *time.Time  `json:"sent_at,omitempty"`
+		CreatedAt         time.Time   `json:"created_at"`
+		UpdatedAt         time.Time   `json:"updated_at"`
+		DataPurgedAt      *time.Time  `json:"data_purged_at,omitempty" doc:"Template data is removed once sent or expired; such messages cannot be resent"`
+	}
+
+	EmailTemplateDTO struct {
+		ID      string         `json:"id" example:"password_reset"`
+		Locales []i18n.Locale  `json:"locales" example:"en id"`
+		Sample  map[string]any `json:"sample"`
+	}
+)
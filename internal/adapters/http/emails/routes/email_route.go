@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"net/http"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/emails/handler"
+	"villainrsty-ecommerce-server/internal/adapters/http/emails/models"
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	"villainrsty-ecommerce-server/internal/adapters/http/middleware"
+	"villainrsty-ecommerce-server/internal/adapters/http/openapi"
+	"villainrsty-ecommerce-server/internal/adapters/notifications/templates"
+)
+
+// previewCSP preview HTML memakai inline style seperti email aslinya, script tetap diblokir
+const previewCSP = "default-src 'none'; style-src 'unsafe-inline'; img-src https: data:"
+
+func RegisterRoute(r *openapi.Router, handler *handler.EmailHandler, idempotent func(http.Handler) http.Handler) {
+	r.Route("/admin/emails", func(r *openapi.Router) {
+		r.Get("/", handler.List, openapi.Doc{
+			ID:          "listEmailMessages",
+			Summary:     "List sent and pending emails",
+			Description: "Template data (reset links, OTP codes) is never returned.",
+			Params:      models.ListEmailMessagesQuery{},
+			Responses:   []openapi.Response{{Status: http.StatusOK, Description: "List of email messages", Body: httpx.BaseResponse[[]models.EmailMessageDTO]{}}},
+			Errors:      []int{http.StatusBadRequest},
+		})
+		r.Get("/templates", handler.ListTemplates, openapi.Doc{
+			ID:        "listEmailTemplates",
+			Summary:   "List email templates with their preview data",
+			Responses: []openapi.Response{{Status: http.StatusOK, Description: "List of email templates", Body: httpx.BaseResponse[[]models.EmailTemplateDTO]{}}},
+		})
+		r.With(middleware.ContentSecurityPolicy(previewCSP)).Get("/templates/{id}/preview", handler.Preview, openapi.Doc{
+			ID:      "previewEmailTemplate",
+			Summary: "Render an email template with sample data",
+			Params:  models.PreviewQuery{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "Rendered email", Body: "", ContentType: "text/html"},
+				{Status: http.StatusOK, Body: "", ContentType: "text/plain"},
+				{Status: http.StatusOK, Body: httpx.BaseResponse[templates.Email]{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		})
+		r.Get("/{id}", handler.Get, openapi.Doc{
+			ID:        "getEmailMessage",
+			Summary:   "Get an email message with its delivery state",
+			Responses: []openapi.Response{{Status: http.StatusOK, Description: "Email message detail", Body: httpx.BaseResponse[models.EmailMessageDTO]{}}},
+			Errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+		})
+		r.With(idempotent).Post("/{id}/resend", handler.Resend, openapi.Doc{
+			ID:         "resendEmailMessage",
+			Summary:    "Queue a failed email again with its original template, data and locale",
+			Idempotent: true,
+			Responses:  []openapi.Response{{Status: http.StatusOK, Description: "Email requeued", Body: httpx.BaseResponse[string]{}}},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+		})
+	})
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/health/models"
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	healthModels "villainrsty-ecommerce-server/internal/core/health/models"
+	"villainrsty-ecommerce-server/internal/core/health/service"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+type HealthHandler struct {
+	checker   *service.HealthChecker
+	clock     sharedPorts.Clock
+	startedAt time.Time
+}
+
+// NewHealthHandler clock nil berarti jam sistem
+func NewHealthHandler(checker *service.HealthChecker, clock sharedPorts.Clock) *HealthHandler {
+	if clock == nil {
+		clock = sharedPorts.SystemClock
+	}
+
+	return &HealthHandler{checker: checker, clock: clock, startedAt: clock.Now()}
+}
+
+// Livez hanya menandakan proses masih hidup, tidak menyentuh dependency
+// supaya restart tidak dipicu oleh database yang sedang down
+func (h *HealthHandler) Livez(w http.ResponseWriter, _ *http.Request) {
+	httpx.JSON(w, http.StatusOK, models.ReportDTO{
+		Status:    string(healthModels.StatusUp),
+		Timestamp: h.clock.Now(),
+	})
+}
+
+// Readyz 503 kalau ada check critical yang gagal atau server sedang shutdown.
+// Route publik, jadi pesan error dependency tidak ikut dikirim.
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Check(r.Context())
+
+	httpx.JSON(w, reportStatusCode(report), mapReportToDTO(report, false))
+}
+
+// Detail laporan lengkap untuk operator: error tiap check beserta uptime dan memori.
+// Hanya dipasang di belakang auth admin.
+func (h *HealthHandler) Detail(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Check(r.Context())
+
+	uptime := h.clock.Now().Sub(h.startedAt)
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	httpx.JSON(w, reportStatusCode(report), models.HealthDTO{
+		ReportDTO: mapReportToDTO(report, true),
+		Uptime:    fmt.Sprintf("%dh %dm %ds", int(uptime.Hours()), int(uptime.Minutes())%60, int(uptime.Seconds())%60),
+		Memory: map[string]string{
+			"heapUsed":  fmt.Sprintf("%d MB", mem.HeapAlloc/1024/1024),
+			"heapTotal": fmt.Sprintf("%d MB", mem.HeapSys/1024/1024),
+		},
+	})
+}
+
+func reportStatusCode(report healthModels.Report) int {
+	if report.Ready() {
+		return http.StatusOK
+	}
+
+	return http.StatusServiceUnavailable
+}
+
+func mapReportToDTO(report healthModels.Report, verbose bool) models.ReportDTO {
+	checks := make([]models.CheckDTO, len(report.Checks))
+	for i, c := range report.Checks {
+		checks[i] = models.CheckDTO{
+			Name:       c.Name,
+			Status:     string(c.Status),
+			Critical:   c.Critical,
+			DurationMS: float64(c.Duration.Microseconds()) / 1000,
+			CheckedAt:  c.CheckedAt,
+			Cached:     c.Cached,
+		}
+
+		if verbose {
+			checks[i].Error = c.Error
+		}
+	}
+
+	return models.ReportDTO{
+		Status:    string(report.Status),
+		Timestamp: report.CheckedAt,
+		Checks:    checks,
+	}
+}
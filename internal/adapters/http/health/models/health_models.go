@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+type (
+	CheckDTO struct {
+		Name       string    `json:"name" example:"postgres"`
+		Status     string    `json:"status" enum:"up down"`
+		Critical   bool      `json:"critical"`
+		Error      string    `json:"error,omitempty"`
+		DurationMS float64   `json:"duration_ms"`
+		CheckedAt  time.Time `json:"checked_at"`
+		Cached     bool      `json:"cached"`
+	}
+
+	ReportDTO struct {
+		Status    string     `json:"status" enum:"up down shutting_down"`
+		Timestamp time.Time  `json:"timestamp"`
+		Checks    []CheckDTO `json:"checks,omitempty"`
+	}
+
+	HealthDTO struct {
+		ReportDTO
+		Uptime string            `json:"uptime" example:"1h 2m 3s"`
+		Memory map[string]string `json:"memory"`
+	}
+)
@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"net/http"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/health/handler"
+	"villainrsty-ecommerce-server/internal/adapters/http/health/models"
+	"villainrsty-ecommerce-server/internal/adapters/http/openapi"
+)
+
+// RegisterRoute probe publik di root, tanpa detail error dependency
+func RegisterRoute(r *openapi.Router, handler *handler.HealthHandler) {
+	r = r.Describe(openapi.Doc{Tags: []string{"General"}})
+
+	r.Get("/livez", handler.Livez, openapi.Doc{
+		ID:          "livez",
+		Summary:     "Liveness probe",
+		Description: "Only confirms the process is alive, dependencies are not checked.",
+		Responses:   []openapi.Response{{Status: http.StatusOK, Description: "Process is alive", Body: models.ReportDTO{}}},
+	})
+	r.Get("/readyz", handler.Readyz, openapi.Doc{
+		ID:      "readyz",
+		Summary: "Readiness probe",
+		Description: "Runs the dependency checks (Postgres, migration version, SMTP). Results are cached for a few seconds.\n" +
+			"Returns 503 when a critical check fails or the server is shutting down.\n" +
+			"Check errors are only shown in the admin health report.\n",
+		Responses: []openapi.Response{
+			{Status: http.StatusOK, Description: "Ready to receive traffic", Body: models.ReportDTO{}},
+			{Status: http.StatusServiceUnavailable, Description: "Not ready", Body: models.ReportDTO{}},
+		},
+	})
+	r.Get("/health", handler.Readyz, openapi.Doc{
+		ID:          "health",
+		Summary:     "Health summary",
+		Description: "Status of each check without error details. Operators can read the full report at /admin/health.",
+		Responses: []openapi.Response{
+			{Status: http.StatusOK, Description: "Healthy", Body: models.ReportDTO{}},
+			{Status: http.StatusServiceUnavailable, Description: "Unhealthy or shutting down", Body: models.ReportDTO{}},
+		},
+	})
+}
+
+// RegisterAdminRoute laporan lengkap, dipasang di grup admin yang sudah memakai auth
+func RegisterAdminRoute(r *openapi.Router, handler *handler.HealthHandler) {
+	r.Get("/admin/health", handler.Detail, openapi.Doc{
+		ID:          "getHealthReport",
+		Summary:     "Detailed health report",
+		Description: "Full report for operators, including the error of each check, uptime and memory.",
+		Responses: []openapi.Response{
+			{Status: http.StatusOK, Description: "Healthy", Body: models.HealthDTO{}},
+			{Status: http.StatusServiceUnavailable, Description: "Unhealthy or shutting down", Body: models.HealthDTO{}},
+		},
+	})
+}
@@ -0,0 +1,138 @@
+package httpx
+
+import (
+	"net/http"
+
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+)
+
+type (
+	oaResponse struct {
+		Description string              `yaml:"description"`
+		Headers     map[string]oaHeader `yaml:"headers,omitempty"`
+		Content     map[string]oaMedia  `yaml:"content"`
+	}
+
+	oaHeader struct {
+		Description string         `yaml:"description"`
+		Schema      map[string]any `yaml:"schema"`
+	}
+
+	oaMedia struct {
+		Schema map[string]any `yaml:"schema"`
+	}
+)
+
+// problemResponses nama components.responses per status
+var problemResponses = []struct {
+	name        string
+	status      int
+	description string
+}{
+	{"BadRequest", http.StatusBadRequest, "Invalid input"},
+	{"Unauthorized", http.StatusUnauthorized, "Authentication failed (token missing or invalid)"},
+	{"Forbidden", http.StatusForbidden, "Authenticated but not allowed"},
+	{"NotFound", http.StatusNotFound, "Resource not found"},
+	{"Conflict", http.StatusConflict, "Conflict with the current state, e.g. an idempotency key in use"},
+	{"PayloadTooLarge", http.StatusRequestEntityTooLarge, "Request body too large"},
+	{"TooManyRequests", http.StatusTooManyRequests, "Rate limit exceeded"},
+	{"InternalError", http.StatusInternalServerError, "Internal server error"},
+}
+
+// ProblemResponseName nama components.responses untuk status error, kosong kalau tidak ada
+func ProblemResponseName(status int) string {
+	for _, resp := range problemResponses {
+		if resp.status == status {
+			return resp.name
+		}
+	}
+
+	return ""
+}
+
+// ProblemComponents components.responses dan components.schemas untuk problem+json yang
+// dibangun dari registry kode error, sehingga dokumen selalu sesuai dengan response asli.
+func ProblemComponents() (responses, schemas map[string]any) {
+	responses = make(map[string]any, len(problemResponses))
+	for _, resp := range problemResponses {
+		responses[resp.name] = problemResponse(resp.status, resp.description)
+	}
+
+	schemas = map[string]any{
+		"Problem": problemSchema(),
+		"ProblemFieldError": map[string]any{
+			"type":     "object",
+			"required": []string{"field", "message"},
+			"properties": map[string]any{
+				"field":   map[string]any{"type": "string", "examples": []string{"email"}},
+				"message": map[string]any{"type": "string", "examples": []string{"Invalid email format"}},
+			},
+		},
+	}
+
+	return responses, schemas
+}
+
+func problemResponse(status int, description string) oaResponse {
+	var codes []string
+	for _, info := range errors.Codes() {
+		if StatusForKind(info.Kind) == status {
+			codes = append(codes, string(info.Code))
+		}
+	}
+
+	resp := oaResponse{
+		Description: description,
+		Content: map[string]oaMedia{
+			ProblemContentType: {Schema: map[string]any{
+				"allOf": []any{
+					map[string]any{"$ref": "#/components/schemas/Problem"},
+					map[string]any{"properties": map[string]any{
+						"status": map[string]any{"enum": []int{status}},
+						"code":   map[string]any{"enum": codes},
+					}},
+				},
+			}},
+		},
+	}
+
+	if status == http.StatusTooManyRequests {
+		integer := map[string]any{"type": "integer"}
+		resp.Headers = map[string]oaHeader{
+			"Retry-After":         {"Seconds to wait before retrying", integer},
+			"RateLimit-Limit":     {"Bucket capacity of the most restrictive policy", integer},
+			"RateLimit-Remaining": {"Requests left in the current window", integer},
+			"RateLimit-Reset":     {"Seconds until the bucket is full again", integer},
+			"RateLimit-Policy":    {"Applied policy, e.g. `5;w=900`", map[string]any{"type": "string"}},
+		}
+	}
+
+	return resp
+}
+
+func problemSchema() map[string]any {
+	codes := errors.Codes()
+	enum := make([]string, 0, len(codes))
+	for _, info := range codes {
+		enum = append(enum, string(info.Code))
+	}
+
+	return map[string]any{
+		"type":        "object",
+		"description": "RFC 7807 problem details. Each code is documented at GET /problems/{slug}.",
+		"required":    []string{"type", "title", "status", "code"},
+		"properties": map[string]any{
+			"type":     map[string]any{"type": "string", "examples": []string{ProblemType(errors.CodeValidation)}},
+			"title":    map[string]any{"type": "string", "examples": []string{"Validation failed"}},
+			"status":   map[string]any{"type": "integer", "examples": []int{http.StatusBadRequest}},
+			"detail":   map[string]any{"type": "string"},
+			"instance": map[string]any{"type": "string", "examples": []string{"/auth/register"}},
+			"code":     map[string]any{"type": "string", "enum": enum},
+			"errors": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"$ref": "#/components/schemas/ProblemFieldError"},
+			},
+			"retry_after": map[string]any{"type": "integer", "description": "Only for RATE_LIMITED"},
+		},
+	}
+}
@@ -0,0 +1,214 @@
+package httpx
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"sort"
+	"strings"
+
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/pkg/i18n"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	// ProblemTypeBase prefix URI "type", dokumentasi kode tersedia di GET /problems/{slug}
+	ProblemTypeBase = "/problems/"
+)
+
+// Problem response error format RFC 7807. Code dan Errors adalah extension member.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     errors.Code  `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+	// Extensions member tambahan yang ikut di level atas JSON, misal retry_after
+	Extensions map[string]any `json:"-"`
+}
+
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type plain Problem
+	if len(p.Extensions) == 0 {
+		return json.Marshal(plain(p))
+	}
+
+	base, err := json.Marshal(plain(p))
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[string]any{}
+	if err := json.Unmarshal(base, &merged); err != nil {
+		return nil, err
+	}
+
+	// member standar tidak boleh ditimpa extension
+	for k, v := range p.Extensions {
+		if _, exists := merged[k]; !exists {
+			merged[k] = v
+		}
+	}
+
+	return json.Marshal(merged)
+}
+
+// NewProblem membangun Problem dari error apa pun dalam bahasa request (lihat middleware.Locale).
+// Error selain AppError dan kind internal tidak membocorkan pesan aslinya ke client.
+func NewProblem(r *http.Request, err error) Problem {
+	appErr, ok := errors.AsAppError(err)
+	if !ok {
+		appErr = errors.New(errors.ErrInternal, "")
+	}
+
+	locale := i18n.Default
+	if r != nil {
+		locale = i18n.FromContext(r.Context())
+	}
+
+	code := appErr.ErrorCode()
+	p := Problem{
+		Type:   ProblemType(code),
+		Title:  problemTitle(locale, code),
+		Status: StatusForKind(appErr.Kind),
+		Code:   code,
+		Detail: appErr.Message,
+	}
+
+	if r != nil {
+		p.Instance = r.URL.Path
+	}
+
+	if p.Status == http.StatusInternalServerError {
+		p.Detail = ""
+		return p
+	}
+
+	if detail, ok := i18n.Lookup(locale, "error."+string(code)+".detail", appErr.Params); ok {
+		p.Detail = detail
+	}
+
+	for field, msg := range appErr.Fields {
+		if localized, ok := appErr.FieldMessages[field]; ok {
+			msg = localized.In(locale)
+		}
+		p.Errors = append(p.Errors, FieldError{Field: field, Message: msg})
+	}
+	sort.Slice(p.Errors, func(i, j int) bool { return p.Errors[i].Field < p.Errors[j].Field })
+
+	return p
+}
+
+// WriteError satu-satunya jalur untuk menulis error ke client
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	WriteProblem(w, NewProblem(r, err))
+}
+
+func WriteProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+func (p Problem) With(key string, value any) Problem {
+	p.Extensions = maps.Clone(p.Extensions)
+	if p.Extensions == nil {
+		p.Extensions = map[string]any{}
+	}
+
+	p.Extensions[key] = value
+	return p
+}
+
+func StatusForKind(kind errors.Kind) int {
+	switch kind {
+	case errors.ErrValidation:
+		return http.StatusBadRequest
+	case errors.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case errors.ErrForbidden:
+		return http.StatusForbidden
+	case errors.ErrNotFound:
+		return http.StatusNotFound
+	case errors.ErrConflict:
+		return http.StatusConflict
+	case errors.ErrRateLimited:
+		return http.StatusTooManyRequests
+	case errors.ErrTooLarge:
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ProblemType URI "type" untuk kode, misal INVALID_TOKEN -> /problems/invalid-token
+func ProblemType(code errors.Code) string {
+	return ProblemTypeBase + ProblemSlug(code)
+}
+
+func ProblemSlug(code errors.Code) string {
+	return strings.ToLower(strings.ReplaceAll(string(code), "_", "-"))
+}
+
+// CodeFromSlug kebalikan ProblemSlug
+func CodeFromSlug(slug string) errors.Code {
+	return errors.Code(strings.ToUpper(strings.ReplaceAll(slug, "-", "_")))
+}
+
+func problemTitle(locale i18n.Locale, code errors.Code) string {
+	if title, ok := i18n.Lookup(locale, "error."+string(code)+".title", nil); ok {
+		return title
+	}
+
+	if info, ok := errors.LookupCode(code); ok {
+		return info.Title
+	}
+
+	return http.StatusText(StatusForKind(code.Kind()))
+}
+
+// ProblemTypeDTO satu kode error di GET /problems
+type ProblemTypeDTO struct {
+	Type   string      `json:"type" example:"/problems/invalid-token"`
+	Code   errors.Code `json:"code" example:"INVALID_TOKEN"`
+	Title  string      `json:"title" example:"Invalid token"`
+	Status int         `json:"status" example:"401"`
+}
+
+// ProblemTypes handler dokumentasi kode error: GET /problems dan GET /problems/{slug}
+func ProblemTypes(w http.ResponseWriter, r *http.Request) {
+	locale := i18n.FromContext(r.Context())
+	describe := func(info errors.CodeInfo) ProblemTypeDTO {
+		return ProblemTypeDTO{
+			Type:   ProblemType(info.Code),
+			Code:   info.Code,
+			Title:  problemTitle(locale, info.Code),
+			Status: StatusForKind(info.Kind),
+		}
+	}
+
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		infos := errors.Codes()
+		types := make([]ProblemTypeDTO, 0, len(infos))
+		for _, info := range infos {
+			types = append(types, describe(info))
+		}
+
+		Success(w, http.StatusOK, "Problem types", types)
+		return
+	}
+
+	info, ok := errors.LookupCode(CodeFromSlug(slug))
+	if !ok {
+		WriteError(w, r, errors.NewCode(errors.CodeNotFound, "unknown problem type"))
+		return
+	}
+
+	Success(w, http.StatusOK, "Problem type", describe(info))
+}
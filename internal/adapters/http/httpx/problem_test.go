@@ -0,0 +1,131 @@
+package httpx
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+func TestWriteError_ValidationProblem(t *testing.T) {
+	err := &errors.AppError{
+		Kind:    errors.ErrValidation,
+		Message: "Validation failed",
+		Fields:  map[string]string{"password": "Minimum 8 characters required", "email": "Invalid email format"},
+	}
+
+	rec := httptest.NewRecorder()
+	WriteError(rec, httptest.NewRequest(http.MethodPost, "/auth/register", nil), err)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != ProblemContentType {
+		t.Errorf("Content-Type = %q, want %q", got, ProblemContentType)
+	}
+
+	var p Problem
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if p.Type != "/problems/validation-error" || p.Code != errors.CodeValidation || p.Instance != "/auth/register" {
+		t.Errorf("problem = %+v", p)
+	}
+	if len(p.Errors) != 2 || p.Errors[0].Field != "email" || p.Errors[1].Field != "password" {
+		t.Errorf("errors = %+v, want sorted by field", p.Errors)
+	}
+}
+
+func TestNewProblem_HidesInternalDetails(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"plain error", stderrors.New("pq: connection refused")},
+		{"internal app error", errors.Wrap(errors.ErrInternal, "failed to save refresh token", stderrors.New("boom"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProblem(nil, tt.err)
+			if p.Status != http.StatusInternalServerError || p.Code != errors.CodeInternal || p.Detail != "" {
+				t.Errorf("problem = %+v, want 500 INTERNAL_ERROR without detail", p)
+			}
+		})
+	}
+}
+
+func TestNewProblem_UsesRegisteredCode(t *testing.T) {
+	p := NewProblem(nil, errors.NewCode(errors.CodeInvalidCredentials, "invalid email or password"))
+
+	if p.Status != http.StatusUnauthorized || p.Title != "Invalid email or password" || p.Type != "/problems/invalid-credentials" {
+		t.Errorf("problem = %+v", p)
+	}
+}
+
+func TestNewProblem_Localized(t *testing.T) {
+	err := &errors.AppError{
+		Kind:          errors.ErrValidation,
+		Message:       "Validation failed",
+		Fields:        map[string]string{"email": "Invalid email format"},
+		FieldMessages: map[string]i18n.Message{"email": {Key: "validation.email"}},
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+	r = r.WithContext(i18n.WithLocale(r.Context(), i18n.ID))
+
+	p := NewProblem(r, err)
+	if p.Title != "Validasi gagal" || p.Errors[0].Message != "Format email tidak valid" {
+		t.Errorf("problem = %+v, want Indonesian title and field message", p)
+	}
+
+	p = NewProblem(r, errors.NewCode(errors.CodeInvalidCredentials, "invalid email or password"))
+	if p.Detail != "Email atau password yang Anda masukkan salah." {
+		t.Errorf("detail = %q, want Indonesian detail", p.Detail)
+	}
+}
+
+func TestCatalog_HasTitleForEveryCode(t *testing.T) {
+	for _, info := range errors.Codes() {
+		for _, locale := range i18n.Supported {
+			if _, ok := i18n.Lookup(locale, "error."+string(info.Code)+".title", nil); !ok {
+				t.Errorf("missing error.%s.title for %s", info.Code, locale)
+			}
+		}
+	}
+}
+
+func TestProblem_MarshalsExtensions(t *testing.T) {
+	p := NewProblem(nil, errors.NewCode(errors.CodeRateLimited, "too many requests")).With("retry_after", 30).With("code", "X")
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var got map[string]any
+	_ = json.Unmarshal(data, &got)
+	if got["retry_after"] != float64(30) || got["code"] != "RATE_LIMITED" {
+		t.Errorf("json = %s, want retry_after and untouched code", data)
+	}
+}
+
+func TestProblemComponents_CoverEveryCode(t *testing.T) {
+	responses, schemas := ProblemComponents()
+	if schemas["Problem"] == nil || schemas["ProblemFieldError"] == nil {
+		t.Fatalf("schemas = %v, want Problem and ProblemFieldError", schemas)
+	}
+
+	for _, info := range errors.Codes() {
+		status := StatusForKind(info.Kind)
+		name := ProblemResponseName(status)
+		if name == "" || responses[name] == nil {
+			t.Errorf("code %s (status %d) has no problem response component", info.Code, status)
+		}
+	}
+}
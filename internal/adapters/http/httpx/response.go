@@ -2,15 +2,13 @@ package httpx
 
 import (
 	"encoding/json"
+	stderrors "errors"
 	"net/http"
+
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
 )
 
 type (
-	ErrorInfo struct {
-		Code    string `json:"code"`
-		Details any    `json:"details,omitempty"`
-	}
-
 	Meta struct {
 		Page      int `json:"page"`
 		Limit     int `json:"limit"`
@@ -24,11 +22,10 @@ type (
 	}
 
 	BaseResponse[T any] struct {
-		Success bool       `json:"success"`
-		Message string     `json:"message"`
-		Data    T          `json:"data,omitempty"`
-		Error   *ErrorInfo `json:"error,omitempty"`
-		Meta    *Meta      `json:"meta,omitempty"`
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+		Data    T      `json:"data,omitempty"`
+		Meta    *Meta  `json:"meta,omitempty"`
 	}
 )
 
@@ -59,55 +56,19 @@ func SuccessWithMeta[T any](w http.ResponseWriter, status int, msg string, data
 	JSON(w, status, resp)
 }
 
-func Error(w http.ResponseWriter, status int, msg string, code string) {
-	resp := BaseResponse[any]{
-		Success: false,
-		Message: msg,
-		Data:    nil,
-		Error: &ErrorInfo{
-			Code: code,
-		},
-	}
-
-	JSON(w, status, resp)
-}
-
-func ErrorWithDetails(w http.ResponseWriter, status int, msg string, code string, details any) {
-	resp := BaseResponse[any]{
-		Success: false,
-		Message: msg,
-		Data:    nil,
-		Error: &ErrorInfo{
-			Code:    code,
-			Details: details,
-		},
-	}
-
-	JSON(w, status, resp)
-}
-
-func ValidationError(w http.ResponseWriter, filedErrors []FieldError) {
-	resp := BaseResponse[any]{
-		Success: false,
-		Message: "validation failed",
-		Data:    nil,
-		Error: &ErrorInfo{
-			Code:    "VALIDATION_ERROR",
-			Details: filedErrors,
-		},
-	}
-
-	JSON(w, http.StatusBadRequest, resp)
-}
-
 func DecodeJSON[T any](w http.ResponseWriter, r *http.Request, dst *T) bool {
 	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
 	dec := json.NewDecoder(r.Body)
 	dec.DisallowUnknownFields()
 
 	if err := dec.Decode(dst); err != nil {
-		ErrorWithDetails(w, http.StatusBadRequest, "Invalid JSON", "INVALID_JSON", err.Error())
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			WriteError(w, r, errors.NewCode(errors.CodeTooLarge, "request body too large"))
+			return false
+		}
 
+		WriteError(w, r, errors.WrapCode(errors.CodeInvalidJSON, err.Error(), err))
 		return false
 	}
 
@@ -0,0 +1,20 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+)
+
+type apiVersionKey struct{}
+
+// WithAPIVersion menyimpan versi API (v1, v2) yang melayani request
+func WithAPIVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, apiVersionKey{}, version)
+}
+
+// APIVersion versi API request, untuk handler yang dipakai bersama beberapa versi tapi
+// perilakunya sedikit berbeda. Kosong untuk route di luar grup versi (health, problems).
+func APIVersion(r *http.Request) string {
+	version, _ := r.Context().Value(apiVersionKey{}).(string)
+	return version
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	"villainrsty-ecommerce-server/internal/adapters/http/jobs/models"
+	"villainrsty-ecommerce-server/internal/core/jobs/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+
+	sharedModel "villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type JobHandler struct {
+	jobService ports.JobService
+	logger     *slog.Logger
+}
+
+func NewJobHandler(service ports.JobService, logger *slog.Logger) *JobHandler {
+	return &JobHandler{jobService: service, logger: logger}
+}
+
+func (h *JobHandler) List(w http.ResponseWriter, r *http.Request) {
+	status := sharedModel.JobStatus(r.URL.Query().Get("status"))
+	if status == "" {
+		status = sharedModel.JobStatusDead
+	}
+
+	page := queryInt(r, "page", 1)
+	limit := min(queryInt(r, "limit", 20), 100)
+
+	jobs, total, err := h.jobService.List(r.Context(), status, page, limit)
+	if err != nil {
+		h.handlerError(w, r, err)
+		return
+	}
+
+	resp := make([]models.JobDTO, len(jobs))
+	for i, job := range jobs {
+		resp[i] = mapJobToDTO(job)
+	}
+
+	totalPage := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPage++
+	}
+
+	httpx.SuccessWithMeta(w, http.StatusOK, "Jobs fetched successfully", resp, &httpx.Meta{
+		Page:      page,
+		Limit:     limit,
+		Total:     int(total),
+		TotalPage: totalPage,
+	})
+}
+
+func (h *JobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobService.Get(r.Context(), sharedModel.ID(chi.URLParam(r, "id")))
+	if err != nil {
+		h.handlerError(w, r, err)
+		return
+	}
+
+	httpx.Success(w, http.StatusOK, "Job fetched successfully", mapJobToDTO(job))
+}
+
+func (h *JobHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	id := sharedModel.ID(chi.URLParam(r, "id"))
+	if err := h.jobService.Retry(r.Context(), id); err != nil {
+		h.handlerError(w, r, err)
+		return
+	}
+
+	h.log(r).Info("dead job requeued", "job_id", id.String())
+	httpx.Success(w, http.StatusOK, "Job requeued successfully", "")
+}
+
+// log mengambil logger request dari ctx supaya log handler membawa request_id
+func (h *JobHandler) log(r *http.Request) *slog.Logger {
+	return logger.FromContext(r.Context(), h.logger)
+}
+
+func (h *JobHandler) handlerError(w http.ResponseWriter, r *http.Request, err error) {
+	if appErr, ok := errors.AsAppError(err); !ok || appErr.Kind == errors.ErrInternal {
+		h.log(r).Error("server error response", "error", err.Error())
+	}
+
+	httpx.WriteError(w, r, err)
+}
+
+func mapJobToDTO(job *sharedModel.Job) models.JobDTO {
+	return models.JobDTO{
+		ID:          job.ID.String(),
+		Type:        job.Type,
+		Status:      string(job.Status),
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+		LockedAt:    job.LockedAt,
+		LastError:   job.LastError,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+	}
+}
+
+func queryInt(r *http.Request, key string, def int) int {
+	v, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil || v < 1 {
+		return def
+	}
+
+	return v
+}
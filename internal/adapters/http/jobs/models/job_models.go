@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+type (
+	// ListJobsQuery query GET /admin/jobs, hanya untuk dokumentasi (handler membaca query sendiri)
+	ListJobsQuery struct {
+		Status string `query:"status" validate:"omitempty,oneof=pending running succeeded dead" default:"dead"`
+		Page   int    `query:"page" validate:"omitempty,min=1" default:"1"`
+		Limit  int    `query:"limit" validate:"omitempty,min=1,max=100" default:"20"`
+	}
+
+	// JobDTO sengaja tanpa payload karena bisa berisi data event (email user, dan sebagainya)
+	JobDTO struct {
+		ID          string     `json:"id"`
+		Type        string     `json:"type" example:"email.deliver"`
+		Status      string     `json:"status" enum:"pending running succeeded dead"`
+		Attempts    int        `json:"attempts"`
+		MaxAttempts int        `json:"max_attempts"`
+		RunAt       time.Time  `json:"run_at"`
+		LockedAt    *time.Time `json:"locked_at,omitempty"`
+		LastError   string     `json:"last_error,omitempty"`
+		CreatedAt   time.Time  `json:"created_at"`
+		UpdatedAt   time.Time  `json:"updated_at"`
+	}
+)
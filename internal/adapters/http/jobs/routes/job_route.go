@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"net/http"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	"villainrsty-ecommerce-server/internal/adapters/http/jobs/handler"
+	"villainrsty-ecommerce-server/internal/adapters/http/jobs/models"
+	"villainrsty-ecommerce-server/internal/adapters/http/openapi"
+)
+
+// RateLimitAdmin nama policy rate limit untuk semua route /admin, dibatasi per user
+const RateLimitAdmin = "admin"
+
+func RegisterRoute(r *openapi.Router, handler *handler.JobHandler, idempotent func(http.Handler) http.Handler) {
+	r.Route("/admin/jobs", func(r *openapi.Router) {
+		r.Get("/", handler.List, openapi.Doc{
+			ID:        "listJobs",
+			Summary:   "List background jobs by status",
+			Params:    models.ListJobsQuery{},
+			Responses: []openapi.Response{{Status: http.StatusOK, Description: "List of jobs", Body: httpx.BaseResponse[[]models.JobDTO]{}}},
+			Errors:    []int{http.StatusBadRequest},
+		})
+		r.Get("/{id}", handler.Get, openapi.Doc{
+			ID:        "getJob",
+			Summary:   "Get a background job",
+			Responses: []openapi.Response{{Status: http.StatusOK, Description: "Job detail", Body: httpx.BaseResponse[models.JobDTO]{}}},
+			Errors:    []int{http.StatusNotFound},
+		})
+		r.With(idempotent).Post("/{id}/retry", handler.Retry, openapi.Doc{
+			ID:         "retryJob",
+			Summary:    "Requeue a dead job",
+			Idempotent: true,
+			Responses:  []openapi.Response{{Status: http.StatusOK, Description: "Job requeued", Body: httpx.BaseResponse[string]{}}},
+			Errors:     []int{http.StatusNotFound, http.StatusConflict},
+		})
+	})
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+)
+
+// AdminOnly membatasi akses hanya untuk user dengan role admin (dari claim JWT) atau yang
+// email-nya ada di allowlist admin. Harus dipasang setelah AuthJWT supaya user sudah ada di context.
+func AdminOnly(adminEmails []string) func(http.Handler) http.Handler {
+	allowed := make(map[string]struct{}, len(adminEmails))
+	for _, email := range adminEmails {
+		allowed[strings.ToLower(strings.TrimSpace(email))] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := GetUserFromContext(*r)
+			if user == nil {
+				httpx.WriteError(w, r, errors.NewCode(errors.CodeMissingToken, "missing authorization token"))
+				return
+			}
+
+			if _, ok := allowed[strings.ToLower(user.Email)]; !ok && !user.IsAdmin() {
+				httpx.WriteError(w, r, errors.NewCode(errors.CodeForbidden, "access denied"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
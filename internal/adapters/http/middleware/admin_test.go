@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+func TestAdminOnly(t *testing.T) {
+	tests := []struct {
+		name string
+		user *models.User
+		want int
+	}{
+		{"role admin", &models.User{Email: "ops@mail.com", Role: models.RoleAdmin}, http.StatusOK},
+		{"email allowlist", &models.User{Email: "Boss@Mail.com", Role: models.RoleUser}, http.StatusOK},
+		{"regular user", &models.User{Email: "budi@mail.com", Role: models.RoleUser}, http.StatusForbidden},
+		{"no user", nil, http.StatusUnauthorized},
+	}
+
+	h := AdminOnly([]string{"boss@mail.com"})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/jobs", nil)
+			if tt.user != nil {
+				req = req.WithContext(context.WithValue(req.Context(), userContextKey, tt.user))
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -6,26 +6,39 @@ import (
 	"strings"
 
 	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
-	"villainrsty-ecommerce-server/internal/core/auth/models"
 	"villainrsty-ecommerce-server/internal/core/auth/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/i18n"
 )
 
+type contextKey string
+
+const userContextKey contextKey = "user"
+
 func AuthJWT(jwtService ports.JWTService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			token := extractToken(r)
 			if token == "" {
-				httpx.Error(w, http.StatusUnauthorized, "missing authorization token", "MISSING_AUTHORIZATION_TOKEN")
+				httpx.WriteError(w, r, errors.NewCode(errors.CodeMissingToken, "missing authorization token"))
 				return
 			}
 
 			user, err := jwtService.ValidateToken(token)
 			if err != nil {
-				httpx.ErrorWithDetails(w, http.StatusUnauthorized, "invalid token", "INVALID_TOKEN", err.Error())
+				httpx.WriteError(w, r, errors.WrapCode(errors.CodeInvalidToken, "invalid token", err))
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), "user", user)
+			setRequestUser(r.Context(), user.ID.String())
+			ctx := context.WithValue(r.Context(), userContextKey, user)
+
+			// tanpa Accept-Language yang didukung, response memakai bahasa pilihan user
+			if _, ok := i18n.Negotiate(r.Header.Get("Accept-Language")); !ok && user.Locale != "" {
+				ctx = i18n.WithLocale(ctx, user.Locale)
+				w.Header().Set("Content-Language", string(user.Locale))
+			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -46,7 +59,7 @@ func extractToken(r *http.Request) string {
 }
 
 func GetUserFromContext(r http.Request) *models.User {
-	user, ok := r.Context().Value("user").(*models.User)
+	user, ok := r.Context().Value(userContextKey).(*models.User)
 	if !ok {
 		return nil
 	}
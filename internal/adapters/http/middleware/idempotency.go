@@ -0,0 +1,213 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	"villainrsty-ecommerce-server/internal/core/idempotency/models"
+	"villainrsty-ecommerce-server/internal/core/idempotency/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+	"villainrsty-ecommerce-server/pkg/logger"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotencyBodyBytes   = 1 << 20
+	maxIdempotencyStoredBytes = 1 << 20
+)
+
+type IdempotencyOptions struct {
+	// TTL lama response disimpan untuk di-replay
+	TTL time.Duration
+	// LockTimeout batas request "processing" dianggap mati dan key boleh diambil alih
+	LockTimeout time.Duration
+	// Clock sumber waktu untuk TTL & lock, default jam sistem
+	Clock sharedPorts.Clock
+}
+
+// Idempotency mengaktifkan header Idempotency-Key untuk request POST/PATCH.
+// Request tanpa header diteruskan apa adanya. Jangan dipasang di route yang response-nya
+// berisi kredensial (login, refresh) karena response disimpan utuh di database.
+func Idempotency(store ports.IdempotencyStore, opts IdempotencyOptions, baseLogger *slog.Logger) func(http.Handler) http.Handler {
+	if opts.TTL <= 0 {
+		opts.TTL = 24 * time.Hour
+	}
+
+	if opts.LockTimeout <= 0 {
+		opts.LockTimeout = time.Minute
+	}
+
+	if opts.Clock == nil {
+		opts.Clock = sharedPorts.SystemClock
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log := logger.FromContext(r.Context(), baseLogger)
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || (r.Method != http.MethodPost && r.Method != http.MethodPatch) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				httpx.WriteError(w, r, errors.NewCode(errors.CodeInvalidIdempotencyKey, "idempotency key is too long"))
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotencyBodyBytes+1))
+			if err != nil {
+				httpx.WriteError(w, r, errors.WrapCode(errors.CodeInvalidBody, "failed to read request body", err))
+				return
+			}
+
+			if len(body) > maxIdempotencyBodyBytes {
+				httpx.WriteError(w, r, errors.NewCode(errors.CodeTooLarge, "request body too large"))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			now := opts.Clock.Now()
+			scope := idempotencyScope(r)
+			record := models.NewRecord(scope, key, r.Method, r.URL.Path, models.Fingerprint(r.Method, r.URL.Path, body), now, opts.TTL)
+
+			existing, acquired, err := store.Acquire(r.Context(), record, now.Add(-opts.LockTimeout))
+			if err != nil {
+				if errors.IsKind(err, errors.ErrConflict) {
+					httpx.WriteError(w, r, errors.NewCode(errors.CodeIdempotencyKeyInUse, "a request with this idempotency key is in progress"))
+					return
+				}
+
+				log.Error("idempotency store failed", "error", err)
+				httpx.WriteError(w, r, errors.Wrap(errors.ErrInternal, "idempotency store failed", err))
+				return
+			}
+
+			if !acquired {
+				switch {
+				case !existing.Matches(record.Fingerprint):
+					httpx.WriteError(w, r, errors.NewCode(errors.CodeIdempotencyMismatch, "idempotency key was used with a different request"))
+				case existing.IsCompleted():
+					replayResponse(w, existing.Response)
+				default:
+					httpx.WriteError(w, r, errors.NewCode(errors.CodeIdempotencyKeyInUse, "a request with this idempotency key is in progress"))
+				}
+				return
+			}
+
+			// header dari middleware luar (CORS, security header, locale) dipasang ulang saat replay,
+			// jadi yang disimpan hanya header yang ditambahkan handler
+			outer := w.Header().Clone()
+			rec := &capturingWriter{ResponseWriter: w, status: http.StatusOK}
+			completed := false
+			defer func() {
+				// Handler panic atau 5xx: key dilepas supaya client bisa retry dengan key yang sama
+				if completed {
+					return
+				}
+
+				if err := store.Release(context.WithoutCancel(r.Context()), scope, key); err != nil {
+					log.Error("failed to release idempotency key", "error", err)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError || rec.overflow {
+				return
+			}
+
+			resp := &models.Response{
+				StatusCode: rec.status,
+				Header:     storableHeader(w.Header(), outer),
+				Body:       rec.body.Bytes(),
+			}
+
+			finishedAt := opts.Clock.Now()
+			if err := store.Complete(context.WithoutCancel(r.Context()), scope, key, resp, finishedAt, finishedAt.Add(opts.TTL)); err != nil {
+				log.Error("failed to store idempotent response", "error", err)
+				return
+			}
+
+			completed = true
+		})
+	}
+}
+
+// idempotencyScope memisahkan key antar user; request anonim dipisah per IP klien
+// supaya key yang sama dari klien lain tidak mendapat response milik orang lain
+func idempotencyScope(r *http.Request) string {
+	return KeyByUser(r)
+}
+
+func replayResponse(w http.ResponseWriter, resp *models.Response) {
+	for k, values := range resp.Header {
+		w.Header()[http.CanonicalHeaderKey(k)] = append([]string(nil), values...)
+	}
+
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(resp.Body)
+}
+
+// storableHeader membuang header yang sudah ada sebelum handler jalan (outer) dan header
+// yang spesifik ke satu request (tanggal, request id, kuota rate limit)
+func storableHeader(h, outer http.Header) http.Header {
+	out := http.Header{}
+	for k, values := range h {
+		canonical := http.CanonicalHeaderKey(k)
+		if canonical == "Date" || canonical == "Retry-After" || canonical == http.CanonicalHeaderKey(RequestIDHeader) ||
+			strings.HasPrefix(canonical, "Ratelimit-") || slices.Equal(values, outer[canonical]) {
+			continue
+		}
+
+		out[canonical] = append([]string(nil), values...)
+	}
+
+	return out
+}
+
+// capturingWriter meneruskan response ke client sambil menyimpan salinannya
+type capturingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	overflow    bool
+}
+
+func (c *capturingWriter) WriteHeader(status int) {
+	if !c.wroteHeader {
+		c.status = status
+		c.wroteHeader = true
+	}
+
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *capturingWriter) Write(b []byte) (int, error) {
+	if !c.wroteHeader {
+		c.WriteHeader(http.StatusOK)
+	}
+
+	if !c.overflow {
+		if c.body.Len()+len(b) > maxIdempotencyStoredBytes {
+			c.overflow = true
+			c.body.Reset()
+		} else {
+			c.body.Write(b)
+		}
+	}
+
+	return c.ResponseWriter.Write(b)
+}
@@ -0,0 +1,198 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/idempotency/models"
+)
+
+type fakeIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*models.Record
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{records: map[string]*models.Record{}}
+}
+
+func (s *fakeIdempotencyStore) Acquire(_ context.Context, record *models.Record, staleBefore time.Time) (*models.Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := record.Scope + "|" + record.Key
+	existing, ok := s.records[id]
+	if ok && record.LockedAt.Before(existing.ExpiresAt) &&
+		!(existing.Status == models.StatusProcessing && !existing.LockedAt.After(staleBefore)) {
+		return existing, false, nil
+	}
+
+	cp := *record
+	s.records[id] = &cp
+	return &cp, true, nil
+}
+
+func (s *fakeIdempotencyStore) Complete(_ context.Context, scope, key string, resp *models.Response, _, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.records[scope+"|"+key]
+	r.Status = models.StatusCompleted
+	r.Response = resp
+	r.ExpiresAt = expiresAt
+	return nil
+}
+
+func (s *fakeIdempotencyStore) Release(_ context.Context, scope, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.records[scope+"|"+key]; ok && r.Status == models.StatusProcessing {
+		delete(s.records, scope+"|"+key)
+	}
+	return nil
+}
+
+func newIdempotentHandler(store *fakeIdempotencyStore, calls *int, status int) http.Handler {
+	mw := Idempotency(store, IdempotencyOptions{TTL: time.Hour}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("RateLimit-Remaining", "3")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(*calls) + `,"echo":` + string(body) + `}`))
+	}))
+}
+
+func idempotentRequest(key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(body))
+	req.Header.Set(IdempotencyKeyHeader, key)
+	return req
+}
+
+func TestIdempotency_ReplaysCompletedResponse(t *testing.T) {
+	store := newFakeIdempotencyStore()
+	calls := 0
+	h := newIdempotentHandler(store, &calls, http.StatusCreated)
+
+	first := httptest.NewRecorder()
+	h.ServeHTTP(first, idempotentRequest("key-1", `{"a":1}`))
+
+	second := httptest.NewRecorder()
+	h.ServeHTTP(second, idempotentRequest("key-1", `{"a":1}`))
+
+	if calls != 1 {
+		t.Fatalf("handler calls = %d, want 1", calls)
+	}
+
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("replay = %d %q, want %d %q", second.Code, second.Body.String(), first.Code, first.Body.String())
+	}
+
+	if second.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Error("replayed response should carry Idempotent-Replayed header")
+	}
+
+	if second.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type = %q, want stored header", second.Header().Get("Content-Type"))
+	}
+
+	if second.Header().Get("RateLimit-Remaining") != "" {
+		t.Error("per-request RateLimit headers should not be replayed")
+	}
+}
+
+func TestIdempotency_RejectsDifferentBody(t *testing.T) {
+	store := newFakeIdempotencyStore()
+	calls := 0
+	h := newIdempotentHandler(store, &calls, http.StatusOK)
+
+	h.ServeHTTP(httptest.NewRecorder(), idempotentRequest("key-1", `{"a":1}`))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, idempotentRequest("key-1", `{"a":2}`))
+
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "IDEMPOTENCY_KEY_MISMATCH") {
+		t.Errorf("status = %d body = %s, want 409 IDEMPOTENCY_KEY_MISMATCH", rec.Code, rec.Body.String())
+	}
+}
+
+func TestIdempotency_RejectsConcurrentDuplicate(t *testing.T) {
+	store := newFakeIdempotencyStore()
+	now := time.Now()
+	// httptest.NewRequest memakai RemoteAddr 192.0.2.1
+	record := models.NewRecord("ip:192.0.2.1", "key-1", http.MethodPost, "/auth/register",
+		models.Fingerprint(http.MethodPost, "/auth/register", []byte(`{"a":1}`)), now, time.Hour)
+	store.records["ip:192.0.2.1|key-1"] = record
+
+	calls := 0
+	h := newIdempotentHandler(store, &calls, http.StatusOK)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, idempotentRequest("key-1", `{"a":1}`))
+
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "IDEMPOTENCY_KEY_IN_USE") {
+		t.Errorf("status = %d body = %s, want 409 IDEMPOTENCY_KEY_IN_USE", rec.Code, rec.Body.String())
+	}
+
+	if calls != 0 {
+		t.Errorf("handler calls = %d, want 0", calls)
+	}
+}
+
+func TestIdempotency_AnonymousKeysAreScopedByClientIP(t *testing.T) {
+	store := newFakeIdempotencyStore()
+	calls := 0
+	h := newIdempotentHandler(store, &calls, http.StatusCreated)
+
+	h.ServeHTTP(httptest.NewRecorder(), idempotentRequest("key-1", `{"a":1}`))
+
+	other := idempotentRequest("key-1", `{"a":1}`)
+	other.RemoteAddr = "198.51.100.7:4321"
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, other)
+
+	if calls != 2 || rec.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Errorf("calls = %d replayed = %q, want the other client to run its own request", calls, rec.Header().Get(IdempotentReplayedHeader))
+	}
+}
+
+func TestIdempotency_ReleasesKeyOnServerError(t *testing.T) {
+	store := newFakeIdempotencyStore()
+	calls := 0
+	h := newIdempotentHandler(store, &calls, http.StatusInternalServerError)
+
+	h.ServeHTTP(httptest.NewRecorder(), idempotentRequest("key-1", `{"a":1}`))
+	h.ServeHTTP(httptest.NewRecorder(), idempotentRequest("key-1", `{"a":1}`))
+
+	if calls != 2 {
+		t.Errorf("handler calls = %d, want 2 (5xx must not be stored)", calls)
+	}
+
+	if len(store.records) != 0 {
+		t.Errorf("records = %d, want key released", len(store.records))
+	}
+}
+
+func TestIdempotency_WithoutHeaderPassesThrough(t *testing.T) {
+	store := newFakeIdempotencyStore()
+	calls := 0
+	h := newIdempotentHandler(store, &calls, http.StatusOK)
+
+	for i := 0; i < 2; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(`{}`)))
+	}
+
+	if calls != 2 || len(store.records) != 0 {
+		t.Errorf("calls = %d records = %d, want 2 calls and nothing stored", calls, len(store.records))
+	}
+}
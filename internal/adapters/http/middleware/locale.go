@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+// Locale memilih bahasa response dari Accept-Language dan menyimpannya di context.
+// Bahasa yang tidak didukung jatuh ke i18n.Default. Content-Language ikut dikirim.
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale, ok := i18n.Negotiate(r.Header.Get("Accept-Language"))
+		if !ok {
+			locale = i18n.Default
+		}
+
+		w.Header().Set("Content-Language", string(locale))
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), locale)))
+	})
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+func TestLocale(t *testing.T) {
+	tests := []struct {
+		header string
+		want   i18n.Locale
+	}{
+		{"id-ID,id;q=0.9", i18n.ID},
+		{"en-US", i18n.EN},
+		{"fr-FR", i18n.Default},
+		{"", i18n.Default},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			var got i18n.Locale
+			h := Locale(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = i18n.FromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Language", tt.header)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if got != tt.want {
+				t.Errorf("locale = %q, want %q", got, tt.want)
+			}
+			if cl := rec.Header().Get("Content-Language"); cl != string(tt.want) {
+				t.Errorf("Content-Language = %q, want %q", cl, tt.want)
+			}
+		})
+	}
+}
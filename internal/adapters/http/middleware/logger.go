@@ -1,59 +1,127 @@
 package middleware
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 
+	"villainrsty-ecommerce-server/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// ANSI colors
-const (
-	reset  = "\033[0m"
-	green  = "\033[32m"
-	yellow = "\033[33m"
-	red    = "\033[31m"
-	cyan   = "\033[36m"
-	gray   = "\033[90m"
-)
+const requestInfoContextKey contextKey = "request_info"
+
+// requestInfo diisi oleh middleware yang lebih dalam (misal AuthJWT) setelah logger dibuat
+type requestInfo struct {
+	mu     sync.RWMutex
+	userID string
+}
+
+func (i *requestInfo) setUserID(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.userID = id
+}
+
+func (i *requestInfo) getUserID() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.userID
+}
+
+// requestLogHandler menambahkan route dan user_id di setiap record. Nilainya dibaca saat log
+// ditulis karena pola route chi dan user baru lengkap setelah routing & autentikasi.
+type requestLogHandler struct {
+	slog.Handler
+	info *requestInfo
+	rctx *chi.Context
+}
+
+func (h *requestLogHandler) Handle(ctx context.Context, rec slog.Record) error {
+	rec = rec.Clone()
+
+	if h.rctx != nil {
+		if route := h.rctx.RoutePattern(); route != "" {
+			rec.AddAttrs(slog.String("route", route))
+		}
+	}
 
-// Helper untuk warna status
-func statusColor(code int) string {
-	switch {
-	case code >= 200 && code < 300:
-		return green
-	case code >= 300 && code < 400:
-		return cyan
-	case code >= 400 && code < 500:
-		return yellow
-	default:
-		return red
+	if userID := h.info.getUserID(); userID != "" {
+		rec.AddAttrs(slog.String("user_id", userID))
 	}
+
+	return h.Handler.Handle(ctx, rec)
+}
+
+func (h *requestLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &requestLogHandler{Handler: h.Handler.WithAttrs(attrs), info: h.info, rctx: h.rctx}
 }
 
-func Logger(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
+func (h *requestLogHandler) WithGroup(name string) slog.Handler {
+	return &requestLogHandler{Handler: h.Handler.WithGroup(name), info: h.info, rctx: h.rctx}
+}
+
+// RequestLogger memasang logger per request di context (request_id, user_id, route) dan
+// menulis satu baris access log setelah response selesai. Pasang setelah RequestID dan Tracing.
+func RequestLogger(base *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			info := &requestInfo{}
+
+			l := slog.New(&requestLogHandler{
+				Handler: base.Handler(),
+				info:    info,
+				rctx:    chi.RouteContext(r.Context()),
+			}).With(
+				"request_id", GetRequestID(r.Context()),
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
 
-		// 1. GUNAKAN WRAPPER BAWAAN CHI
-		// Ini menggantikan struct `responseWriter` manual yang kamu buat sebelumnya.
-		// Wrapper ini otomatis menangkap Status Code dan Bytes Written.
-		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			// trace_id supaya baris log bisa dicocokkan dengan span di backend tracing
+			if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+				l = l.With("trace_id", sc.TraceID().String())
+			}
 
-		// 2. Jalankan handler selanjutnya
-		// Penting: Masukkan `ww` (wrapper), bukan `w` asli.
-		next.ServeHTTP(ww, r)
+			ctx := context.WithValue(r.Context(), requestInfoContextKey, info)
+			ctx = logger.WithContext(ctx, l)
 
-		// 3. Ambil Status Code dari wrapper Chi
-		status := ww.Status()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
 
-		// 4. Print Output (Sesuai format kode kamu)
-		fmt.Printf("%s%s%s %s %s%d%s %s%.2fms%s\n",
-			cyan, r.Method, reset,
-			r.URL.Path,
-			statusColor(status), status, reset,
-			gray, float64(time.Since(start).Microseconds())/1000, reset,
-		)
-	})
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			level := slog.LevelInfo
+			switch {
+			case status >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case status >= http.StatusBadRequest:
+				level = slog.LevelWarn
+			}
+
+			l.LogAttrs(r.Context(), level, "http request",
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+				slog.String("remote_ip", KeyByIP(r)),
+				slog.String("user_agent", r.UserAgent()),
+			)
+		})
+	}
+}
+
+// setRequestUser mencatat user yang sudah terautentikasi ke request logger
+func setRequestUser(ctx context.Context, userID string) {
+	if info, ok := ctx.Value(requestInfoContextKey).(*requestInfo); ok {
+		info.setUserID(userID)
+	}
 }
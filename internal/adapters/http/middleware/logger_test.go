@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"villainrsty-ecommerce-server/pkg/logger"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var lines []map[string]any
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var line map[string]any
+		if err := json.Unmarshal([]byte(raw), &line); err != nil {
+			t.Fatalf("invalid log line %q: %v", raw, err)
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func TestRequestLogger_CorrelatesHandlerAndAccessLogs(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	r := chi.NewRouter()
+	r.Use(RequestID, RequestLogger(base))
+	r.With(func(next http.Handler) http.Handler {
+		// simulasi AuthJWT: user baru diketahui setelah logger dibuat
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			setRequestUser(r.Context(), "user-1")
+			next.ServeHTTP(w, r.WithContext(r.Context()))
+		})
+	}).Get("/orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context(), nil).Info("loading order")
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+	req.Header.Set(RequestIDHeader, "req-abc")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get(RequestIDHeader); got != "req-abc" {
+		t.Errorf("response X-Request-ID = %q, want req-abc", got)
+	}
+
+	lines := decodeLogLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("log lines = %d, want 2 (handler + access)", len(lines))
+	}
+
+	for _, line := range lines {
+		if line["request_id"] != "req-abc" || line["route"] != "/orders/{id}" || line["user_id"] != "user-1" {
+			t.Errorf("log line missing request attrs: %v", line)
+		}
+	}
+
+	access := lines[1]
+	if access["msg"] != "http request" || access["level"] != "WARN" || access["status"] != float64(http.StatusNotFound) {
+		t.Errorf("access line = %v, want WARN http request with status 404", access)
+	}
+}
+
+func TestRequestID_ReplacesInvalidHeader(t *testing.T) {
+	var got string
+	h := RequestID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = GetRequestID(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "bad id\nwith newline")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got == "" || strings.ContainsAny(got, " \n") {
+		t.Errorf("request id = %q, want generated id", got)
+	}
+
+	if rec.Header().Get(RequestIDHeader) != got {
+		t.Errorf("response header = %q, want %q", rec.Header().Get(RequestIDHeader), got)
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type recordedObservation struct {
+	method string
+	route  string
+	status int
+}
+
+type fakeHTTPMetrics struct {
+	observations []recordedObservation
+}
+
+func (f *fakeHTTPMetrics) ObserveHTTPRequest(method, route string, status int, _ time.Duration) {
+	f.observations = append(f.observations, recordedObservation{method, route, status})
+}
+
+func TestMetrics_LabelsByRoutePattern(t *testing.T) {
+	recorder := &fakeHTTPMetrics{}
+
+	r := chi.NewRouter()
+	r.Use(Metrics(recorder))
+	r.Get("/jobs/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	for _, path := range []string{"/jobs/1", "/jobs/2", "/unknown/path"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	want := []recordedObservation{
+		{http.MethodGet, "/jobs/{id}", http.StatusNoContent},
+		{http.MethodGet, "/jobs/{id}", http.StatusNoContent},
+		{http.MethodGet, unmatchedRoute, http.StatusNotFound},
+	}
+
+	if len(recorder.observations) != len(want) {
+		t.Fatalf("observations = %+v, want %+v", recorder.observations, want)
+	}
+
+	for i, got := range recorder.observations {
+		if got != want[i] {
+			t.Errorf("observation[%d] = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestMetrics_UnknownMethodsShareOneLabel(t *testing.T) {
+	recorder := &fakeHTTPMetrics{}
+
+	r := chi.NewRouter()
+	r.Use(Metrics(recorder))
+	r.Get("/jobs", func(w http.ResponseWriter, _ *http.Request) {})
+
+	for _, method := range []string{"FOO", "BAR", http.MethodGet} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/jobs", nil))
+	}
+
+	got := []string{recorder.observations[0].method, recorder.observations[1].method, recorder.observations[2].method}
+	if got[0] != otherMethod || got[1] != otherMethod || got[2] != http.MethodGet {
+		t.Errorf("method labels = %v, want [%s %s GET]", got, otherMethod, otherMethod)
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	"villainrsty-ecommerce-server/internal/adapters/http/openapi"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/pkg/logger"
+)
+
+// OpenAPIValidator memvalidasi request dan response terhadap dokumen OpenAPI, hanya untuk dev/test.
+// Request yang tidak sesuai ditolak dengan VALIDATION_ERROR sebelum sampai handler; response yang
+// tidak sesuai tetap dikirim apa adanya tapi dicatat sebagai error supaya drift kelihatan di log.
+// Route yang tidak ada di dokumen mana pun (metrics, docs, alias legacy) diteruskan tanpa validasi.
+// Dokumen baru dibangun saat request pertama, setelah semua route terdaftar.
+func OpenAPIValidator(baseLogger *slog.Logger, docs ...*openapi.Document) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log := logger.FromContext(r.Context(), baseLogger)
+
+			op, params, ok := findOperation(docs, r, log)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if err := op.ValidateRequest(r, params); err != nil {
+				log.Warn("request does not match openapi spec", "operation", op.ID, "error", err)
+				httpx.WriteError(w, r, violationsError(err))
+				return
+			}
+
+			rec := &capturingWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.overflow {
+				return
+			}
+
+			if err := op.ValidateResponse(rec.status, w.Header(), rec.body.Bytes()); err != nil {
+				log.Error("response does not match openapi spec", "operation", op.ID, "status", rec.status, "error", err)
+			}
+		})
+	}
+}
+
+// findOperation mencari operation di dokumen pertama yang memuatnya, route umum (health)
+// tercatat di semua dokumen versi dengan definisi yang sama
+func findOperation(docs []*openapi.Document, r *http.Request, log *slog.Logger) (openapi.Operation, map[string]string, bool) {
+	for _, doc := range docs {
+		spec, err := doc.Spec()
+		if err != nil {
+			log.Error("openapi validation skipped", "error", err)
+			continue
+		}
+
+		if op, params, ok := spec.Find(r.Method, r.URL.Path); ok {
+			return op, params, true
+		}
+	}
+
+	return openapi.Operation{}, nil, false
+}
+
+func violationsError(err error) *errors.AppError {
+	appErr := errors.NewCode(errors.CodeValidation, "request does not match the API specification")
+	violations, ok := err.(openapi.Violations)
+	if !ok {
+		return appErr
+	}
+
+	appErr.Fields = map[string]string{}
+	for _, v := range violations {
+		field := v.Field
+		if field == "" {
+			field = "body"
+		}
+		appErr.Fields[field] = v.Message
+	}
+
+	return appErr
+}
@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	"villainrsty-ecommerce-server/internal/core/ratelimit/models"
+	"villainrsty-ecommerce-server/internal/core/ratelimit/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+	"villainrsty-ecommerce-server/pkg/logger"
+)
+
+const maxRateLimitBodyBytes = 1 << 20
+
+// RateLimitKeyFunc menentukan identitas yang dibatasi. String kosong berarti policy dilewati.
+type RateLimitKeyFunc func(r *http.Request) string
+
+type RateLimitPolicy struct {
+	Limit models.Limit
+	Key   RateLimitKeyFunc
+}
+
+type RateLimiter struct {
+	store    ports.BucketStore
+	policies map[string]RateLimitPolicy
+	logger   *slog.Logger
+	clock    sharedPorts.Clock
+}
+
+// NewRateLimiter clock nil berarti jam sistem
+func NewRateLimiter(store ports.BucketStore, policies map[string]RateLimitPolicy, clock sharedPorts.Clock, logger *slog.Logger) *RateLimiter {
+	if clock == nil {
+		clock = sharedPorts.SystemClock
+	}
+
+	return &RateLimiter{
+		store:    store,
+		policies: policies,
+		logger:   logger,
+		clock:    clock,
+	}
+}
+
+// Limit mengembalikan middleware untuk policy dengan nama tertentu.
+// Policy yang tidak dikonfigurasi (atau limiter nil) tidak membatasi apa pun.
+func (l *RateLimiter) Limit(name string) func(http.Handler) http.Handler {
+	if l == nil {
+		return passthrough
+	}
+
+	policy, ok := l.policies[name]
+	if !ok || policy.Limit.IsZero() || policy.Key == nil {
+		return passthrough
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := policy.Key(r)
+			if id == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result, err := l.store.Take(r.Context(), name+":"+id, policy.Limit, l.clock.Now())
+			if err != nil {
+				// fail open: gangguan store tidak boleh membuat seluruh API down
+				logger.FromContext(r.Context(), l.logger).Error("rate limit store failed", "policy", name, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			setRateLimitHeaders(w, policy.Limit, result)
+
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				problem := httpx.NewProblem(r, errors.NewCode(errors.CodeRateLimited, "too many requests"))
+				httpx.WriteProblem(w, problem.With("retry_after", ceilSeconds(result.RetryAfter)))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setRateLimitHeaders menulis header RateLimit-*. Kalau beberapa policy berlaku di satu route,
+// yang dilaporkan adalah policy dengan sisa kuota paling sedikit.
+func setRateLimitHeaders(w http.ResponseWriter, limit models.Limit, result models.Result) {
+	h := w.Header()
+	if current := h.Get("RateLimit-Remaining"); current != "" {
+		if remaining, err := strconv.Atoi(current); err == nil && remaining <= result.Remaining {
+			return
+		}
+	}
+
+	h.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+	h.Set("RateLimit-Policy", limit.String())
+}
+
+// KeyByIP membatasi per alamat IP klien. Pasang chi middleware.RealIP di depan kalau di belakang proxy.
+func KeyByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// KeyByUser membatasi per user yang login, fallback ke IP kalau belum ada user di context
+func KeyByUser(r *http.Request) string {
+	if user := GetUserFromContext(*r); user != nil {
+		return "user:" + user.ID.String()
+	}
+
+	return "ip:" + KeyByIP(r)
+}
+
+// KeyByJSONField membatasi per nilai field di body JSON (misal email). Body dikembalikan utuh untuk handler.
+func KeyByJSONField(field string) RateLimitKeyFunc {
+	return func(r *http.Request) string {
+		if r.Body == nil {
+			return ""
+		}
+
+		// Sisa body yang belum terbaca tetap disambung supaya handler menerima body lengkap
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRateLimitBodyBytes))
+		r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+		if err != nil {
+			return ""
+		}
+
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return ""
+		}
+
+		value, ok := payload[field].(string)
+		if !ok {
+			return ""
+		}
+
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func passthrough(next http.Handler) http.Handler {
+	return next
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
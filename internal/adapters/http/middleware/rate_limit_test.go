@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/memory"
+	"villainrsty-ecommerce-server/internal/core/ratelimit/models"
+	"villainrsty-ecommerce-server/pkg/clock"
+)
+
+func newTestLimiter(policies map[string]RateLimitPolicy) (*RateLimiter, *clock.Fake) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	return NewRateLimiter(memory.NewRateLimitStore(), policies, clk, slog.New(slog.NewTextHandler(io.Discard, nil))), clk
+}
+
+func TestRateLimit_BlocksAfterLimit(t *testing.T) {
+	limiter, clk := newTestLimiter(map[string]RateLimitPolicy{
+		"test": {Limit: models.Limit{Requests: 2, Per: time.Minute}, Key: KeyByIP},
+	})
+	h := limiter.Limit("test")(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("request %d status = %d, want 204", i+1, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want 30", got)
+	}
+
+	if got := rec.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Errorf("RateLimit-Remaining = %q, want 0", got)
+	}
+
+	if got := rec.Header().Get("RateLimit-Policy"); got != "2;w=60" {
+		t.Errorf("RateLimit-Policy = %q, want 2;w=60", got)
+	}
+
+	if got := rec.Header().Get("Content-Type"); got != "application/problem+json" {
+		t.Errorf("Content-Type = %q, want application/problem+json", got)
+	}
+
+	var body struct {
+		Status     int    `json:"status"`
+		Code       string `json:"code"`
+		RetryAfter int    `json:"retry_after"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+
+	if body.Status != http.StatusTooManyRequests || body.Code != "RATE_LIMITED" || body.RetryAfter != 30 {
+		t.Errorf("body = %+v, want RATE_LIMITED problem with retry_after 30", body)
+	}
+
+	// token terisi lagi mengikuti clock, bukan jam sistem
+	clk.Advance(30 * time.Second)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status after refill = %d, want 204", rec.Code)
+	}
+}
+
+func TestRateLimit_KeyByJSONFieldKeepsBody(t *testing.T) {
+	limiter, _ := newTestLimiter(map[string]RateLimitPolicy{
+		"email": {Limit: models.Limit{Requests: 1, Per: time.Hour}, Key: KeyByJSONField("email")},
+	})
+
+	var gotBody string
+	h := limiter.Limit("email")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func(body string) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		return rec.Code
+	}
+
+	if code := send(`{"email":"Budi@Mail.com"}`); code != http.StatusOK {
+		t.Fatalf("first status = %d, want 200", code)
+	}
+
+	if gotBody != `{"email":"Budi@Mail.com"}` {
+		t.Errorf("handler body = %q, want original body", gotBody)
+	}
+
+	if code := send(`{"email":"budi@mail.com "}`); code != http.StatusTooManyRequests {
+		t.Errorf("same email status = %d, want 429", code)
+	}
+
+	if code := send(`{"email":"siti@mail.com"}`); code != http.StatusOK {
+		t.Errorf("other email status = %d, want 200", code)
+	}
+
+	if code := send(`not json`); code != http.StatusOK {
+		t.Errorf("body without email status = %d, want 200 (policy skipped)", code)
+	}
+}
+
+func TestRateLimit_UnknownPolicyPassesThrough(t *testing.T) {
+	var limiter *RateLimiter
+	h := limiter.Limit("missing")(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("status = %d, headers = %v, want untouched passthrough", rec.Code, rec.Header())
+	}
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	opts := SecurityHeadersOptions{
+		HSTSMaxAge:            24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		FrameOptions:          "DENY",
+		ReferrerPolicy:        "no-referrer",
+		ContentSecurityPolicy: "default-src 'none'",
+		TrustProxy:            true,
+	}
+
+	r := chi.NewRouter()
+	r.Use(SecurityHeaders(opts))
+	r.Get("/api", func(w http.ResponseWriter, _ *http.Request) {})
+	r.With(ContentSecurityPolicy("default-src 'self'")).Get("/docs", func(w http.ResponseWriter, _ *http.Request) {})
+
+	tests := []struct {
+		name      string
+		path      string
+		forwarded string
+		wantCSP   string
+		wantHSTS  string
+	}{
+		{"plain http has no hsts", "/api", "", "default-src 'none'", ""},
+		{"https behind proxy", "/api", "https", "default-src 'none'", "max-age=86400; includeSubDomains"},
+		{"docs override csp", "/docs", "", "default-src 'self'", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-Proto", tt.forwarded)
+			}
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			h := rec.Header()
+			if got := h.Get("X-Content-Type-Options"); got != "nosniff" {
+				t.Errorf("X-Content-Type-Options = %q", got)
+			}
+			if got := h.Get("X-Frame-Options"); got != "DENY" {
+				t.Errorf("X-Frame-Options = %q", got)
+			}
+			if got := h.Get("Referrer-Policy"); got != "no-referrer" {
+				t.Errorf("Referrer-Policy = %q", got)
+			}
+			if got := h.Get("Content-Security-Policy"); got != tt.wantCSP {
+				t.Errorf("Content-Security-Policy = %q, want %q", got, tt.wantCSP)
+			}
+			if got := h.Get("Strict-Transport-Security"); got != tt.wantHSTS {
+				t.Errorf("Strict-Transport-Security = %q, want %q", got, tt.wantHSTS)
+			}
+		})
+	}
+}
+
+func TestSecurityHeaders_IgnoresForwardedProtoWithoutTrustProxy(t *testing.T) {
+	h := SecurityHeaders(SecurityHeadersOptions{HSTSMaxAge: time.Hour})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Strict-Transport-Security"); got != "" {
+		t.Fatalf("Strict-Transport-Security = %q, want empty", got)
+	}
+}
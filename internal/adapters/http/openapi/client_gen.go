@@ -0,0 +1,456 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GenerateClient menghasilkan source Go client typed dari dokumen OpenAPI: satu struct per
+// schema di components dan satu method per operation. Runtime yang dipanggil method-nya
+// (Client.do, call, Envelope, Response, RequestOption, Tokens) ditulis tangan di package
+// tujuan, lihat pkg/apiclient.
+func GenerateClient(data []byte, pkg string) ([]byte, error) {
+	spec, err := Load(data)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &clientGen{spec: spec, imports: map[string]bool{}}
+	if err := g.types(); err != nil {
+		return nil, err
+	}
+	if err := g.operations(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by apiclient-gen from the OpenAPI document. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+
+	imports := make([]string, 0, len(g.imports))
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	if len(imports) > 0 {
+		out.WriteString("import (\n")
+		for _, imp := range imports {
+			fmt.Fprintf(&out, "\t%q\n", imp)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated client: %w", err)
+	}
+
+	return src, nil
+}
+
+type clientGen struct {
+	spec    *Spec
+	buf     bytes.Buffer
+	imports map[string]bool
+}
+
+func (g *clientGen) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *clientGen) types() error {
+	names := make([]string, 0, len(g.spec.doc.Components.Schemas))
+	for name := range g.spec.doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		schema := g.spec.doc.Components.Schemas[name]
+		typ, err := g.goType(schema, false)
+		if err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+
+		g.comment(name, schema["description"])
+		g.printf("type %s %s\n\n", name, typ)
+
+		// response yang membawa pasangan token disimpan otomatis oleh Client
+		if props, ok := schema["properties"].(map[string]any); ok && props["token"] != nil && props["refresh_token"] != nil {
+			g.printf("func (r %s) tokens() Tokens { return Tokens{Access: r.Token, Refresh: r.RefreshToken} }\n\n", name)
+		}
+	}
+
+	return nil
+}
+
+func (g *clientGen) operations() error {
+	for _, op := range g.spec.Operations() {
+		if op.ID == "" {
+			return fmt.Errorf("%s %s: missing operationId", op.Method, op.Path)
+		}
+		if err := g.operation(op); err != nil {
+			return fmt.Errorf("operation %s: %w", op.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *clientGen) operation(op Operation) error {
+	name := exportedName(op.ID)
+
+	var (
+		args       []string
+		query      []parameter
+		idempotent bool
+	)
+	for _, p := range op.params {
+		switch p.In {
+		case "path":
+			args = append(args, paramName(p.Name)+" string")
+		case "query":
+			query = append(query, p)
+		case "header":
+			idempotent = idempotent || strings.EqualFold(p.Name, "Idempotency-Key")
+		}
+	}
+
+	if len(query) > 0 {
+		if err := g.queryParams(name+"Params", query); err != nil {
+			return err
+		}
+		args = append(args, "params "+name+"Params")
+	}
+
+	var body string
+	if rb := op.op.RequestBody; rb != nil {
+		media, ok := rb.Content["application/json"]
+		if !ok {
+			return fmt.Errorf("request body without application/json")
+		}
+
+		typ, err := g.goType(media.Schema, false)
+		if err != nil {
+			return err
+		}
+		args = append(args, "body "+typ)
+		body = "body"
+	}
+
+	result, err := g.result(op)
+	if err != nil {
+		return err
+	}
+
+	g.imports["context"] = true
+	g.imports["net/http"] = true
+	g.printf("// %s memanggil %s %s", name, op.Method, op.Path)
+	if op.op.Summary != "" {
+		g.printf(": %s", op.op.Summary)
+	}
+	if op.op.Deprecated {
+		g.printf("\n//\n// Deprecated: the operation is deprecated by the API, see its Sunset header.")
+	}
+	g.printf("\nfunc (c *Client) %s(ctx context.Context, %s) ", name, strings.Join(append(args, "opts ...RequestOption"), ", "))
+
+	fields := []string{"method: " + methodConst(op.Method), "path: " + g.pathExpr(op.Path)}
+	if len(query) > 0 {
+		fields = append(fields, "query: params.values()")
+	}
+	if body != "" {
+		fields = append(fields, "body: body")
+	}
+	if len(op.op.Security) > 0 {
+		fields = append(fields, "auth: true")
+	}
+	if idempotent {
+		fields = append(fields, "idempotent: true")
+	}
+	call := "call{" + strings.Join(fields, ", ") + "}"
+
+	if result == "" {
+		g.printf("error {\n\treturn c.do(ctx, %s, nil, opts)\n}\n\n", call)
+		return nil
+	}
+
+	g.printf("(*%s, error) {\n", result)
+	g.printf("\tout := new(%s)\n", result)
+	g.printf("\tif err := c.do(ctx, %s, out, opts); err != nil {\n\t\treturn nil, err\n\t}\n\n", call)
+	g.printf("\treturn out, nil\n}\n\n")
+	return nil
+}
+
+// result type response sukses pertama (2xx terkecil). Response dengan beberapa content type
+// dikembalikan mentah sebagai Response, body BaseResponse jadi Envelope[T].
+func (g *clientGen) result(op Operation) (string, error) {
+	status := 0
+	for code := range op.op.Responses {
+		n, err := strconv.Atoi(code)
+		if err == nil && n >= 200 && n < 300 && (status == 0 || n < status) {
+			status = n
+		}
+	}
+	if status == 0 {
+		return "", fmt.Errorf("no 2xx response")
+	}
+
+	resp, err := g.spec.response(op.op.Responses[strconv.Itoa(status)])
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case len(resp.Content) == 0:
+		return "", nil
+	case len(resp.Content) > 1:
+		return "Response", nil
+	}
+
+	media, ok := resp.Content["application/json"]
+	if !ok {
+		return "Response", nil
+	}
+
+	props, _ := media.Schema["properties"].(map[string]any)
+	if props["success"] == nil || props["message"] == nil {
+		return g.goType(media.Schema, false)
+	}
+
+	data := "json.RawMessage"
+	if schema, ok := props["data"].(map[string]any); ok {
+		if data, err = g.goType(schema, false); err != nil {
+			return "", err
+		}
+	} else {
+		g.imports["encoding/json"] = true
+	}
+
+	return "Envelope[" + data + "]", nil
+}
+
+// queryParams struct parameter query, nilai kosong tidak dikirim sehingga server memakai default
+func (g *clientGen) queryParams(name string, params []parameter) error {
+	g.imports["net/url"] = true
+
+	var fields, values strings.Builder
+	for _, p := range params {
+		typ, err := g.goType(p.Schema, false)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+
+		field := exportedName(p.Name)
+		if p.Description != "" {
+			fmt.Fprintf(&fields, "// %s\n", p.Description)
+		}
+		fmt.Fprintf(&fields, "%s %s\n", field, typ)
+
+		switch typ {
+		case "string":
+			fmt.Fprintf(&values, "if p.%s != \"\" {\nq.Set(%q, p.%s)\n}\n", field, p.Name, field)
+		case "int":
+			g.imports["strconv"] = true
+			fmt.Fprintf(&values, "if p.%s != 0 {\nq.Set(%q, strconv.Itoa(p.%s))\n}\n", field, p.Name, field)
+		case "bool":
+			fmt.Fprintf(&values, "if p.%s {\nq.Set(%q, \"true\")\n}\n", field, p.Name)
+		default:
+			return fmt.Errorf("parameter %s: unsupported query type %s", p.Name, typ)
+		}
+	}
+
+	g.printf("// %s parameter query, field kosong tidak dikirim\n", name)
+	g.printf("type %s struct {\n%s}\n\n", name, fields.String())
+	g.printf("func (p %s) values() url.Values {\nq := url.Values{}\n%sreturn q\n}\n\n", name, values.String())
+	return nil
+}
+
+func (g *clientGen) pathExpr(path string) string {
+	var parts []string
+	literal := ""
+	for _, seg := range splitPath(path) {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			g.imports["net/url"] = true
+			parts = append(parts, strconv.Quote(literal+"/"), "url.PathEscape("+paramName(seg[1:len(seg)-1])+")")
+			literal = ""
+			continue
+		}
+		literal += "/" + seg
+	}
+	if literal != "" || len(parts) == 0 {
+		if literal == "" {
+			literal = "/"
+		}
+		parts = append(parts, strconv.Quote(literal))
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+// goType tipe Go untuk schema. optional berarti field boleh tidak ada: struct dan waktu jadi
+// pointer, sisanya cukup omitempty.
+func (g *clientGen) goType(schema map[string]any, optional bool) (string, error) {
+	if ref, ok := schema["$ref"].(string); ok {
+		name, found := strings.CutPrefix(ref, "#/components/schemas/")
+		if !found {
+			return "", fmt.Errorf("unsupported $ref %s", ref)
+		}
+		if optional {
+			return "*" + name, nil
+		}
+		return name, nil
+	}
+
+	var (
+		types    []string
+		nullable bool
+	)
+	for _, t := range schemaTypes(schema["type"]) {
+		if t == "null" {
+			nullable = true
+			continue
+		}
+		types = append(types, t)
+	}
+	if len(types) == 0 && (schema["properties"] != nil || schema["additionalProperties"] != nil) {
+		types = []string{"object"}
+	}
+	if len(types) != 1 {
+		g.imports["encoding/json"] = true
+		return "json.RawMessage", nil
+	}
+
+	switch types[0] {
+	case "string":
+		if schema["format"] != "date-time" {
+			return pointerIf(nullable, "string"), nil
+		}
+		g.imports["time"] = true
+		return pointerIf(nullable || optional, "time.Time"), nil
+	case "integer":
+		return pointerIf(nullable, "int"), nil
+	case "number":
+		return pointerIf(nullable, "float64"), nil
+	case "boolean":
+		return pointerIf(nullable, "bool"), nil
+	case "array":
+		items, _ := schema["items"].(map[string]any)
+		typ, err := g.goType(items, false)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + typ, nil
+	case "object":
+		if props, ok := schema["properties"].(map[string]any); ok {
+			typ, err := g.structType(props, schema["required"])
+			return pointerIf(nullable || optional, typ), err
+		}
+		if extra, ok := schema["additionalProperties"].(map[string]any); ok {
+			typ, err := g.goType(extra, false)
+			return "map[string]" + typ, err
+		}
+		g.imports["encoding/json"] = true
+		return "map[string]json.RawMessage", nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", types[0])
+	}
+}
+
+func (g *clientGen) structType(props map[string]any, required any) (string, error) {
+	isRequired := map[string]bool{}
+	for _, name := range schemaTypes(required) {
+		isRequired[name] = true
+	}
+
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, name := range names {
+		schema, _ := props[name].(map[string]any)
+		typ, err := g.goType(schema, !isRequired[name])
+		if err != nil {
+			return "", fmt.Errorf("property %s: %w", name, err)
+		}
+
+		if desc, ok := schema["description"].(string); ok && desc != "" {
+			fmt.Fprintf(&b, "// %s\n", strings.TrimSpace(desc))
+		}
+		tag := name
+		if !isRequired[name] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "%s %s `json:%q`\n", exportedName(name), typ, tag)
+	}
+	b.WriteString("}")
+
+	return b.String(), nil
+}
+
+func (g *clientGen) comment(name string, desc any) {
+	if text, ok := desc.(string); ok && text != "" {
+		g.printf("// %s %s\n", name, strings.TrimSpace(text))
+	}
+}
+
+func pointerIf(ok bool, typ string) string {
+	if ok {
+		return "*" + typ
+	}
+	return typ
+}
+
+func methodConst(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "http.MethodGet"
+	case http.MethodPost:
+		return "http.MethodPost"
+	case http.MethodPut:
+		return "http.MethodPut"
+	case http.MethodPatch:
+		return "http.MethodPatch"
+	case http.MethodDelete:
+		return "http.MethodDelete"
+	default:
+		return strconv.Quote(method)
+	}
+}
+
+// initialisms ditulis kapital semua sesuai gaya Go (challenge_id jadi ChallengeID)
+var initialisms = map[string]string{
+	"id": "ID", "url": "URL", "otp": "OTP", "html": "HTML", "json": "JSON", "ms": "MS", "api": "API", "uuid": "UUID",
+}
+
+// exportedName mengubah snake_case/camelCase jadi nama Go yang diekspor
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		if upper, ok := initialisms[strings.ToLower(word)]; ok {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	return b.String()
+}
+
+// paramName nama argumen untuk parameter path
+func paramName(name string) string {
+	exported := exportedName(name)
+	if upper, ok := initialisms[strings.ToLower(exported)]; ok && upper == exported {
+		return strings.ToLower(exported)
+	}
+	return strings.ToLower(exported[:1]) + exported[1:]
+}
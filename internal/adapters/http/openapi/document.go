@@ -0,0 +1,465 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version versi OpenAPI dokumen yang dihasilkan
+const Version = "3.1.0"
+
+type (
+	Info struct {
+		Title       string `yaml:"title"`
+		Version     string `yaml:"version"`
+		Description string `yaml:"description,omitempty"`
+	}
+
+	Tag struct {
+		Name        string `yaml:"name"`
+		Description string `yaml:"description,omitempty"`
+	}
+
+	// Doc metadata satu operation, didaftarkan bersama handler-nya lewat Router
+	Doc struct {
+		ID          string
+		Summary     string
+		Description string
+		Tags        []string
+		// Auth operation butuh bearer token
+		Auth bool
+		// Idempotent operation menerima header Idempotency-Key
+		Idempotent bool
+		// Params struct dengan tag path, query atau header (lihat parameters)
+		Params any
+		// Request DTO body JSON, nil kalau tanpa body
+		Request   any
+		Responses []Response
+		// Errors status error problem+json, dirujuk ke components.responses
+		Errors []int
+		// Since dan Until versi API (v1, v2) tempat operation tersedia: Since <= versi < Until,
+		// kosong berarti tanpa batas. Dipakai saat perubahan tidak kompatibel hanya masuk versi baru.
+		Since, Until string
+		// Deprecated dan Sunset dikirim sebagai header Deprecation dan Sunset, zero berarti tidak
+		Deprecated, Sunset time.Time
+	}
+
+	Response struct {
+		Status      int
+		Description string
+		// Body nil kalau response tanpa body
+		Body        any
+		ContentType string
+	}
+)
+
+// merge menambahkan doc milik grup (Router.Describe) ke doc operation
+func (d Doc) merge(base Doc) Doc {
+	for _, tag := range base.Tags {
+		if !slices.Contains(d.Tags, tag) {
+			d.Tags = append(d.Tags, tag)
+		}
+	}
+	for _, status := range base.Errors {
+		if !slices.Contains(d.Errors, status) {
+			d.Errors = append(d.Errors, status)
+		}
+	}
+
+	d.Auth = d.Auth || base.Auth
+	d.Idempotent = d.Idempotent || base.Idempotent
+
+	if d.Since == "" {
+		d.Since = base.Since
+	}
+	if d.Until == "" {
+		d.Until = base.Until
+	}
+	if d.Deprecated.IsZero() {
+		d.Deprecated = base.Deprecated
+	}
+	if d.Sunset.IsZero() {
+		d.Sunset = base.Sunset
+	}
+
+	return d
+}
+
+// Document mengumpulkan operation dari Router lalu menghasilkan dokumen OpenAPI 3.1.
+// Dokumen dibangun ulang saat pertama kali diminta setelah ada operation baru.
+type Document struct {
+	info Info
+	tags []Tag
+
+	mu         sync.Mutex
+	operations []registered
+	built      []byte
+	spec       *Spec
+}
+
+type registered struct {
+	method string
+	path   string
+	doc    Doc
+}
+
+func NewDocument(info Info, tags ...Tag) *Document {
+	return &Document{info: info, tags: tags}
+}
+
+// Add mencatat operation, path memakai pola chi (/admin/jobs/{id})
+func (d *Document) Add(method, path string, doc Doc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.operations = append(d.operations, registered{method: method, path: openAPIPath(path), doc: doc})
+	d.built, d.spec = nil, nil
+}
+
+// YAML dokumen OpenAPI dalam format YAML
+func (d *Document) YAML() ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.yaml()
+}
+
+// Spec dokumen yang sudah di-parse untuk validasi request/response
+func (d *Document) Spec() (*Spec, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.spec != nil {
+		return d.spec, nil
+	}
+
+	data, err := d.yaml()
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := Load(data)
+	if err != nil {
+		return nil, err
+	}
+	d.spec = spec
+
+	return spec, nil
+}
+
+// ServeHTTP menyajikan dokumen, misal di GET /docs/v1/openapi.yml
+func (d *Document) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data, err := d.YAML()
+	if err != nil {
+		httpx.WriteError(w, r, errors.Wrap(errors.ErrInternal, "failed to build openapi document", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(data)
+}
+
+type (
+	oaDocument struct {
+		OpenAPI    string                             `yaml:"openapi"`
+		Info       Info                               `yaml:"info"`
+		Tags       []Tag                              `yaml:"tags,omitempty"`
+		Paths      map[string]map[string]*oaOperation `yaml:"paths"`
+		Components oaComponents                       `yaml:"components"`
+	}
+
+	oaComponents struct {
+		SecuritySchemes map[string]any         `yaml:"securitySchemes"`
+		Parameters      map[string]oaParameter `yaml:"parameters"`
+		Responses       map[string]any         `yaml:"responses"`
+		Schemas         map[string]any         `yaml:"schemas"`
+	}
+
+	oaOperation struct {
+		Tags        []string              `yaml:"tags,omitempty"`
+		Summary     string                `yaml:"summary,omitempty"`
+		Description string                `yaml:"description,omitempty"`
+		OperationID string                `yaml:"operationId"`
+		Deprecated  bool                  `yaml:"deprecated,omitempty"`
+		Security    []map[string][]string `yaml:"security,omitempty"`
+		Parameters  []any                 `yaml:"parameters,omitempty"`
+		RequestBody *oaRequestBody        `yaml:"requestBody,omitempty"`
+		Responses   map[string]any        `yaml:"responses"`
+	}
+
+	oaParameter struct {
+		In              string         `yaml:"in"`
+		Name            string         `yaml:"name"`
+		Description     string         `yaml:"description,omitempty"`
+		Required        bool           `yaml:"required,omitempty"`
+		AllowEmptyValue bool           `yaml:"allowEmptyValue,omitempty"`
+		Schema          map[string]any `yaml:"schema"`
+	}
+
+	oaRequestBody struct {
+		Required bool               `yaml:"required"`
+		Content  map[string]oaMedia `yaml:"content"`
+	}
+
+	oaResponse struct {
+		Description string             `yaml:"description"`
+		Content     map[string]oaMedia `yaml:"content,omitempty"`
+	}
+
+	oaMedia struct {
+		Schema map[string]any `yaml:"schema"`
+	}
+)
+
+const jsonContentType = "application/json"
+
+var idempotencyKey = oaParameter{
+	In:   "header",
+	Name: "Idempotency-Key",
+	Description: "Unique key (e.g. a UUID) making retries of this request safe. The first response is\n" +
+		"stored and replayed for retries with the same key and body, marked with the\n" +
+		"`Idempotent-Replayed: true` header.\n",
+	Schema: map[string]any{"type": "string", "maxLength": 255},
+}
+
+func (d *Document) yaml() ([]byte, error) {
+	if d.built != nil {
+		return d.built, nil
+	}
+
+	problemResponses, problemSchemas := httpx.ProblemComponents()
+	doc := oaDocument{
+		OpenAPI: Version,
+		Info:    d.info,
+		Tags:    d.tags,
+		Paths:   map[string]map[string]*oaOperation{},
+		Components: oaComponents{
+			SecuritySchemes: map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+			Parameters: map[string]oaParameter{"IdempotencyKey": idempotencyKey},
+			Responses:  problemResponses,
+		},
+	}
+
+	schemas := newSchemas()
+	ids := map[string]string{}
+	for _, op := range d.operations {
+		where := op.method + " " + op.path
+		if op.doc.ID == "" {
+			return nil, fmt.Errorf("openapi: %s has no operation id", where)
+		}
+		if other, ok := ids[op.doc.ID]; ok {
+			return nil, fmt.Errorf("openapi: operation id %s is used by %s and %s", op.doc.ID, other, where)
+		}
+		ids[op.doc.ID] = where
+
+		built, err := buildOperation(schemas, op)
+		if err != nil {
+			return nil, fmt.Errorf("openapi: %s: %w", where, err)
+		}
+
+		if doc.Paths[op.path] == nil {
+			doc.Paths[op.path] = map[string]*oaOperation{}
+		}
+		doc.Paths[op.path][strings.ToLower(op.method)] = built
+	}
+
+	doc.Components.Schemas = schemas.components
+	for name, schema := range problemSchemas {
+		if _, exists := doc.Components.Schemas[name]; exists {
+			return nil, fmt.Errorf("openapi: schema %s clashes with a problem schema", name)
+		}
+		doc.Components.Schemas[name] = schema
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	d.built = buf.Bytes()
+	return d.built, nil
+}
+
+func buildOperation(schemas *schemas, op registered) (*oaOperation, error) {
+	out := &oaOperation{
+		Tags:        op.doc.Tags,
+		Summary:     op.doc.Summary,
+		Description: op.doc.Description,
+		OperationID: op.doc.ID,
+		Deprecated:  !op.doc.Deprecated.IsZero(),
+		Responses:   map[string]any{},
+	}
+
+	if !op.doc.Sunset.IsZero() {
+		out.Description = strings.TrimSpace(out.Description + "\n\nSunset on " + op.doc.Sunset.UTC().Format(time.DateOnly) + ", the operation is removed after this date.")
+	}
+
+	if op.doc.Auth {
+		out.Security = []map[string][]string{{"bearerAuth": {}}}
+	}
+
+	if op.doc.Idempotent {
+		out.Parameters = append(out.Parameters, map[string]any{"$ref": "#/components/parameters/IdempotencyKey"})
+	}
+
+	params, err := schemas.parameters(op.doc.Params)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range pathParams(op.path) {
+		if !slices.ContainsFunc(params, func(p oaParameter) bool { return p.In == "path" && p.Name == name }) {
+			params = append(params, oaParameter{In: "path", Name: name, Required: true, Schema: map[string]any{"type": "string"}})
+		}
+	}
+	for _, p := range params {
+		out.Parameters = append(out.Parameters, p)
+	}
+
+	if op.doc.Request != nil {
+		schema, err := schemas.of(op.doc.Request)
+		if err != nil {
+			return nil, err
+		}
+		out.RequestBody = &oaRequestBody{Required: true, Content: map[string]oaMedia{jsonContentType: {Schema: schema}}}
+	}
+
+	if len(op.doc.Responses) == 0 {
+		return nil, fmt.Errorf("no responses documented")
+	}
+
+	for _, resp := range op.doc.Responses {
+		key := strconv.Itoa(resp.Status)
+		built, _ := out.Responses[key].(oaResponse)
+		if built.Description == "" {
+			built.Description = resp.Description
+		}
+		if built.Description == "" {
+			built.Description = http.StatusText(resp.Status)
+		}
+
+		if resp.Body != nil {
+			schema, err := schemas.of(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			contentType := resp.ContentType
+			if contentType == "" {
+				contentType = jsonContentType
+			}
+			if built.Content == nil {
+				built.Content = map[string]oaMedia{}
+			}
+			built.Content[contentType] = oaMedia{Schema: schema}
+		}
+
+		out.Responses[key] = built
+	}
+
+	errorStatuses := slices.Clone(op.doc.Errors)
+	sort.Ints(errorStatuses)
+	for _, status := range errorStatuses {
+		name := httpx.ProblemResponseName(status)
+		if name == "" {
+			return nil, fmt.Errorf("no problem response for status %d", status)
+		}
+		out.Responses[strconv.Itoa(status)] = map[string]any{"$ref": "#/components/responses/" + name}
+	}
+
+	return out, nil
+}
+
+// parameters membaca struct params. Field-nya ditandai dengan tag path, query atau header berisi
+// nama parameter, opsi "allowEmptyValue" boleh ditambahkan setelah koma (query:"verbose,allowEmptyValue").
+// Constraint diambil dari tag validate seperti body, tag doc jadi description parameter.
+func (s *schemas) parameters(v any) ([]oaParameter, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("params must be a struct, got %s", t)
+	}
+
+	var params []oaParameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		for _, in := range []string{"path", "query", "header"} {
+			tag, ok := field.Tag.Lookup(in)
+			if !ok {
+				continue
+			}
+
+			name, opts, _ := strings.Cut(tag, ",")
+			schema, err := s.property(field)
+			if err != nil {
+				return nil, fmt.Errorf("param %s: %w", name, err)
+			}
+
+			description, _ := schema["description"].(string)
+			delete(schema, "description")
+
+			_, required := validateRules(field)["required"]
+			params = append(params, oaParameter{
+				In:              in,
+				Name:            name,
+				Description:     description,
+				Required:        required || in == "path",
+				AllowEmptyValue: slices.Contains(strings.Split(opts, ","), "allowEmptyValue"),
+				Schema:          schema,
+			})
+		}
+	}
+
+	return params, nil
+}
+
+var (
+	chiParam  = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+	pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+)
+
+// openAPIPath mengubah pola chi ke path OpenAPI: regex parameter dibuang dan slash di akhir dihapus
+func openAPIPath(pattern string) string {
+	path := chiParam.ReplaceAllString(pattern, "{$1}")
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	return path
+}
+
+func pathParams(path string) []string {
+	var names []string
+	for _, match := range pathParam.FindAllStringSubmatch(path, -1) {
+		names = append(names, match[1])
+	}
+
+	return names
+}
@@ -0,0 +1,301 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	signupRequest struct {
+		Email    string   `json:"email" validate:"required,email" example:"user@mail.com"`
+		Password string   `json:"password" validate:"required,min=8,max=72"`
+		Code     string   `json:"code" validate:"omitempty,len=6"`
+		Plan     string   `json:"plan" validate:"oneof=free pro"`
+		Age      int      `json:"age" validate:"gte=17"`
+		Tags     []string `json:"tags" validate:"max=3,dive,min=1"`
+		Internal string   `json:"-"`
+	}
+
+	accountDTO struct {
+		ID        string      `json:"id"`
+		Role      string      `json:"role" enum:"user admin"`
+		Note      string      `json:"note,omitempty"`
+		DeletedAt *time.Time  `json:"deleted_at"`
+		Owner     *accountDTO `json:"owner,omitempty"`
+		auditDTO
+	}
+
+	auditDTO struct {
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	envelope[T any] struct {
+		Data T `json:"data,omitempty"`
+	}
+
+	listQuery struct {
+		Status  string `query:"status" validate:"omitempty,oneof=active archived" default:"active"`
+		Limit   int    `query:"limit" validate:"min=1,max=100" doc:"Page size"`
+		Verbose bool   `query:"verbose,allowEmptyValue"`
+	}
+)
+
+func TestSchemas_DerivesConstraintsFromTags(t *testing.T) {
+	s := newSchemas()
+	if _, err := s.of(signupRequest{}); err != nil {
+		t.Fatalf("of() error = %v", err)
+	}
+
+	signup := s.components["signupRequest"].(map[string]any)
+	props := signup["properties"].(map[string]any)
+
+	want := map[string]map[string]any{
+		"email":    {"type": "string", "format": "email", "examples": []any{"user@mail.com"}},
+		"password": {"type": "string", "minLength": 8, "maxLength": 72},
+		"code":     {"type": "string", "minLength": 6, "maxLength": 6},
+		"plan":     {"type": "string", "enum": []any{"free", "pro"}},
+		"age":      {"type": "integer", "minimum": float64(17)},
+		"tags":     {"type": "array", "items": map[string]any{"type": "string"}, "maxItems": 3},
+	}
+	for name, schema := range want {
+		if !reflect.DeepEqual(props[name], schema) {
+			t.Errorf("%s = %v, want %v", name, props[name], schema)
+		}
+	}
+
+	if _, ok := props["Internal"]; ok {
+		t.Error(`field with json:"-" is documented`)
+	}
+
+	// DTO request: hanya field dengan validate required yang wajib
+	if got := signup["required"]; !reflect.DeepEqual(got, []string{"email", "password"}) {
+		t.Errorf("required = %v, want [email password]", got)
+	}
+}
+
+func TestSchemas_ResponseDTO(t *testing.T) {
+	s := newSchemas()
+	schema, err := s.of(envelope[accountDTO]{})
+	if err != nil {
+		t.Fatalf("of() error = %v", err)
+	}
+
+	// generic di-inline, struct bernama jadi component tanpa akhiran DTO
+	data := schema["properties"].(map[string]any)["data"]
+	if !reflect.DeepEqual(data, ref("account")) {
+		t.Errorf("data = %v, want $ref account", data)
+	}
+	if got := schema["required"]; !reflect.DeepEqual(got, []string{"data"}) {
+		t.Errorf("envelope required = %v, want [data] because structs are never omitted", got)
+	}
+
+	account := s.components["account"].(map[string]any)
+	props := account["properties"].(map[string]any)
+
+	if got := props["deleted_at"]; !reflect.DeepEqual(got, map[string]any{"type": []any{"string", "null"}, "format": "date-time"}) {
+		t.Errorf("deleted_at = %v, want nullable date-time", got)
+	}
+	if got := props["owner"]; !reflect.DeepEqual(got, ref("account")) {
+		t.Errorf("owner = %v, want recursive $ref", got)
+	}
+	if _, ok := props["created_at"]; !ok {
+		t.Error("embedded struct fields are not flattened")
+	}
+	if got := account["required"]; !reflect.DeepEqual(got, []string{"id", "role", "deleted_at", "created_at"}) {
+		t.Errorf("required = %v, want fields without omitempty", got)
+	}
+}
+
+func TestRouter_RegistersDocumentedRoutes(t *testing.T) {
+	mux := chi.NewRouter()
+	doc := NewDocument(Info{Title: "Test", Version: "1"})
+	api := NewRouter(mux, doc)
+
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	api.Route("/accounts", func(r *Router) {
+		r = r.Describe(Doc{Tags: []string{"Accounts"}, Auth: true, Errors: []int{http.StatusUnauthorized}})
+
+		r.Get("/", ok, Doc{
+			ID:        "listAccounts",
+			Params:    listQuery{},
+			Responses: []Response{{Status: http.StatusOK, Body: envelope[[]accountDTO]{}}},
+			Errors:    []int{http.StatusBadRequest},
+		})
+		r.Post("/{id:[0-9]+}/close", ok, Doc{
+			ID:         "closeAccount",
+			Idempotent: true,
+			Request:    signupRequest{},
+			Responses:  []Response{{Status: http.StatusNoContent}},
+		})
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/accounts/42/close", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("route not mounted on chi: status = %d", rec.Code)
+	}
+
+	data, err := doc.YAML()
+	if err != nil {
+		t.Fatalf("YAML() error = %v", err)
+	}
+
+	var out struct {
+		OpenAPI string
+		Paths   map[string]map[string]struct {
+			Tags       []string
+			Security   []map[string][]string
+			Parameters []map[string]any
+			Responses  map[string]map[string]any
+		}
+	}
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if out.OpenAPI != Version {
+		t.Errorf("openapi = %q, want %q", out.OpenAPI, Version)
+	}
+
+	list := out.Paths["/accounts"]["get"]
+	if !reflect.DeepEqual(list.Tags, []string{"Accounts"}) || len(list.Security) != 1 {
+		t.Errorf("list tags = %v, security = %v, want group defaults", list.Tags, list.Security)
+	}
+	if list.Responses["401"]["$ref"] != "#/components/responses/Unauthorized" || list.Responses["400"]["$ref"] != "#/components/responses/BadRequest" {
+		t.Errorf("list responses = %v, want group and operation errors", list.Responses)
+	}
+	if len(list.Parameters) != 3 || list.Parameters[1]["description"] != "Page size" || list.Parameters[2]["allowEmptyValue"] != true {
+		t.Errorf("list parameters = %v", list.Parameters)
+	}
+
+	closeOp, ok2 := out.Paths["/accounts/{id}/close"]["post"]
+	if !ok2 {
+		t.Fatalf("paths = %v, want chi regex stripped from /accounts/{id}/close", out.Paths)
+	}
+	if closeOp.Parameters[0]["$ref"] != "#/components/parameters/IdempotencyKey" || closeOp.Parameters[1]["name"] != "id" || closeOp.Parameters[1]["required"] != true {
+		t.Errorf("close parameters = %v, want Idempotency-Key and path id", closeOp.Parameters)
+	}
+
+	// dokumen hasil generate bisa dipakai validator
+	spec, err := doc.Spec()
+	if err != nil {
+		t.Fatalf("Spec() error = %v", err)
+	}
+	op, _, found := spec.Find(http.MethodGet, "/accounts")
+	if !found {
+		t.Fatal("listAccounts not found in spec")
+	}
+	if err := op.ValidateRequest(httptest.NewRequest(http.MethodGet, "/accounts?limit=500", nil), nil); err == nil {
+		t.Error("limit=500 passes validation, want maximum from validate tag")
+	}
+}
+
+func TestDocument_RejectsDuplicateOperationIDs(t *testing.T) {
+	doc := NewDocument(Info{Title: "Test", Version: "1"})
+	doc.Add(http.MethodGet, "/a", Doc{ID: "same", Responses: []Response{{Status: http.StatusOK}}})
+	doc.Add(http.MethodGet, "/b", Doc{ID: "same", Responses: []Response{{Status: http.StatusOK}}})
+
+	if _, err := doc.YAML(); err == nil || !strings.Contains(err.Error(), "same") {
+		t.Errorf("YAML() error = %v, want duplicate operation id", err)
+	}
+}
+
+func TestRouter_VersionsAndDeprecation(t *testing.T) {
+	mux := chi.NewRouter()
+	v1 := NewDocument(Info{Title: "Test", Version: "1"})
+	v2 := NewDocument(Info{Title: "Test", Version: "2"})
+	api := NewRouter(mux, v1, v2)
+
+	version := func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(httpx.APIVersion(r))) }
+	text := []Response{{Status: http.StatusOK, Description: "API version", Body: "", ContentType: "text/plain"}}
+	api.Get("/health", version, Doc{ID: "health", Responses: text})
+
+	sunset := time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+	mount := func(r *Router) {
+		r.Get("/orders", version, Doc{ID: "listOrders", Responses: text, Until: "v2"})
+		r.Get("/orders/v2", version, Doc{ID: "listOrdersV2", Responses: text, Since: "v2"})
+		r.Get("/carts", version, Doc{ID: "listCarts", Responses: text, Deprecated: sunset.AddDate(0, -6, 0), Sunset: sunset})
+	}
+	api.Version(APIVersion{Name: "v1"}, v1, mount)
+	api.Version(APIVersion{Name: "v2"}, v2, mount)
+	api.Legacy(APIVersion{Name: "v1", Deprecated: sunset.AddDate(0, -6, 0), Sunset: sunset}, mount)
+
+	tests := []struct {
+		path       string
+		status     int
+		version    string
+		deprecated bool
+		link       string
+	}{
+		{path: "/health", status: http.StatusOK},
+		{path: "/v1/orders", status: http.StatusOK, version: "v1"},
+		{path: "/v1/orders/v2", status: http.StatusNotFound},
+		{path: "/v2/orders", status: http.StatusNotFound},
+		{path: "/v2/orders/v2", status: http.StatusOK, version: "v2"},
+		{path: "/v2/carts", status: http.StatusOK, version: "v2", deprecated: true},
+		{path: "/orders", status: http.StatusOK, version: "v1", deprecated: true, link: `</v1/orders>; rel="successor-version"`},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("%s status = %d, want %d", tt.path, rec.Code, tt.status)
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		if got := rec.Body.String(); got != tt.version {
+			t.Errorf("%s version = %q, want %q", tt.path, got, tt.version)
+		}
+		if got := rec.Header().Get(HeaderDeprecation) != ""; got != tt.deprecated {
+			t.Errorf("%s deprecated = %v, want %v", tt.path, got, tt.deprecated)
+		}
+		if tt.deprecated && rec.Header().Get(HeaderSunset) != "Fri, 30 Apr 2027 00:00:00 GMT" {
+			t.Errorf("%s Sunset = %q", tt.path, rec.Header().Get(HeaderSunset))
+		}
+		if got := rec.Header().Get(HeaderLink); got != tt.link {
+			t.Errorf("%s Link = %q, want %q", tt.path, got, tt.link)
+		}
+	}
+
+	// route umum tercatat di setiap dokumen, route versi hanya di dokumen versinya
+	for doc, want := range map[*Document][]string{
+		v1: {"/health", "/v1/carts", "/v1/orders"},
+		v2: {"/health", "/v2/carts", "/v2/orders/v2"},
+	} {
+		spec, err := doc.Spec()
+		if err != nil {
+			t.Fatalf("Spec() error = %v", err)
+		}
+
+		var paths []string
+		for _, op := range spec.Operations() {
+			paths = append(paths, op.Path)
+		}
+		slices.Sort(paths)
+		if !reflect.DeepEqual(paths, want) {
+			t.Errorf("documented paths = %v, want %v", paths, want)
+		}
+	}
+
+	data, err := v2.YAML()
+	if err != nil {
+		t.Fatalf("YAML() error = %v", err)
+	}
+	if !strings.Contains(string(data), "deprecated: true") || !strings.Contains(string(data), "Sunset on 2027-04-30") {
+		t.Errorf("deprecated operation is not marked in the document:\n%s", data)
+	}
+}
@@ -0,0 +1,389 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemas membangun components.schemas dari tipe Go. Struct bernama (bukan generic) jadi
+// component dengan nama tipenya tanpa akhiran DTO, sisanya di-inline.
+//
+// Tag yang dibaca per field:
+//   - json: nama property; field tanpa omitempty selalu ada di response
+//   - validate: constraint (required, email, url, uuid, min, max, len, oneof, gte, lte)
+//   - doc, example, enum (dipisah spasi), format, default: pelengkap dokumentasi
+type schemas struct {
+	components map[string]any
+	types      map[string]reflect.Type
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]any{}, types: map[string]reflect.Type{}}
+}
+
+// of schema untuk value v, nil kalau v nil
+func (s *schemas) of(v any) (map[string]any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	return s.schema(reflect.TypeOf(v))
+}
+
+func (s *schemas) schema(t reflect.Type) (map[string]any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case t == rawMessageType:
+		return map[string]any{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := s.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("openapi: map key of %s must be a string", t)
+		}
+		values, err := s.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		return s.structRef(t)
+	default:
+		return nil, fmt.Errorf("openapi: unsupported type %s", t)
+	}
+}
+
+func (s *schemas) structRef(t reflect.Type) (map[string]any, error) {
+	name := componentName(t)
+	if name == "" {
+		return s.object(t)
+	}
+
+	if existing, ok := s.types[name]; ok {
+		if existing != t {
+			return nil, fmt.Errorf("openapi: schema %s is used by both %s and %s", name, existing, t)
+		}
+		return ref(name), nil
+	}
+
+	// daftarkan dulu supaya tipe rekursif tidak berputar terus
+	s.types[name] = t
+	object, err := s.object(t)
+	if err != nil {
+		return nil, err
+	}
+	s.components[name] = object
+
+	return ref(name), nil
+}
+
+func (s *schemas) object(t reflect.Type) (map[string]any, error) {
+	properties := map[string]any{}
+	var required []string
+
+	// struct yang punya tag validate adalah DTO request: hanya field "required" yang wajib
+	request := hasValidateTag(t)
+
+	var walk func(t reflect.Type) error
+	walk = func(t reflect.Type) error {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+
+			// field embedded tanpa tag json diratakan seperti encoding/json, walaupun tipenya unexported
+			if field.Anonymous && field.Tag.Get("json") == "" && field.Type.Kind() == reflect.Struct {
+				if err := walk(field.Type); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if !field.IsExported() {
+				continue
+			}
+
+			name, omitempty, skip := jsonName(field)
+			if skip {
+				continue
+			}
+
+			prop, err := s.property(field)
+			if err != nil {
+				return fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+			}
+			properties[name] = prop
+
+			rules := validateRules(field)
+			_, isRequired := rules["required"]
+			if !request {
+				// encoding/json tidak pernah membuang struct walaupun omitempty
+				isRequired = !omitempty || (field.Type.Kind() == reflect.Struct)
+			}
+			if isRequired {
+				required = append(required, name)
+			}
+		}
+		return nil
+	}
+
+	if err := walk(t); err != nil {
+		return nil, err
+	}
+
+	object := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		object["required"] = required
+	}
+
+	return object, nil
+}
+
+// property schema satu field ditambah constraint dari tag-nya
+func (s *schemas) property(field reflect.StructField) (map[string]any, error) {
+	schema, err := s.schema(field.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, isRef := schema["$ref"]; isRef {
+		// 3.1 membolehkan keyword lain di samping $ref, tapi description saja sudah cukup
+		if doc := field.Tag.Get("doc"); doc != "" {
+			schema["description"] = doc
+		}
+		return schema, nil
+	}
+
+	_, omitempty, _ := jsonName(field)
+	if typ, ok := schema["type"].(string); ok && field.Type.Kind() == reflect.Pointer && !omitempty {
+		schema["type"] = []any{typ, "null"}
+	}
+
+	if err := applyTags(schema, field); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// applyTags menerjemahkan tag validate dan tag dokumentasi ke keyword JSON schema
+func applyTags(schema map[string]any, field reflect.StructField) error {
+	kind := field.Type.Kind()
+	if kind == reflect.Pointer {
+		kind = field.Type.Elem().Kind()
+	}
+
+	for rule, arg := range validateRules(field) {
+		switch rule {
+		case "email":
+			schema["format"] = "email"
+		case "url":
+			schema["format"] = "uri"
+		case "uuid":
+			schema["format"] = "uuid"
+		case "min", "gte":
+			if err := setBound(schema, kind, arg, "minLength", "minimum", "minItems"); err != nil {
+				return err
+			}
+		case "max", "lte":
+			if err := setBound(schema, kind, arg, "maxLength", "maximum", "maxItems"); err != nil {
+				return err
+			}
+		case "len":
+			if err := setBound(schema, kind, arg, "minLength", "minimum", "minItems"); err != nil {
+				return err
+			}
+			if err := setBound(schema, kind, arg, "maxLength", "maximum", "maxItems"); err != nil {
+				return err
+			}
+		case "oneof":
+			enum, err := typedList(kind, strings.Fields(arg))
+			if err != nil {
+				return err
+			}
+			schema["enum"] = enum
+		}
+	}
+
+	if enum := field.Tag.Get("enum"); enum != "" {
+		values, err := typedList(kind, strings.Fields(enum))
+		if err != nil {
+			return err
+		}
+		schema["enum"] = values
+	}
+
+	if doc := field.Tag.Get("doc"); doc != "" {
+		schema["description"] = doc
+	}
+
+	if format := field.Tag.Get("format"); format != "" {
+		schema["format"] = format
+	}
+
+	if example, ok := field.Tag.Lookup("example"); ok {
+		value, err := typedValue(kind, example)
+		if err != nil {
+			return err
+		}
+		schema["examples"] = []any{value}
+	}
+
+	if def, ok := field.Tag.Lookup("default"); ok {
+		value, err := typedValue(kind, def)
+		if err != nil {
+			return err
+		}
+		schema["default"] = value
+	}
+
+	return nil
+}
+
+func setBound(schema map[string]any, kind reflect.Kind, arg, forString, forNumber, forArray string) error {
+	n, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Errorf("invalid bound %q: %w", arg, err)
+	}
+
+	switch kind {
+	case reflect.String:
+		schema[forString] = int(n)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		schema[forArray] = int(n)
+	default:
+		schema[forNumber] = n
+	}
+
+	return nil
+}
+
+func typedList(kind reflect.Kind, raw []string) ([]any, error) {
+	values := make([]any, len(raw))
+	for i, item := range raw {
+		value, err := typedValue(kind, item)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// typedValue mengubah nilai dari struct tag sesuai tipe field, supaya contoh angka tidak jadi string
+func typedValue(kind reflect.Kind, raw string) (any, error) {
+	switch kind {
+	case reflect.Bool:
+		return strconv.ParseBool(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.Atoi(raw)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(raw, 64)
+	case reflect.Slice, reflect.Array:
+		return strings.Fields(raw), nil
+	default:
+		return raw, nil
+	}
+}
+
+// validateRules tag validate sebagai map rule -> argumen, misal "min=8" jadi {"min": "8"}
+func validateRules(field reflect.StructField) map[string]string {
+	rules := map[string]string{}
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if name == "dive" {
+			// rule setelah dive berlaku untuk isi slice, bukan field-nya
+			break
+		}
+		if name != "" {
+			rules[name] = arg
+		}
+	}
+
+	return rules
+}
+
+func hasValidateTag(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, ok := field.Tag.Lookup("validate"); ok {
+			return true
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && hasValidateTag(field.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func jsonName(field reflect.StructField) (name string, omitempty, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitempty = true
+		}
+	}
+
+	return name, omitempty, false
+}
+
+// componentName nama component untuk struct bernama, kosong untuk struct anonim dan generic
+// (misal httpx.BaseResponse[T]) yang di-inline.
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	if name == "" || strings.Contains(name, "[") {
+		return ""
+	}
+
+	if trimmed := strings.TrimSuffix(name, "DTO"); trimmed != "" {
+		return trimmed
+	}
+
+	return name
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
@@ -0,0 +1,127 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Router membungkus chi.Router supaya setiap route didaftarkan bersama Doc-nya. Route yang
+// sengaja tidak didokumentasikan (metrics, docs) dipasang langsung di chi.Router.
+type Router struct {
+	mux chi.Router
+	// docs dokumen yang mencatat route, route di luar grup versi masuk ke semua dokumen versi
+	docs   []*Document
+	prefix string
+	base   Doc
+	// version versi API grup ini, kosong di luar Version dan Legacy
+	version string
+	// successor prefix versi pengganti untuk header Link, hanya di Legacy
+	successor string
+}
+
+func NewRouter(mux chi.Router, docs ...*Document) *Router {
+	return &Router{mux: mux, docs: docs}
+}
+
+// Version memasang grup /<v.Name> yang didokumentasikan di doc. Route yang sama bisa dipasang
+// di beberapa versi dengan fn yang sama; Doc.Since dan Doc.Until memilih versi per operation,
+// handler bisa membedakan perilaku lewat httpx.APIVersion.
+func (r *Router) Version(v APIVersion, doc *Document, fn func(r *Router)) {
+	r.mux.Route("/"+v.Name, func(mux chi.Router) {
+		mux.Use(withVersion(v.Name))
+
+		out := *r
+		out.mux = mux
+		out.docs = []*Document{doc}
+		out.prefix = r.prefix + "/" + v.Name
+		out.version = v.Name
+		out.base = Doc{Deprecated: v.Deprecated, Sunset: v.Sunset}.merge(r.base)
+		fn(&out)
+	})
+}
+
+// Legacy memasang fn tanpa prefix versi sebagai alias v.Name untuk klien lama. Route-nya tidak
+// didokumentasikan dan setiap response membawa header Deprecation, Sunset dan Link ke /<v.Name>.
+func (r *Router) Legacy(v APIVersion, fn func(r *Router)) {
+	r.mux.Group(func(mux chi.Router) {
+		mux.Use(withVersion(v.Name))
+
+		out := *r
+		out.mux = mux
+		out.docs = nil
+		out.version = v.Name
+		out.successor = "/" + v.Name
+		out.base = Doc{Deprecated: v.Deprecated, Sunset: v.Sunset}.merge(r.base)
+		fn(&out)
+	})
+}
+
+// Describe mengembalikan Router yang menambahkan tags, Auth, Idempotent dan Errors dari base
+// ke setiap operation di bawahnya, berguna untuk grup yang memasang middleware yang sama.
+func (r *Router) Describe(base Doc) *Router {
+	out := *r
+	out.base = base.merge(r.base)
+	return &out
+}
+
+func (r *Router) Use(middlewares ...func(http.Handler) http.Handler) {
+	r.mux.Use(middlewares...)
+}
+
+func (r *Router) With(middlewares ...func(http.Handler) http.Handler) *Router {
+	out := *r
+	out.mux = r.mux.With(middlewares...)
+	return &out
+}
+
+func (r *Router) Group(fn func(r *Router)) {
+	r.mux.Group(func(mux chi.Router) {
+		out := *r
+		out.mux = mux
+		fn(&out)
+	})
+}
+
+func (r *Router) Route(pattern string, fn func(r *Router)) {
+	r.mux.Route(pattern, func(mux chi.Router) {
+		out := *r
+		out.mux = mux
+		out.prefix = r.prefix + strings.TrimSuffix(pattern, "/")
+		fn(&out)
+	})
+}
+
+// Method memasang route kalau operation tersedia di versi grup ini (lihat Doc.Since)
+func (r *Router) Method(method, pattern string, handler http.HandlerFunc, doc Doc) {
+	doc = doc.merge(r.base)
+	if !doc.available(r.version) {
+		return
+	}
+
+	r.mux.Method(method, pattern, deprecation(doc, r.successor, handler))
+	for _, d := range r.docs {
+		d.Add(method, r.prefix+pattern, doc)
+	}
+}
+
+func (r *Router) Get(pattern string, handler http.HandlerFunc, doc Doc) {
+	r.Method(http.MethodGet, pattern, handler, doc)
+}
+
+func (r *Router) Post(pattern string, handler http.HandlerFunc, doc Doc) {
+	r.Method(http.MethodPost, pattern, handler, doc)
+}
+
+func (r *Router) Put(pattern string, handler http.HandlerFunc, doc Doc) {
+	r.Method(http.MethodPut, pattern, handler, doc)
+}
+
+func (r *Router) Patch(pattern string, handler http.HandlerFunc, doc Doc) {
+	r.Method(http.MethodPatch, pattern, handler, doc)
+}
+
+func (r *Router) Delete(pattern string, handler http.HandlerFunc, doc Doc) {
+	r.Method(http.MethodDelete, pattern, handler, doc)
+}
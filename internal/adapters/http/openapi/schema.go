@@ -0,0 +1,334 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Violation satu ketidaksesuaian dengan dokumen. Field berupa path bertitik, misal "data.user.email".
+type Violation struct {
+	Field   string
+	Message string
+}
+
+// Violations dikembalikan sebagai error oleh ValidateRequest/ValidateResponse
+type Violations []Violation
+
+func (v Violations) Error() string {
+	parts := make([]string, 0, len(v))
+	for _, violation := range v {
+		if violation.Field == "" {
+			parts = append(parts, violation.Message)
+			continue
+		}
+		parts = append(parts, violation.Field+": "+violation.Message)
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+func (v *Violations) add(field, format string, args ...any) {
+	*v = append(*v, Violation{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v Violations) orNil() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	return v
+}
+
+// validate mengecek value hasil json.Unmarshal (map[string]any, []any, float64, string, bool, nil)
+// terhadap schema. Keyword yang didukung: $ref, allOf, anyOf, oneOf, type (string atau list 3.1),
+// nullable, enum, properties, required, additionalProperties, items, minItems, maxItems,
+// minLength, maxLength, pattern, format (email, date-time), minimum, maximum.
+func (s *Spec) validate(value any, schema map[string]any, field string, out *Violations) {
+	s.validateDepth(value, schema, field, out, 0)
+}
+
+func (s *Spec) validateDepth(value any, schema map[string]any, field string, out *Violations, depth int) {
+	if schema == nil {
+		return
+	}
+
+	if depth > 32 {
+		out.add(field, "schema nesting too deep")
+		return
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, err := s.schema(ref)
+		if err != nil {
+			out.add(field, "%v", err)
+			return
+		}
+		s.validateDepth(value, resolved, field, out, depth+1)
+		return
+	}
+
+	for _, sub := range schemaList(schema["allOf"]) {
+		s.validateDepth(value, sub, field, out, depth+1)
+	}
+
+	if subs := schemaList(schema["anyOf"]); len(subs) > 0 && s.matching(value, subs, depth) == 0 {
+		out.add(field, "does not match any allowed schema")
+	}
+
+	if subs := schemaList(schema["oneOf"]); len(subs) > 0 {
+		if n := s.matching(value, subs, depth); n != 1 {
+			out.add(field, "matches %d schemas, want exactly one", n)
+		}
+	}
+
+	types := schemaTypes(schema["type"])
+	if value == nil {
+		nullable, _ := schema["nullable"].(bool)
+		if !nullable && len(types) > 0 && !slices.Contains(types, "null") {
+			out.add(field, "must not be null")
+		}
+		return
+	}
+
+	if len(types) > 0 && !slices.ContainsFunc(types, func(typ string) bool { return hasType(value, typ) }) {
+		out.add(field, "must be %s, got %s", strings.Join(types, " or "), typeName(value))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !inEnum(value, enum) {
+		out.add(field, "must be one of %v", enum)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		s.validateObject(v, schema, field, out, depth)
+	case []any:
+		if n, ok := number(schema["minItems"]); ok && float64(len(v)) < n {
+			out.add(field, "must have at least %v items", n)
+		}
+		if n, ok := number(schema["maxItems"]); ok && float64(len(v)) > n {
+			out.add(field, "must have at most %v items", n)
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				s.validateDepth(item, items, fmt.Sprintf("%s[%d]", field, i), out, depth+1)
+			}
+		}
+	case string:
+		validateString(v, schema, field, out)
+	case float64:
+		if n, ok := number(schema["minimum"]); ok && v < n {
+			out.add(field, "must be at least %v", n)
+		}
+		if n, ok := number(schema["maximum"]); ok && v > n {
+			out.add(field, "must be at most %v", n)
+		}
+	}
+}
+
+func (s *Spec) validateObject(v map[string]any, schema map[string]any, field string, out *Violations, depth int) {
+	properties, _ := schema["properties"].(map[string]any)
+
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			key, _ := name.(string)
+			if _, exists := v[key]; !exists {
+				out.add(join(field, key), "is required")
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if prop, ok := properties[key].(map[string]any); ok {
+			s.validateDepth(v[key], prop, join(field, key), out, depth+1)
+			continue
+		}
+
+		switch extra := schema["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				out.add(join(field, key), "is not allowed")
+			}
+		case map[string]any:
+			s.validateDepth(v[key], extra, join(field, key), out, depth+1)
+		}
+	}
+}
+
+func validateString(v string, schema map[string]any, field string, out *Violations) {
+	length := float64(utf8.RuneCountInString(v))
+	if n, ok := number(schema["minLength"]); ok && length < n {
+		out.add(field, "must be at least %v characters", n)
+	}
+	if n, ok := number(schema["maxLength"]); ok && length > n {
+		out.add(field, "must be at most %v characters", n)
+	}
+
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			out.add(field, "invalid pattern %q in spec", pattern)
+		} else if !re.MatchString(v) {
+			out.add(field, "must match %s", pattern)
+		}
+	}
+
+	switch schema["format"] {
+	case "email":
+		if addr, err := mail.ParseAddress(v); err != nil || addr.Address != v {
+			out.add(field, "must be an email address")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+			out.add(field, "must be an RFC 3339 date-time")
+		}
+	}
+}
+
+// matching jumlah schema di subs yang cocok dengan value
+func (s *Spec) matching(value any, subs []map[string]any, depth int) int {
+	n := 0
+	for _, sub := range subs {
+		var violations Violations
+		s.validateDepth(value, sub, "", &violations, depth+1)
+		if len(violations) == 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (s *Spec) schema(ref string) (map[string]any, error) {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	schema, exists := s.doc.Components.Schemas[name]
+	if !ok || !exists {
+		return nil, fmt.Errorf("unknown schema %s", ref)
+	}
+
+	return schema, nil
+}
+
+// schemaTypes type bisa berupa string (3.0) atau list (3.1, misal [string, "null"])
+func schemaTypes(v any) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			if typ, ok := item.(string); ok {
+				types = append(types, typ)
+			}
+		}
+		return types
+	default:
+		return nil
+	}
+}
+
+func schemaList(v any) []map[string]any {
+	list, _ := v.([]any)
+	out := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		if schema, ok := item.(map[string]any); ok {
+			out = append(out, schema)
+		}
+	}
+
+	return out
+}
+
+func hasType(value any, typ string) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	default:
+		return true
+	}
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func inEnum(value any, enum []any) bool {
+	for _, allowed := range enum {
+		if a, ok := number(allowed); ok {
+			if v, ok := value.(float64); ok && v == a {
+				return true
+			}
+			continue
+		}
+
+		if allowed == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// number menyamakan angka dari YAML (int/float64) dan JSON (float64)
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+func join(field, key string) string {
+	if field == "" {
+		return key
+	}
+
+	return field + "." + key
+}
@@ -0,0 +1,251 @@
+// Package openapi membangun dokumen OpenAPI 3.1 dari route yang didaftarkan lewat Router
+// (lihat document.go) dan memvalidasi request/response terhadapnya. Hanya subset OpenAPI yang
+// dipakai dokumen ini yang didukung (lihat schema.go).
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	document struct {
+		Paths      map[string]pathItem `yaml:"paths"`
+		Components components          `yaml:"components"`
+	}
+
+	components struct {
+		Schemas    map[string]map[string]any `yaml:"schemas"`
+		Parameters map[string]parameter      `yaml:"parameters"`
+		Responses  map[string]response       `yaml:"responses"`
+	}
+
+	pathItem struct {
+		Parameters []parameter `yaml:"parameters"`
+		Get        *operation  `yaml:"get"`
+		Put        *operation  `yaml:"put"`
+		Post       *operation  `yaml:"post"`
+		Patch      *operation  `yaml:"patch"`
+		Delete     *operation  `yaml:"delete"`
+	}
+
+	operation struct {
+		OperationID string                `yaml:"operationId"`
+		Summary     string                `yaml:"summary"`
+		Deprecated  bool                  `yaml:"deprecated"`
+		Security    []map[string][]string `yaml:"security"`
+		Parameters  []parameter           `yaml:"parameters"`
+		RequestBody *requestBody          `yaml:"requestBody"`
+		Responses   map[string]response   `yaml:"responses"`
+	}
+
+	parameter struct {
+		Ref             string         `yaml:"$ref"`
+		In              string         `yaml:"in"`
+		Name            string         `yaml:"name"`
+		Required        bool           `yaml:"required"`
+		AllowEmptyValue bool           `yaml:"allowEmptyValue"`
+		Description     string         `yaml:"description"`
+		Schema          map[string]any `yaml:"schema"`
+	}
+
+	requestBody struct {
+		Required bool                 `yaml:"required"`
+		Content  map[string]mediaType `yaml:"content"`
+	}
+
+	response struct {
+		Ref     string               `yaml:"$ref"`
+		Content map[string]mediaType `yaml:"content"`
+	}
+
+	mediaType struct {
+		Schema map[string]any `yaml:"schema"`
+	}
+)
+
+// Spec dokumen OpenAPI yang sudah di-parse dan siap dipakai untuk validasi
+type Spec struct {
+	doc        document
+	operations []Operation
+}
+
+// Operation satu pasangan method + path di dokumen
+type Operation struct {
+	Method string
+	Path   string
+	ID     string
+
+	spec     *Spec
+	op       *operation
+	segments []string
+	params   []parameter
+}
+
+func LoadFile(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read openapi spec: %w", err)
+	}
+
+	return Load(data)
+}
+
+func Load(data []byte) (*Spec, error) {
+	s := &Spec{}
+	if err := yaml.Unmarshal(data, &s.doc); err != nil {
+		return nil, fmt.Errorf("parse openapi spec: %w", err)
+	}
+
+	for path, item := range s.doc.Paths {
+		for method, op := range item.operations() {
+			params, err := s.mergeParameters(item.Parameters, op.Parameters)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+
+			s.operations = append(s.operations, Operation{
+				Method:   method,
+				Path:     path,
+				ID:       op.OperationID,
+				spec:     s,
+				op:       op,
+				segments: splitPath(path),
+				params:   params,
+			})
+		}
+	}
+
+	sort.Slice(s.operations, func(i, j int) bool {
+		if s.operations[i].Path != s.operations[j].Path {
+			return s.operations[i].Path < s.operations[j].Path
+		}
+
+		return s.operations[i].Method < s.operations[j].Method
+	})
+
+	return s, nil
+}
+
+// Operations semua operation, urut berdasarkan path lalu method
+func (s *Spec) Operations() []Operation {
+	return s.operations
+}
+
+// Find mencari operation untuk request. Path literal menang atas path dengan parameter,
+// jadi /admin/emails/templates tidak tertangkap oleh /admin/emails/{id}.
+func (s *Spec) Find(method, path string) (Operation, map[string]string, bool) {
+	segments := splitPath(path)
+
+	var (
+		best       Operation
+		bestParams map[string]string
+		found      bool
+	)
+	for _, op := range s.operations {
+		if op.Method != method {
+			continue
+		}
+
+		params, ok := matchPath(op.segments, segments)
+		if !ok {
+			continue
+		}
+
+		if !found || len(params) < len(bestParams) {
+			best, bestParams, found = op, params, true
+		}
+	}
+
+	return best, bestParams, found
+}
+
+func (p pathItem) operations() map[string]*operation {
+	ops := map[string]*operation{}
+	for method, op := range map[string]*operation{
+		http.MethodGet:    p.Get,
+		http.MethodPut:    p.Put,
+		http.MethodPost:   p.Post,
+		http.MethodPatch:  p.Patch,
+		http.MethodDelete: p.Delete,
+	} {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+
+	return ops
+}
+
+// mergeParameters menggabungkan parameter path item dan operation, yang di operation menang
+func (s *Spec) mergeParameters(shared, own []parameter) ([]parameter, error) {
+	var params []parameter
+	index := map[string]int{}
+	for _, p := range append(append([]parameter{}, shared...), own...) {
+		if p.Ref != "" {
+			name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/")
+			resolved, exists := s.doc.Components.Parameters[name]
+			if !ok || !exists {
+				return nil, fmt.Errorf("unknown parameter %s", p.Ref)
+			}
+			p = resolved
+		}
+
+		key := p.In + ":" + strings.ToLower(p.Name)
+		if i, ok := index[key]; ok {
+			params[i] = p
+			continue
+		}
+
+		index[key] = len(params)
+		params = append(params, p)
+	}
+
+	return params, nil
+}
+
+func (s *Spec) response(r response) (response, error) {
+	if r.Ref == "" {
+		return r, nil
+	}
+
+	name, ok := strings.CutPrefix(r.Ref, "#/components/responses/")
+	resolved, exists := s.doc.Components.Responses[name]
+	if !ok || !exists {
+		return response{}, fmt.Errorf("unknown response %s", r.Ref)
+	}
+
+	return resolved, nil
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func matchPath(template, segments []string) (map[string]string, bool) {
+	if len(template) != len(segments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, seg := range template {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params[seg[1:len(seg)-1]] = segments[i]
+			continue
+		}
+
+		if seg != segments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
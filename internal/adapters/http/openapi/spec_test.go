@@ -0,0 +1,265 @@
+package openapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testSpec = `
+openapi: 3.0.3
+paths:
+  /items:
+    get:
+      operationId: listItems
+      parameters:
+        - in: query
+          name: limit
+          schema:
+            type: integer
+            minimum: 1
+            maximum: 100
+        - in: query
+          name: status
+          schema:
+            type: string
+            enum: [active, archived]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/ItemList"
+    post:
+      operationId: createItem
+      parameters:
+        - $ref: "#/components/parameters/IdempotencyKey"
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Item"
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Item"
+        "400":
+          $ref: "#/components/responses/BadRequest"
+  /items/{id}:
+    get:
+      operationId: getItem
+      parameters:
+        - in: path
+          name: id
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+  /items/featured:
+    get:
+      operationId: featuredItems
+      responses:
+        "204":
+          description: No content
+components:
+  parameters:
+    IdempotencyKey:
+      in: header
+      name: Idempotency-Key
+      schema:
+        type: string
+        maxLength: 5
+  responses:
+    BadRequest:
+      description: Invalid input
+      content:
+        application/problem+json:
+          schema:
+            allOf:
+              - $ref: "#/components/schemas/Problem"
+              - properties:
+                  status:
+                    enum: [400]
+  schemas:
+    Item:
+      type: object
+      properties:
+        name:
+          type: string
+          minLength: 1
+        email:
+          type: string
+          format: email
+        tags:
+          type: array
+          items:
+            type: string
+      required: [name]
+      additionalProperties: false
+    ItemList:
+      type: object
+      properties:
+        data:
+          type: array
+          items:
+            $ref: "#/components/schemas/Item"
+      required: [data]
+    Problem:
+      type: object
+      properties:
+        status:
+          type: integer
+      required: [status]
+`
+
+func loadTestSpec(t *testing.T) *Spec {
+	t.Helper()
+
+	spec, err := Load([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	return spec
+}
+
+func TestSpec_Find(t *testing.T) {
+	spec := loadTestSpec(t)
+
+	tests := []struct {
+		method, path, want string
+		params             map[string]string
+	}{
+		{http.MethodGet, "/items", "listItems", nil},
+		{http.MethodPost, "/items", "createItem", nil},
+		{http.MethodGet, "/items/42", "getItem", map[string]string{"id": "42"}},
+		// path literal menang atas {id}
+		{http.MethodGet, "/items/featured", "featuredItems", nil},
+		{http.MethodDelete, "/items/42", "", nil},
+		{http.MethodGet, "/items/42/extra", "", nil},
+	}
+
+	for _, tt := range tests {
+		op, params, ok := spec.Find(tt.method, tt.path)
+		if ok != (tt.want != "") || op.ID != tt.want {
+			t.Errorf("Find(%s %s) = %q, %v, want %q", tt.method, tt.path, op.ID, ok, tt.want)
+			continue
+		}
+
+		for k, v := range tt.params {
+			if params[k] != v {
+				t.Errorf("Find(%s %s) params = %v, want %v", tt.method, tt.path, params, tt.params)
+			}
+		}
+	}
+}
+
+func TestOperation_ValidateRequest(t *testing.T) {
+	spec := loadTestSpec(t)
+
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		body    string
+		header  map[string]string
+		invalid []string
+	}{
+		{name: "valid query", method: http.MethodGet, target: "/items?limit=10&status=active"},
+		{name: "query out of range", method: http.MethodGet, target: "/items?limit=500", invalid: []string{"limit"}},
+		{name: "query not an integer", method: http.MethodGet, target: "/items?limit=ten", invalid: []string{"limit"}},
+		{name: "query outside enum", method: http.MethodGet, target: "/items?status=deleted", invalid: []string{"status"}},
+		{name: "valid body", method: http.MethodPost, target: "/items", body: `{"name":"Sepatu","email":"a@mail.com","tags":["x"]}`},
+		{name: "missing body", method: http.MethodPost, target: "/items", invalid: []string{""}},
+		{
+			name: "body fields", method: http.MethodPost, target: "/items",
+			body:    `{"email":"not-an-email","tags":[1],"color":"red"}`,
+			invalid: []string{"name", "email", "tags[0]", "color"},
+		},
+		{name: "header too long", method: http.MethodPost, target: "/items", body: `{"name":"a"}`, header: map[string]string{"Idempotency-Key": "123456"}, invalid: []string{"Idempotency-Key"}},
+		// JSON rusak dibiarkan lolos, handler yang mengembalikan INVALID_JSON
+		{name: "malformed json", method: http.MethodPost, target: "/items", body: `{"name":`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader = http.NoBody
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			r := httptest.NewRequest(tt.method, tt.target, body)
+			r.Header.Set("Content-Type", "application/json")
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+
+			op, params, _ := spec.Find(tt.method, r.URL.Path)
+			err := op.ValidateRequest(r, params)
+
+			got := map[string]bool{}
+			if violations, ok := err.(Violations); ok {
+				for _, v := range violations {
+					got[v.Field] = true
+				}
+			}
+			if len(got) != len(tt.invalid) {
+				t.Fatalf("ValidateRequest() = %v, want violations for %q", err, tt.invalid)
+			}
+			for _, field := range tt.invalid {
+				if !got[field] {
+					t.Errorf("ValidateRequest() = %v, want a violation for %q", err, field)
+				}
+			}
+
+			// body tetap bisa dibaca handler
+			if rest, _ := io.ReadAll(r.Body); string(rest) != tt.body {
+				t.Errorf("body after validation = %q, want %q", rest, tt.body)
+			}
+		})
+	}
+}
+
+func TestOperation_ValidateResponse(t *testing.T) {
+	spec := loadTestSpec(t)
+	create, _, _ := spec.Find(http.MethodPost, "/items")
+	featured, _, _ := spec.Find(http.MethodGet, "/items/featured")
+
+	jsonHeader := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+	problemHeader := http.Header{"Content-Type": {"application/problem+json"}}
+
+	tests := []struct {
+		name   string
+		op     Operation
+		status int
+		header http.Header
+		body   string
+		valid  bool
+	}{
+		{"documented", create, http.StatusCreated, jsonHeader, `{"name":"Sepatu"}`, true},
+		{"schema mismatch", create, http.StatusCreated, jsonHeader, `{"name":1}`, false},
+		{"undocumented status", create, http.StatusConflict, problemHeader, `{"status":409}`, false},
+		{"undocumented content type", create, http.StatusCreated, http.Header{"Content-Type": {"text/plain"}}, `ok`, false},
+		{"response ref with allOf", create, http.StatusBadRequest, problemHeader, `{"status":400}`, true},
+		{"allOf enum", create, http.StatusBadRequest, problemHeader, `{"status":422}`, false},
+		{"no content", featured, http.StatusNoContent, http.Header{}, ``, true},
+		{"unexpected body", featured, http.StatusNoContent, jsonHeader, `{}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.op.ValidateResponse(tt.status, tt.header, []byte(tt.body))
+			if (err == nil) != tt.valid {
+				t.Errorf("ValidateResponse() error = %v, want valid = %v", err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxBodyBytes body yang lebih besar tidak divalidasi, handler sendiri yang menolaknya (413)
+const maxBodyBytes = 1 << 20
+
+// ValidateRequest mengecek parameter dan body request. Body dibaca lalu dikembalikan ke r.Body
+// supaya handler tetap bisa membacanya. Body yang bukan JSON valid dilewati, biar handler yang
+// mengembalikan INVALID_JSON seperti biasa.
+func (o Operation) ValidateRequest(r *http.Request, pathParams map[string]string) error {
+	var out Violations
+
+	query := r.URL.Query()
+	for _, p := range o.params {
+		var (
+			raw     string
+			present bool
+		)
+		switch p.In {
+		case "path":
+			raw, present = pathParams[p.Name]
+		case "query":
+			raw, present = query.Get(p.Name), query.Has(p.Name)
+		case "header":
+			raw = r.Header.Get(p.Name)
+			present = raw != ""
+		default:
+			continue
+		}
+
+		if !present {
+			if p.Required {
+				out.add(p.Name, "is required")
+			}
+			continue
+		}
+
+		if raw == "" && p.AllowEmptyValue {
+			continue
+		}
+
+		o.spec.validate(coerce(raw, p.Schema), p.Schema, p.Name, &out)
+	}
+
+	o.validateRequestBody(r, &out)
+	return out.orNil()
+}
+
+func (o Operation) validateRequestBody(r *http.Request, out *Violations) {
+	if r.Body == nil || r.Body == http.NoBody {
+		if o.op.RequestBody != nil && o.op.RequestBody.Required {
+			out.add("", "request body is required")
+		}
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+	if err != nil || len(body) > maxBodyBytes || o.op.RequestBody == nil {
+		return
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if o.op.RequestBody.Required {
+			out.add("", "request body is required")
+		}
+		return
+	}
+
+	contentType := mediaTypeOf(r.Header.Get("Content-Type"))
+	media, ok := o.op.RequestBody.Content[contentType]
+	if !ok {
+		out.add("", "content type %q is not documented", contentType)
+		return
+	}
+
+	var value any
+	if !isJSON(contentType) || json.Unmarshal(body, &value) != nil {
+		return
+	}
+
+	o.spec.validate(value, media.Schema, "", out)
+}
+
+// ValidateResponse mengecek status, Content-Type dan body response. Status dicari persis,
+// lalu "4XX" dan terakhir "default".
+func (o Operation) ValidateResponse(status int, header http.Header, body []byte) error {
+	resp, ok := o.op.Responses[strconv.Itoa(status)]
+	if !ok {
+		resp, ok = o.op.Responses[fmt.Sprintf("%dXX", status/100)]
+	}
+	if !ok {
+		resp, ok = o.op.Responses["default"]
+	}
+	if !ok {
+		return Violations{{Message: fmt.Sprintf("status %d is not documented", status)}}
+	}
+
+	resp, err := o.spec.response(resp)
+	if err != nil {
+		return Violations{{Message: err.Error()}}
+	}
+
+	var out Violations
+	if len(resp.Content) == 0 {
+		if len(bytes.TrimSpace(body)) > 0 {
+			out.add("", "status %d is documented without a body", status)
+		}
+		return out.orNil()
+	}
+
+	contentType := mediaTypeOf(header.Get("Content-Type"))
+	media, ok := resp.Content[contentType]
+	if !ok {
+		out.add("", "content type %q is not documented for status %d", contentType, status)
+		return out
+	}
+
+	if !isJSON(contentType) || media.Schema == nil {
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		out.add("", "invalid JSON body: %v", err)
+		return out
+	}
+
+	o.spec.validate(value, media.Schema, "", &out)
+	return out.orNil()
+}
+
+// coerce mengubah nilai parameter (selalu string di URL/header) sesuai type di schema
+func coerce(raw string, schema map[string]any) any {
+	switch schema["type"] {
+	case "integer", "number":
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+
+	return raw
+}
+
+func mediaTypeOf(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	return mediaType
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
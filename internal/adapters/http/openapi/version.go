@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+)
+
+// APIVersion satu versi API yang dipasang di /<Name> lewat Router.Version
+type APIVersion struct {
+	// Name v1, v2, dan seterusnya, dibandingkan secara numerik
+	Name string
+	// Deprecated dan Sunset berlaku untuk semua operation di versi ini
+	Deprecated, Sunset time.Time
+}
+
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+	HeaderLink        = "Link"
+)
+
+// available apakah operation tersedia di versi, route di luar grup versi selalu tersedia
+func (d Doc) available(version string) bool {
+	if version == "" {
+		return true
+	}
+	if d.Since != "" && compareVersions(version, d.Since) < 0 {
+		return false
+	}
+	if d.Until != "" && compareVersions(version, d.Until) >= 0 {
+		return false
+	}
+
+	return true
+}
+
+// compareVersions membandingkan v2 dan v10 sebagai angka, nama lain secara leksikal
+func compareVersions(a, b string) int {
+	na, errA := strconv.Atoi(strings.TrimPrefix(a, "v"))
+	nb, errB := strconv.Atoi(strings.TrimPrefix(b, "v"))
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	switch {
+	case na < nb:
+		return -1
+	case na > nb:
+		return 1
+	}
+	return 0
+}
+
+// withVersion menyimpan versi API di context request, lihat httpx.APIVersion
+func withVersion(version string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(httpx.WithAPIVersion(r.Context(), version)))
+		})
+	}
+}
+
+// deprecation menambahkan header Deprecation (RFC 9745), Sunset (RFC 8594) dan Link ke versi
+// pengganti sebelum handler jalan, supaya ikut terkirim di response error
+func deprecation(doc Doc, successor string, next http.HandlerFunc) http.HandlerFunc {
+	if doc.Deprecated.IsZero() && doc.Sunset.IsZero() && successor == "" {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		if !doc.Deprecated.IsZero() {
+			h.Set(HeaderDeprecation, "@"+strconv.FormatInt(doc.Deprecated.Unix(), 10))
+		}
+		if !doc.Sunset.IsZero() {
+			h.Set(HeaderSunset, doc.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if successor != "" {
+			h.Add(HeaderLink, "<"+successor+r.URL.Path+`>; rel="successor-version"`)
+		}
+
+		next(w, r)
+	}
+}
@@ -1,93 +1,146 @@
 package router
 
 import (
-	"fmt"
 	"net/http"
-	"runtime"
+	"strings"
 	"time"
 
 	"villainrsty-ecommerce-server/internal/adapters/http/auth/routes"
+	emailRoutes "villainrsty-ecommerce-server/internal/adapters/http/emails/routes"
+	healthRoutes "villainrsty-ecommerce-server/internal/adapters/http/health/routes"
 	"villainrsty-ecommerce-server/internal/adapters/http/httpx"
+	jobRoutes "villainrsty-ecommerce-server/internal/adapters/http/jobs/routes"
+	authMiddleware "villainrsty-ecommerce-server/internal/adapters/http/middleware"
+	"villainrsty-ecommerce-server/internal/adapters/http/openapi"
 	"villainrsty-ecommerce-server/internal/app"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	"github.com/swaggest/swgui/v5emb"
 )
 
-var startTime = time.Now()
+// versions versi API yang dipasang di /<versi>, urut dari yang paling lama. Versi baru hanya
+// ditambahkan untuk perubahan yang tidak kompatibel, bedanya diatur lewat openapi.Doc.Since dan Until.
+var versions = []openapi.APIVersion{
+	{Name: "v1"},
+}
+
+// legacy route lama tanpa prefix versi (/auth/...) yang masih dipakai aplikasi mobile, tetap
+// dilayani sebagai alias v1 sampai Sunset
+var legacy = openapi.APIVersion{
+	Name:       "v1",
+	Deprecated: time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+	Sunset:     time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC),
+}
 
 func New(container *app.Container) *chi.Mux {
 	r := chi.NewRouter()
 
-	r.Use(middleware.Logger, middleware.Recoverer)
-
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"http://localhost:5500", "http://127.0.0.1:5500"},
-		AllowedMethods:   []string{"GET", "POST", "PATCH", "DELETE", "PUT", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization"},
-		AllowCredentials: true,
-	}))
-
-	r.Get("/", func(w http.ResponseWriter, _ *http.Request) {
-		httpx.JSON(w, http.StatusOK, map[string]any{
-			"success": true,
-			"message": "Server API Villainrsty Ecommerce is running",
-		})
+	if container.TrustProxy {
+		r.Use(middleware.RealIP)
+	}
+
+	r.Use(
+		authMiddleware.RequestID,
+		authMiddleware.Tracing,
+		authMiddleware.Metrics(container.Metrics),
+		authMiddleware.RequestLogger(container.Logger),
+		middleware.Recoverer,
+		authMiddleware.Locale,
+	)
+
+	r.Use(container.Security, container.CORS)
+
+	docs := make([]*openapi.Document, len(versions))
+	for i, v := range versions {
+		docs[i] = newDocument(v)
+	}
+
+	if container.ValidateOpenAPI {
+		r.Use(authMiddleware.OpenAPIValidator(container.Logger, docs...))
+	}
+
+	// route umum (index, health, problems) tetap di root dan tercatat di dokumen setiap versi
+	api := openapi.NewRouter(r, docs...)
+	general := api.Describe(openapi.Doc{Tags: []string{"General"}})
+
+	general.Get("/", func(w http.ResponseWriter, _ *http.Request) {
+		httpx.Success(w, http.StatusOK, "Server API Villainrsty Ecommerce is running", "")
+	}, openapi.Doc{
+		ID:        "healthCheck",
+		Summary:   "Index",
+		Responses: []openapi.Response{{Status: http.StatusOK, Description: "Server is running", Body: httpx.BaseResponse[string]{}}},
 	})
 
-	r.Get("/health", healtHandler)
+	healthRoutes.RegisterRoute(api, container.HealthHandler)
 	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
-		httpx.ErrorWithDetails(
-			w,
-			http.StatusNotFound,
-			"Route not found",
-			"NOT_FOUND",
-			map[string]any{
-				"path":   r.URL.Path,
-				"method": r.Method,
-			})
+		httpx.WriteError(w, r, errors.NewCode(errors.CodeRouteNotFound, r.Method+" "+r.URL.Path+" does not exist"))
 	})
 
-	r.Get("/openapi.yml", func(w http.ResponseWriter, r *http.Request) {
-		http.ServeFile(w, r, "./openapi.yml")
+	general.Get("/problems", httpx.ProblemTypes, openapi.Doc{
+		ID:        "listProblemTypes",
+		Summary:   "List problem types returned in error responses",
+		Responses: []openapi.Response{{Status: http.StatusOK, Description: "Every registered error code", Body: httpx.BaseResponse[[]httpx.ProblemTypeDTO]{}}},
+	})
+	general.Get("/problems/{slug}", httpx.ProblemTypes, openapi.Doc{
+		ID:        "getProblemType",
+		Summary:   "Describe one problem type",
+		Responses: []openapi.Response{{Status: http.StatusOK, Description: "Problem type", Body: httpx.BaseResponse[httpx.ProblemTypeDTO]{}}},
+		Errors:    []int{http.StatusNotFound},
 	})
 
-	// Pasang Swagger UI v5 (Support v3.0 & v3.1)
-	// New(Judul, Path_ke_YAML, Path_di_Browser)
-	r.Mount("/docs", v5emb.New(
-		"Villainrsty API",
-		"/openapi.yml",
-		"/docs",
-	))
-
-	routes.RegisterRoute(r, container.AuthHandler)
+	// dokumen dibangun dari route yang didaftarkan lewat api, bukan file statis
+	for i, v := range versions {
+		base := "/docs/" + v.Name
+		r.Method(http.MethodGet, base+"/openapi.yml", docs[i])
+
+		// Pasang Swagger UI v5 (Support v3.0 & v3.1)
+		// New(Judul, Path_ke_YAML, Path_di_Browser)
+		r.With(container.DocsCSP).Mount(base, v5emb.New("Villainrsty API "+v.Name, base+"/openapi.yml", base))
+	}
+	r.Get("/docs", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/docs/"+versions[len(versions)-1].Name, http.StatusFound)
+	})
 
-	return r
-}
+	mount := func(r *openapi.Router) {
+		routes.RegisterRoute(r, container.AuthHandler, container.RateLimiter, container.Idempotency, authMiddleware.AuthJWT(container.JWTService))
 
-func healtHandler(w http.ResponseWriter, _ *http.Request) {
-	requestStart := time.Now()
+		r.Group(func(r *openapi.Router) {
+			r.Use(
+				authMiddleware.AuthJWT(container.JWTService),
+				authMiddleware.AdminOnly(container.AdminEmails),
+				container.RateLimiter.Limit(jobRoutes.RateLimitAdmin),
+			)
 
-	uptime := time.Since(startTime)
-	hours := int(uptime.Hours())
-	minutes := int(uptime.Minutes()) % 60
-	seconds := int(uptime.Seconds()) % 60
+			r = r.Describe(openapi.Doc{
+				Tags:   []string{"Admin"},
+				Auth:   true,
+				Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests},
+			})
+			jobRoutes.RegisterRoute(r, container.JobHandler, container.Idempotency)
+			emailRoutes.RegisterRoute(r, container.EmailHandler, container.Idempotency)
+			healthRoutes.RegisterAdminRoute(r, container.HealthHandler)
+		})
+	}
 
-	var mem runtime.MemStats
-	runtime.ReadMemStats(&mem)
+	for i, v := range versions {
+		api.Version(v, docs[i], mount)
+	}
+	api.Legacy(legacy, mount)
 
-	latency := time.Since(requestStart)
+	return r
+}
 
-	httpx.JSON(w, http.StatusOK, map[string]any{
-		"status":    "ok",
-		"timestamp": time.Now().Format(time.RFC3339),
-		"uptime":    fmt.Sprintf("%dh %dm %ds", hours, minutes, seconds),
-		"latency":   fmt.Sprintf("%.2f ms", float64(latency.Microseconds())/1000),
-		"memory": map[string]string{
-			"heapUsed":  fmt.Sprintf("%d MB", mem.HeapAlloc/1024/1024),
-			"heapTotal": fmt.Sprintf("%d MB", mem.HeapSys/1024/1024),
-		},
-	})
+// newDocument dokumen OpenAPI satu versi, disajikan di /docs/<versi>/openapi.yml
+func newDocument(v openapi.APIVersion) *openapi.Document {
+	return openapi.NewDocument(openapi.Info{
+		Title:       "Villainrsty Ecommerce API",
+		Version:     strings.TrimPrefix(v.Name, "v") + ".0.0",
+		Description: "API Documentation for Villainrsty E-Commerce Platform.\nCovers authentication, health probes, problem types and operator (admin) endpoints.\n",
+	},
+		openapi.Tag{Name: "General", Description: "Health check and general info"},
+		openapi.Tag{Name: "Auth", Description: "Authentication endpoints"},
+		openapi.Tag{Name: "Admin", Description: "Operator endpoints (admin only)"},
+	)
 }
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/jobs/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector membaca pgxpool.Stat() setiap scrape
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquireCount      *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	emptyAcquireCount *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("pgxpool_"+name, help, nil, nil)
+	}
+
+	return &poolCollector{
+		pool:              pool,
+		acquiredConns:     desc("acquired_conns", "Koneksi yang sedang dipakai."),
+		idleConns:         desc("idle_conns", "Koneksi idle di pool."),
+		totalConns:        desc("total_conns", "Total koneksi yang dibuka pool."),
+		maxConns:          desc("max_conns", "Batas maksimum koneksi pool."),
+		acquireCount:      desc("acquire_count_total", "Jumlah acquire koneksi yang berhasil."),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Total waktu menunggu acquire koneksi."),
+		emptyAcquireCount: desc("empty_acquire_count_total", "Acquire yang harus menunggu karena pool kosong."),
+		canceledAcquires:  desc("canceled_acquire_count_total", "Acquire yang batal karena context dibatalkan."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquires
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
+
+// jobQueueCollector menghitung job per status langsung dari database saat scrape.
+// Status succeeded tidak dihitung karena terus bertambah dan tidak menggambarkan antrean.
+type jobQueueCollector struct {
+	repo    ports.JobRepository
+	logger  *slog.Logger
+	timeout time.Duration
+	depth   *prometheus.Desc
+}
+
+func NewJobQueueCollector(repo ports.JobRepository, logger *slog.Logger) prometheus.Collector {
+	return &jobQueueCollector{
+		repo:    repo,
+		logger:  logger,
+		timeout: 2 * time.Second,
+		depth:   prometheus.NewDesc("job_queue_depth", "Jumlah job per status di antrean.", []string{"status"}, nil),
+	}
+}
+
+func (c *jobQueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.depth
+}
+
+func (c *jobQueueCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	for _, status := range []models.JobStatus{models.JobStatusPending, models.JobStatusRunning, models.JobStatusDead} {
+		count, err := c.repo.CountByStatus(ctx, status)
+		if err != nil {
+			// metrik yang gagal dibaca dilewati, scrape lain tetap jalan
+			c.logger.Warn("failed to count jobs for metrics", "status", string(status), "error", err)
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.depth, prometheus.GaugeValue, float64(count), string(status))
+	}
+}
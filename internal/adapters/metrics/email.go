@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"context"
+
+	"villainrsty-ecommerce-server/internal/core/emails/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+// emailTransport membungkus transport asli (SMTP) dan menghitung hasil setiap pengiriman
+type emailTransport struct {
+	next    ports.EmailTransport
+	metrics *Metrics
+}
+
+// InstrumentEmailTransport dipasang di sisi worker, bukan di sisi enqueue, supaya yang dihitung
+// adalah pengiriman yang benar-benar terjadi (termasuk retry)
+func InstrumentEmailTransport(next ports.EmailTransport, m *Metrics) ports.EmailTransport {
+	return &emailTransport{next: next, metrics: m}
+}
+
+func (t *emailTransport) Deliver(ctx context.Context, msg *models.EmailMessage) (string, error) {
+	id, err := t.next.Deliver(ctx, msg)
+	t.metrics.observeEmail(msg.Template, err == nil)
+
+	return id, err
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+// Metrics menyimpan registry Prometheus milik aplikasi. Registry sendiri (bukan default global)
+// supaya test bisa membuat instance baru tanpa bentrok registrasi.
+type Metrics struct {
+	registry     *prometheus.Registry
+	httpDuration *prometheus.HistogramVec
+	emailSends   *prometheus.CounterVec
+	logins       *prometheus.CounterVec
+	twoFactor    *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Durasi request HTTP per pola route chi.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		emailSends: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "email_sends_total",
+			Help: "Jumlah pengiriman email ke SMTP per jenis dan hasil.",
+		}, []string{"kind", "result"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_logins_total",
+			Help: "Jumlah percobaan login per metode dan hasil.",
+		}, []string{"method", "result"}),
+		twoFactor: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_two_factor_total",
+			Help: "Jumlah langkah 2FA (challenge, verify) per hasil.",
+		}, []string{"step", "result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpDuration,
+		m.emailSends,
+		m.logins,
+		m.twoFactor,
+	)
+
+	return m
+}
+
+// Register menambahkan collector lain (pool database, antrean job) ke registry aplikasi
+func (m *Metrics) Register(c prometheus.Collector) {
+	m.registry.MustRegister(c)
+}
+
+// Handler melayani /metrics dalam format exposition Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.httpDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveLogin(method string, success bool) {
+	m.logins.WithLabelValues(method, result(success)).Inc()
+}
+
+func (m *Metrics) ObserveTwoFactor(step string, success bool) {
+	m.twoFactor.WithLabelValues(step, result(success)).Inc()
+}
+
+func (m *Metrics) observeEmail(kind string, success bool) {
+	m.emailSends.WithLabelValues(kind, result(success)).Inc()
+}
+
+func result(success bool) string {
+	if success {
+		return resultSuccess
+	}
+
+	return resultFailure
+}
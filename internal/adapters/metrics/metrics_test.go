@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+type stubEmailTransport struct {
+	err error
+}
+
+func (s stubEmailTransport) Deliver(context.Context, *models.EmailMessage) (string, error) {
+	return "", s.err
+}
+
+func TestHandler_ExposesAppMetrics(t *testing.T) {
+	m := New()
+	m.ObserveHTTPRequest(http.MethodPost, "/auth/login-2fa", http.StatusOK, 120*time.Millisecond)
+	m.ObserveLogin("password", false)
+	m.ObserveTwoFactor("verify", true)
+
+	ok := InstrumentEmailTransport(stubEmailTransport{}, m)
+	failing := InstrumentEmailTransport(stubEmailTransport{err: errors.New("smtp down")}, m)
+	_, _ = ok.Deliver(context.Background(), &models.EmailMessage{Template: "login_otp"})
+	_, _ = failing.Deliver(context.Background(), &models.EmailMessage{Template: "password_reset"})
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		`http_request_duration_seconds_count{method="POST",route="/auth/login-2fa",status="200"} 1`,
+		`auth_logins_total{method="password",result="failure"} 1`,
+		`auth_two_factor_total{result="success",step="verify"} 1`,
+		`email_sends_total{kind="login_otp",result="success"} 1`,
+		`email_sends_total{kind="password_reset",result="failure"} 1`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package capture
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+// Message satu email yang "dikirim" lewat EmailSender
+type Message struct {
+	Template string
+	To       string
+	Locale   i18n.Locale
+	Data     map[string]any
+}
+
+// EmailSender menyimpan email di memori alih-alih mengirimnya. Untuk test yang perlu
+// membaca isi email, misalnya link reset password atau kode OTP.
+type EmailSender struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewEmailSender() *EmailSender {
+	return &EmailSender{}
+}
+
+func (s *EmailSender) Send(ctx context.Context, templateID, toEmail string, data map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, Message{
+		Template: templateID,
+		To:       toEmail,
+		Locale:   i18n.FromContext(ctx),
+		Data:     maps.Clone(data),
+	})
+	return nil
+}
+
+// Messages semua email sesuai urutan pengiriman
+func (s *EmailSender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.messages)
+}
+
+// Last email terakhir dengan template dan penerima tertentu, false kalau belum ada
+func (s *EmailSender) Last(templateID, toEmail string) (Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		if m := s.messages[i]; m.Template == templateID && m.To == toEmail {
+			return m, true
+		}
+	}
+
+	return Message{}, false
+}
+
+func (s *EmailSender) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = nil
+}
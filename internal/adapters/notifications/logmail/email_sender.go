@@ -0,0 +1,35 @@
+package logmail
+
+import (
+	"context"
+	"log/slog"
+
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/logger"
+)
+
+// EmailSender menulis isi email ke log alih-alih mengirim ke SMTP.
+// Hanya untuk development: data template (link reset, kode OTP) ikut tercatat di log.
+type EmailSender struct {
+	logger *slog.Logger
+}
+
+func NewEmailSender(logger *slog.Logger) *EmailSender {
+	return &EmailSender{logger: logger}
+}
+
+func (s *EmailSender) Deliver(ctx context.Context, msg *models.EmailMessage) (string, error) {
+	if msg.ToEmail == "" {
+		return "", errors.New(errors.ErrValidation, "email is required")
+	}
+
+	logger.FromContext(ctx, s.logger).Info("email not sent (log driver)",
+		"message_id", msg.ID.String(),
+		"template", msg.Template,
+		"locale", msg.Locale,
+		"to", msg.ToEmail,
+		"data", msg.Data,
+	)
+	return "", nil
+}
@@ -0,0 +1,27 @@
+package queue
+
+import (
+	"context"
+
+	emailPorts "villainrsty-ecommerce-server/internal/core/emails/ports"
+	emailService "villainrsty-ecommerce-server/internal/core/emails/service"
+	"villainrsty-ecommerce-server/internal/core/jobs/service"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+// RegisterEmailHandlers mendaftarkan handler job pengiriman email ke worker
+func RegisterEmailHandlers(worker *service.Worker, emails emailPorts.EmailService) {
+	service.Handle(worker, emailService.JobDeliverEmail, func(ctx context.Context, p emailService.DeliverPayload) error {
+		return permanentOnValidation(emails.Deliver(ctx, models.ID(p.MessageID), service.FinalAttempt(ctx)))
+	})
+}
+
+// permanentOnValidation data yang salah atau pesan yang hilang tidak akan berhasil walau di-retry
+func permanentOnValidation(err error) error {
+	if errors.IsKind(err, errors.ErrValidation) || errors.IsKind(err, errors.ErrNotFound) {
+		return service.Permanent(err)
+	}
+
+	return err
+}
@@ -2,15 +2,30 @@ package smtp
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/mail"
 	"net/smtp"
-	"strings"
+	"strconv"
 	"time"
 
+	"villainrsty-ecommerce-server/internal/adapters/notifications/templates"
 	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/logger"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("villainrsty-ecommerce-server/internal/adapters/notifications/smtp")
+
+// EmailSender mengimplementasikan emails ports.EmailTransport lewat server SMTP
 type EmailSender struct {
 	host      string
 	port      string
@@ -18,9 +33,11 @@ type EmailSender struct {
 	password  string
 	fromEmail string
 	fromName  string
+	renderer  *templates.Renderer
+	logger    *slog.Logger
 }
 
-func NewEmailSender(host, port, username, password, fromEmail, fromName string) *EmailSender {
+func NewEmailSender(host, port, username, password, fromEmail, fromName string, renderer *templates.Renderer, logger *slog.Logger) *EmailSender {
 	return &EmailSender{
 		host:      host,
 		port:      port,
@@ -28,118 +45,144 @@ func NewEmailSender(host, port, username, password, fromEmail, fromName string)
 		password:  password,
 		fromEmail: fromEmail,
 		fromName:  fromName,
+		renderer:  renderer,
+		logger:    logger,
 	}
 }
 
-func (s *EmailSender) SendPasswordReset(ctx context.Context, toEmail, resetLink string) error {
-	if toEmail == "" {
-		return errors.New(errors.ErrValidation, "email is required")
-	}
+// Deliver mengembalikan Message-ID sebagai provider message ID. Batas waktu mengikuti ctx
+// (timeout job di worker), koneksi ditutup begitu ctx selesai.
+func (s *EmailSender) Deliver(ctx context.Context, msg *models.EmailMessage) (messageID string, err error) {
+	ctx, span := s.startSpan(ctx, msg.Template)
+	defer func() { endSpan(span, err) }()
 
-	if resetLink == "" {
-		return errors.New(errors.ErrValidation, "reset link is required")
+	if msg.ToEmail == "" {
+		return "", errors.New(errors.ErrValidation, "email is required")
 	}
 
-	subject := "Reset Password"
-	plainBody := fmt.Sprintf("klik link berikut untuk reset password Anda: %s\nLink berlaku sementara.", resetLink)
-	htmlBody := fmt.Sprintf(`<p>Klik link berikut untuk reset password Anda:</p><p><a href="%s">Reset Password</a></p><p>Link berlaku sementara</p>`, resetLink)
+	email, err := s.renderer.Render(msg.Locale, msg.Template, msg.Data)
+	if err != nil {
+		return "", err
+	}
 
-	msg := buildMIMEMessage(s.fromName, s.fromEmail, toEmail, subject, plainBody, htmlBody)
-	addr := fmt.Sprintf("%s:%s", s.host, s.port)
-	auth := smtp.PlainAuth("", s.username, s.password, s.host)
+	raw, messageID, err := buildMessage(mail.Address{Name: s.fromName, Address: s.fromEmail}, msg.ToEmail, email, time.Now())
+	if err != nil {
+		return "", errors.Wrap(errors.ErrInternal, "failed to build email", err)
+	}
 
+	log := logger.FromContext(ctx, s.logger).With("message_id", msg.ID.String(), "template", msg.Template, "addr", net.JoinHostPort(s.host, s.port))
 	start := time.Now()
-	deadline, hasDeadline := ctx.Deadline()
-	log.Printf("[smtp] start to=%s from=%s user=%s addr=%s host=%s port=%s subject=%q msgBytes=%d hasDeadline=%v deadline=%v",
-		toEmail, s.fromEmail, s.username, addr, s.host, s.port, subject, len(msg), hasDeadline, deadline)
-
-	done := make(chan error, 1)
-	go func() {
-		err := smtp.SendMail(addr, auth, s.fromEmail, []string{toEmail}, []byte(msg))
-		done <- err
-	}()
-
-	select {
-	case <-ctx.Done():
-		log.Printf("[smtp] ctx cancelled after=%s err=%v", time.Since(start), ctx.Err())
-
-		return errors.Wrap(errors.ErrInternal, "email send cancelled", ctx.Err())
-	case err := <-done:
-		if err != nil {
-			log.Printf("[smtp] send FAIL after=%s to=%s from=%s addr=%s err=%v", time.Since(start), toEmail, s.fromEmail, addr, err)
-
-			return errors.Wrap(errors.ErrInternal, "failed to send email", err)
-		}
-		log.Printf("[smtp] send OK after=%s to=%s from=%s addr=%s", time.Since(start), toEmail, s.fromEmail, addr)
 
-		return nil
-	case <-time.After(30 * time.Second):
-		log.Printf("[smtp] TIMEOUT after=%s to=%s from=%s addr=%s", time.Since(start), toEmail, s.fromEmail, addr)
+	if err := s.send(ctx, msg.ToEmail, raw); err != nil {
+		log.Warn("smtp send failed", "duration", time.Since(start).String(), "error", err)
+		if ctx.Err() != nil {
+			return "", errors.Wrap(errors.ErrInternal, "email send cancelled", ctx.Err())
+		}
 
-		return errors.New(errors.ErrInternal, "smtp timeout")
+		return "", errors.Wrap(errors.ErrInternal, "failed to send email", err)
 	}
+
+	log.Info("smtp send ok", "duration", time.Since(start).String(), "bytes", len(raw), "provider_message_id", messageID)
+	return messageID, nil
 }
 
-func (s *EmailSender) SendLoginOTP(ctx context.Context, toEmail, otpCode string) error {
-	if toEmail == "" {
-		return errors.New(errors.ErrValidation, "email is required")
+// send sama seperti smtp.SendMail (STARTTLS kalau ada, lalu AUTH) tapi menghormati ctx
+func (s *EmailSender) send(ctx context.Context, to string, raw []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.host, s.port))
+	if err != nil {
+		return fmt.Errorf("dial smtp: %w", err)
 	}
 
-	if otpCode == "" {
-		return errors.New(errors.ErrValidation, "otp code is required")
-	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
 
-	subject := "Kode OTP Login"
-	plainBody := fmt.Sprintf("Kode OTP login Anda: %s\nKode berlaku selama beberapa menit.", otpCode)
-	htmlBody := fmt.Sprintf(`<p>Kode OTP Login Anda:</p><h2>%s</h2><p>Kode berlaku selama beberapa menit.</p>`, otpCode)
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
 
-	msg := buildMIMEMessage(s.fromName, s.fromEmail, toEmail, subject, plainBody, htmlBody)
-	addr := fmt.Sprintf("%s:%s", s.host, s.port)
-	auth := smtp.PlainAuth("", s.username, s.password, s.host)
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("smtp greeting: %w", err)
+	}
+	defer client.Close()
 
-	done := make(chan error, 1)
-	go func() {
-		done <- smtp.SendMail(addr, auth, s.fromEmail, []string{toEmail}, []byte(msg))
-	}()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
 
-	select {
-	case <-ctx.Done():
-		return errors.Wrap(errors.ErrInternal, "email send cancalled", ctx.Err())
-	case err := <-done:
-		if err != nil {
-			return errors.Wrap(errors.ErrInternal, "failed to send email", err)
+	if s.username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+				return fmt.Errorf("auth: %w", err)
+			}
 		}
-		return nil
+	}
+
+	if err := client.Mail(s.fromEmail); err != nil {
+		return fmt.Errorf("mail from: %w", err)
+	}
 
-	case <-time.After(30 * time.Second):
-		return errors.New(errors.ErrInternal, "smtp timeout")
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("rcpt to: %w", err)
 	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+
+	if _, err := w.Write(raw); err != nil {
+		return fmt.Errorf("write data: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("close data: %w", err)
+	}
+
+	return client.Quit()
 }
 
-func buildMIMEMessage(frontName, fromEmail, toEmail, subject, plainBody, htmlBody string) string {
-	boundary := "mixed-boundary-reset-password"
+// Check membuka koneksi ke server SMTP dan membaca greeting-nya tanpa mengirim email
+func (s *EmailSender) Check(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.host, s.port))
+	if err != nil {
+		return fmt.Errorf("dial smtp: %w", err)
+	}
+	defer conn.Close()
 
-	headers := []string{
-		fmt.Sprintf("From %s <%s>", frontName, fromEmail),
-		fmt.Sprintf("To: %s", toEmail),
-		fmt.Sprintf("Subject: %s", subject),
-		"MIME-Version: 1.0",
-		fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q", boundary),
-		"",
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
 	}
 
-	body := []string{
-		fmt.Sprintf("--%s", boundary),
-		"Content-Type: text/plain; charset=UTF-8",
-		"",
-		plainBody,
-		fmt.Sprintf("--%s", boundary),
-		"Content-Type: text/html; charset=UTF-8",
-		"",
-		htmlBody,
-		fmt.Sprintf("--%s--", boundary),
-		"",
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return fmt.Errorf("smtp greeting: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// startSpan membuka span untuk satu pengiriman email. Alamat penerima tidak dicatat (PII).
+func (s *EmailSender) startSpan(ctx context.Context, templateID string) (context.Context, trace.Span) {
+	port, _ := strconv.Atoi(s.port)
+
+	return tracer.Start(ctx, "smtp.send", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("email.template", templateID),
+		semconv.ServerAddress(s.host),
+		semconv.ServerPort(port),
+	))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
 
-	return strings.Join(append(headers, body...), "\r\n")
+	span.End()
 }
@@ -0,0 +1,146 @@
+package smtp
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/notifications/templates"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+// fakeServer server SMTP minimal (tanpa STARTTLS/AUTH) yang mengembalikan isi DATA
+func fakeServer(t *testing.T) (host, port string, received <-chan string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	out := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		write := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+		write("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				write("250 localhost")
+			case strings.HasPrefix(cmd, "DATA"):
+				write("354 go ahead")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				out <- data.String()
+				write("250 queued")
+			case strings.HasPrefix(cmd, "QUIT"):
+				write("221 bye")
+				return
+			default:
+				write("250 ok")
+			}
+		}
+	}()
+
+	host, port, _ = net.SplitHostPort(ln.Addr().String())
+	return host, port, out
+}
+
+func testMessage() *models.EmailMessage {
+	return models.NewEmailMessage("login_otp", "budi@mail.com", i18n.ID, map[string]any{"code": "123456"}, time.Now())
+}
+
+func TestEmailSender_Deliver(t *testing.T) {
+	host, port, received := fakeServer(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sender := NewEmailSender(host, port, "", "", "no-reply@villainrsty.com", "Villainrsty", templates.NewRenderer(), logger)
+
+	id, err := sender.Deliver(context.Background(), testMessage())
+	if err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	data := <-received
+	if !strings.Contains(data, "Message-ID: "+id) || !strings.Contains(data, "123456") {
+		t.Fatalf("data does not contain message id %s and otp code:\n%s", id, data)
+	}
+}
+
+func TestEmailSender_DeliverHonorsContext(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	// server menerima koneksi tapi tidak pernah mengirim greeting
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			defer conn.Close()
+			_, _ = io.Copy(io.Discard, conn)
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	sender := NewEmailSender(host, port, "", "", "no-reply@villainrsty.com", "", templates.NewRenderer(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := sender.Deliver(ctx, testMessage()); err == nil {
+		t.Fatal("Deliver to a silent server returned nil")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Deliver took %s, want it to stop at the ctx deadline", elapsed)
+	}
+}
+
+func TestMailbox_WritesEML(t *testing.T) {
+	dir := t.TempDir()
+	mailbox := NewMailbox(dir, "no-reply@villainrsty.com", "Villainrsty", templates.NewRenderer(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	msg := testMessage()
+	id, err := mailbox.Deliver(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*-"+msg.ID.String()+".eml"))
+	if len(files) != 1 {
+		t.Fatalf("files = %v, want one .eml", files)
+	}
+
+	raw, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(raw), "Message-ID: "+id) {
+		t.Fatalf("eml does not contain Message-ID %s", id)
+	}
+}
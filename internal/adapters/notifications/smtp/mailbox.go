@@ -0,0 +1,63 @@
+package smtp
+
+import (
+	"context"
+	"log/slog"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/notifications/templates"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/logger"
+)
+
+// Mailbox menulis email (persis yang akan dikirim lewat SMTP) sebagai file .eml di dir.
+// Untuk development: buka filenya di email client atau baca langsung.
+type Mailbox struct {
+	dir      string
+	from     mail.Address
+	renderer *templates.Renderer
+	logger   *slog.Logger
+}
+
+func NewMailbox(dir, fromEmail, fromName string, renderer *templates.Renderer, logger *slog.Logger) *Mailbox {
+	return &Mailbox{
+		dir:      dir,
+		from:     mail.Address{Name: fromName, Address: fromEmail},
+		renderer: renderer,
+		logger:   logger,
+	}
+}
+
+func (m *Mailbox) Deliver(ctx context.Context, msg *models.EmailMessage) (string, error) {
+	if msg.ToEmail == "" {
+		return "", errors.New(errors.ErrValidation, "email is required")
+	}
+
+	email, err := m.renderer.Render(msg.Locale, msg.Template, msg.Data)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	raw, messageID, err := buildMessage(m.from, msg.ToEmail, email, now)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrInternal, "failed to build email", err)
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return "", errors.Wrap(errors.ErrInternal, "failed to create mailbox dir", err)
+	}
+
+	// nama file diawali waktu supaya urut saat di-list
+	path := filepath.Join(m.dir, now.UTC().Format("20060102T150405.000000000")+"-"+msg.ID.String()+".eml")
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		return "", errors.Wrap(errors.ErrInternal, "failed to write mailbox file", err)
+	}
+
+	logger.FromContext(ctx, m.logger).Info("email written to mailbox", "message_id", msg.ID.String(), "template", msg.Template, "path", path)
+	return messageID, nil
+}
@@ -0,0 +1,76 @@
+package smtp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/notifications/templates"
+)
+
+// buildMessage menyusun email multipart/alternative (text lalu HTML) sesuai RFC 5322.
+// Header non-ASCII di-encode RFC 2047 dan body UTF-8 memakai quoted-printable.
+// Message-ID ikut dikembalikan untuk dicatat di email_messages.
+func buildMessage(from mail.Address, to string, email *templates.Email, now time.Time) ([]byte, string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	id := messageID(from.Address)
+
+	headers := []string{
+		"From: " + from.String(),
+		"To: " + (&mail.Address{Address: to}).String(),
+		"Subject: " + mime.QEncoding.Encode("utf-8", email.Subject),
+		"Date: " + now.Format(time.RFC1123Z),
+		"Message-ID: " + id,
+		"MIME-Version: 1.0",
+		fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q", mw.Boundary()),
+	}
+	buf.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", email.Text},
+		{"text/html; charset=UTF-8", email.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, "", err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), id, nil
+}
+
+func messageID(fromEmail string) string {
+	domain := "localhost"
+	if _, d, ok := strings.Cut(fromEmail, "@"); ok && d != "" {
+		domain = d
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
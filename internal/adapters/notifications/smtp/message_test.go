@@ -0,0 +1,68 @@
+package smtp
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/notifications/templates"
+)
+
+func TestBuildMessage_EncodesHeadersAndBodies(t *testing.T) {
+	email := &templates.Email{
+		Subject: "Kode OTP Login — Villainrsty",
+		Text:    "Kode Anda: 123456 ✓",
+		HTML:    "<p>Kode Anda: <b>123456</b> ✓</p>",
+	}
+
+	raw, id, err := buildMessage(mail.Address{Name: "Villainrsty Toko", Address: "no-reply@villainrsty.com"}, "user@example.com", email, time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("buildMessage: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != email.Subject {
+		t.Errorf("subject = %q (%v), want %q", subject, err, email.Subject)
+	}
+	if strings.ContainsFunc(msg.Header.Get("Subject"), func(r rune) bool { return r > 127 }) {
+		t.Errorf("raw subject is not ASCII: %q", msg.Header.Get("Subject"))
+	}
+	if from, err := msg.Header.AddressList("From"); err != nil || from[0].Address != "no-reply@villainrsty.com" {
+		t.Errorf("from = %v (%v)", from, err)
+	}
+	if got := msg.Header.Get("Message-ID"); got != id || !strings.HasSuffix(got, "@villainrsty.com>") {
+		t.Errorf("Message-ID = %q", msg.Header.Get("Message-ID"))
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q (%v)", msg.Header.Get("Content-Type"), err)
+	}
+
+	// multipart.Reader men-decode quoted-printable secara otomatis
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for _, want := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", email.Text},
+		{"text/html; charset=UTF-8", email.HTML},
+	} {
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Fatalf("NextPart: %v", err)
+		}
+
+		body, _ := io.ReadAll(part)
+		if part.Header.Get("Content-Type") != want.contentType || string(body) != want.body {
+			t.Errorf("part %s = %q, want %q", part.Header.Get("Content-Type"), body, want.body)
+		}
+	}
+}
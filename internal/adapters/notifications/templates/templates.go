@@ -0,0 +1,158 @@
+// Package templates merender email transaksional dari file html/template dan text/template
+// dengan layout bersama. Teks diambil dari katalog i18n sesuai locale penerima.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+
+	"villainrsty-ecommerce-server/internal/core/auth/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+const (
+	layoutHTML = "layout.html.tmpl"
+	layoutText = "layout.txt.tmpl"
+)
+
+//go:embed files/*.tmpl
+var files embed.FS
+
+// samples data contoh untuk preview admin, setiap template wajib punya
+var samples = map[string]map[string]any{
+	ports.EmailPasswordReset: {"link": "https://villainrsty.com/reset-password?token=preview-token"},
+	ports.EmailLoginOTP:      {"code": "123456"},
+}
+
+type (
+	// Email hasil render, siap dibungkus MIME
+	Email struct {
+		Subject string `json:"subject"`
+		Text    string `json:"text"`
+		HTML    string `json:"html"`
+	}
+
+	// view data yang diterima template: {{.Data.link}}, {{.Locale}}, {{.Subject}}
+	view struct {
+		Locale  i18n.Locale
+		Subject string
+		Data    map[string]any
+	}
+
+	template struct {
+		html *htmltemplate.Template
+		text *texttemplate.Template
+	}
+
+	Renderer struct {
+		templates map[string]template
+	}
+)
+
+// NewRenderer mem-parse semua template embedded. Panic kalau ada yang rusak,
+// sama seperti katalog i18n, karena itu kesalahan build bukan runtime.
+func NewRenderer() *Renderer {
+	r, err := parse(files)
+	if err != nil {
+		panic(fmt.Sprintf("email templates: %v", err))
+	}
+
+	return r
+}
+
+func parse(fsys fs.FS) (*Renderer, error) {
+	names, err := fs.Glob(fsys, "files/*.html.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Renderer{templates: map[string]template{}}
+	for _, name := range names {
+		id := strings.TrimSuffix(path.Base(name), ".html.tmpl")
+		if id+".html.tmpl" == layoutHTML {
+			continue
+		}
+
+		// fungsi "t" asli dipasang per render karena bergantung pada locale
+		html, err := htmltemplate.New(layoutHTML).Funcs(htmltemplate.FuncMap{"t": translate("")}).
+			Option("missingkey=error").
+			ParseFS(fsys, "files/"+layoutHTML, "files/"+id+".html.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+
+		text, err := texttemplate.New(layoutText).Funcs(texttemplate.FuncMap{"t": translate("")}).
+			Option("missingkey=error").
+			ParseFS(fsys, "files/"+layoutText, "files/"+id+".txt.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+
+		if _, ok := samples[id]; !ok {
+			return nil, fmt.Errorf("%s: missing preview sample", id)
+		}
+
+		r.templates[id] = template{html: html, text: text}
+	}
+
+	return r, nil
+}
+
+// IDs daftar template yang tersedia, terurut
+func (r *Renderer) IDs() []string {
+	return slices.Sorted(maps.Keys(r.templates))
+}
+
+// Sample data contoh untuk preview, nil kalau template tidak dikenal
+func (r *Renderer) Sample(id string) map[string]any {
+	return maps.Clone(samples[id])
+}
+
+// Render membangun subject, body text dan HTML. Template tidak dikenal atau data yang
+// kurang dianggap ErrValidation karena retry tidak akan mengubah hasilnya.
+func (r *Renderer) Render(locale i18n.Locale, id string, data map[string]any) (*Email, error) {
+	tmpl, ok := r.templates[id]
+	if !ok {
+		return nil, errors.New(errors.ErrValidation, "unknown email template: "+id)
+	}
+
+	v := view{
+		Locale:  locale,
+		Subject: i18n.T(locale, "email."+id+".subject", nil),
+		Data:    data,
+	}
+
+	html, err := tmpl.html.Clone()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to clone email template", err)
+	}
+
+	text, err := tmpl.text.Clone()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to clone email template", err)
+	}
+
+	var htmlBody, textBody bytes.Buffer
+	if err := html.Funcs(htmltemplate.FuncMap{"t": translate(locale)}).Execute(&htmlBody, v); err != nil {
+		return nil, errors.Wrap(errors.ErrValidation, "invalid data for email template "+id, err)
+	}
+
+	if err := text.Funcs(texttemplate.FuncMap{"t": translate(locale)}).Execute(&textBody, v); err != nil {
+		return nil, errors.Wrap(errors.ErrValidation, "invalid data for email template "+id, err)
+	}
+
+	return &Email{Subject: v.Subject, Text: textBody.String(), HTML: htmlBody.String()}, nil
+}
+
+func translate(locale i18n.Locale) func(key string) string {
+	return func(key string) string { return i18n.T(locale, key, nil) }
+}
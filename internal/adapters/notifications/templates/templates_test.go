@@ -0,0 +1,66 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+
+	"villainrsty-ecommerce-server/internal/core/auth/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+func TestRender_AllTemplatesWithSamples(t *testing.T) {
+	r := NewRenderer()
+
+	if ids := r.IDs(); len(ids) != len(samples) {
+		t.Fatalf("IDs() = %v, want one template per sample", ids)
+	}
+
+	for _, id := range r.IDs() {
+		for _, locale := range i18n.Supported {
+			email, err := r.Render(locale, id, r.Sample(id))
+			if err != nil {
+				t.Fatalf("Render(%s, %s): %v", locale, id, err)
+			}
+
+			if email.Subject == "" || strings.Contains(email.Subject, "email.") {
+				t.Errorf("%s/%s subject = %q", locale, id, email.Subject)
+			}
+			if !strings.Contains(email.HTML, `<html lang="`+string(locale)+`">`) || !strings.Contains(email.HTML, "Villainrsty") {
+				t.Errorf("%s/%s html is missing the layout", locale, id)
+			}
+			if strings.Contains(email.Text, "<") {
+				t.Errorf("%s/%s text body contains markup: %q", locale, id, email.Text)
+			}
+		}
+	}
+}
+
+func TestRender_LocalizedAndEscaped(t *testing.T) {
+	email, err := NewRenderer().Render(i18n.ID, ports.EmailLoginOTP, map[string]any{"code": "<b>42</b>"})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	if email.Subject != "Kode OTP Login" {
+		t.Errorf("subject = %q", email.Subject)
+	}
+	if !strings.Contains(email.HTML, "&lt;b&gt;42&lt;/b&gt;") {
+		t.Errorf("html does not escape data: %s", email.HTML)
+	}
+	if !strings.Contains(email.Text, "Kode OTP login Anda: <b>42</b>") {
+		t.Errorf("text = %q", email.Text)
+	}
+}
+
+func TestRender_InvalidInput(t *testing.T) {
+	r := NewRenderer()
+
+	if _, err := r.Render(i18n.EN, "nope", nil); !errors.IsKind(err, errors.ErrValidation) {
+		t.Errorf("unknown template err = %v, want validation", err)
+	}
+
+	if _, err := r.Render(i18n.EN, ports.EmailPasswordReset, map[string]any{}); !errors.IsKind(err, errors.ErrValidation) {
+		t.Errorf("missing data err = %v, want validation", err)
+	}
+}
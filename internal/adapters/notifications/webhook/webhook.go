@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	jobPorts "villainrsty-ecommerce-server/internal/core/jobs/ports"
+	"villainrsty-ecommerce-server/internal/core/jobs/service"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+const JobDeliverWebhook = "webhook.deliver"
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderEventID   = "X-Webhook-ID"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+type (
+	// Envelope adalah body JSON yang dikirim ke endpoint webhook
+	Envelope struct {
+		ID            string          `json:"id"`
+		Type          string          `json:"type"`
+		AggregateType string          `json:"aggregate_type"`
+		AggregateID   string          `json:"aggregate_id"`
+		OccurredAt    time.Time       `json:"occurred_at"`
+		Data          json.RawMessage `json:"data"`
+	}
+
+	DeliveryPayload struct {
+		URL   string   `json:"url"`
+		Event Envelope `json:"event"`
+	}
+)
+
+// Relay adalah subscriber outbox yang membuat satu job pengiriman per URL webhook,
+// jadi retry dan dead-letter ditangani oleh job queue
+type Relay struct {
+	queue jobPorts.JobQueue
+	urls  []string
+}
+
+func NewRelay(queue jobPorts.JobQueue, urls []string) *Relay {
+	return &Relay{queue: queue, urls: urls}
+}
+
+func (r *Relay) Handle(ctx context.Context, event *models.DomainEvent) error {
+	envelope := Envelope{
+		ID:            event.ID.String(),
+		Type:          event.Type,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID.String(),
+		OccurredAt:    event.OccurredAt,
+		Data:          event.Payload,
+	}
+
+	for _, url := range r.urls {
+		if _, err := r.queue.Enqueue(ctx, JobDeliverWebhook, DeliveryPayload{URL: url, Event: envelope}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type Sender struct {
+	client *http.Client
+	secret string
+	clock  sharedPorts.Clock
+}
+
+func NewSender(secret string, timeout time.Duration, clock sharedPorts.Clock) *Sender {
+	if clock == nil {
+		clock = sharedPorts.SystemClock
+	}
+
+	return &Sender{
+		client: &http.Client{Timeout: timeout},
+		secret: secret,
+		clock:  clock,
+	}
+}
+
+func (s *Sender) Deliver(ctx context.Context, p DeliveryPayload) error {
+	body, err := json.Marshal(p.Event)
+	if err != nil {
+		return service.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return service.Permanent(err)
+	}
+
+	timestamp := strconv.FormatInt(s.clock.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, p.Event.Type)
+	req.Header.Set(HeaderEventID, p.Event.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	if s.secret != "" {
+		req.Header.Set(HeaderSignature, "sha256="+Sign(s.secret, timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	err = fmt.Errorf("webhook %s responded with status %d", p.URL, resp.StatusCode)
+
+	// 4xx selain 408/429 tidak akan berhasil walau di-retry
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return service.Permanent(err)
+	}
+
+	return err
+}
+
+// Sign menghasilkan HMAC-SHA256 dari "<timestamp>.<body>" supaya penerima bisa verifikasi
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func RegisterWebhookHandlers(worker *service.Worker, sender *Sender) {
+	service.Handle(worker, JobDeliverWebhook, sender.Deliver)
+}
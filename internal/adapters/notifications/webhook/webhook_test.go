@@ -0,0 +1,140 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/jobs/service"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/clock"
+)
+
+var testNow = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testPayload(url string) DeliveryPayload {
+	return DeliveryPayload{
+		URL: url,
+		Event: Envelope{
+			ID:         "e-1",
+			Type:       "user.registered",
+			OccurredAt: testNow,
+			Data:       json.RawMessage(`{"email":"budi@mail.com"}`),
+		},
+	}
+}
+
+func TestSign_MatchesHMACOfTimestampAndBody(t *testing.T) {
+	// hmac-sha256("rahasia", "1767225600.{"id":"e-1"}")
+	want := "e7eaba6b0f2da87a7a3ba06ef88f813fb1ba69ea846932f016a887ef2bcca315"
+	if got := Sign("rahasia", "1767225600", []byte(`{"id":"e-1"}`)); got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+	}
+}
+
+func TestSender_DeliverSignsRequest(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sender := NewSender("rahasia", time.Second, clock.NewFake(testNow))
+	if err := sender.Deliver(context.Background(), testPayload(srv.URL)); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+
+	if got.Header.Get(HeaderEvent) != "user.registered" || got.Header.Get(HeaderEventID) != "e-1" || got.Header.Get(HeaderTimestamp) != "1767225600" {
+		t.Errorf("headers = %v", got.Header)
+	}
+
+	// penerima memverifikasi dengan timestamp dari header dan body mentah
+	if want := "sha256=" + Sign("rahasia", "1767225600", body); got.Header.Get(HeaderSignature) != want {
+		t.Errorf("%s = %q, want %q", HeaderSignature, got.Header.Get(HeaderSignature), want)
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.ID != "e-1" || string(envelope.Data) != `{"email":"budi@mail.com"}` {
+		t.Errorf("body = %s, %v", body, err)
+	}
+}
+
+func TestSender_DeliverStatusMapping(t *testing.T) {
+	tests := []struct {
+		status    int
+		wantErr   bool
+		permanent bool
+	}{
+		{status: http.StatusOK},
+		{status: http.StatusAccepted},
+		{status: http.StatusBadRequest, wantErr: true, permanent: true},
+		{status: http.StatusGone, wantErr: true, permanent: true},
+		{status: http.StatusRequestTimeout, wantErr: true},
+		{status: http.StatusTooManyRequests, wantErr: true},
+		{status: http.StatusInternalServerError, wantErr: true},
+		{status: http.StatusMovedPermanently, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			// 3xx tanpa Location tidak diikuti client, dikembalikan apa adanya
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			err := NewSender("", time.Second, clock.NewFake(testNow)).Deliver(context.Background(), testPayload(srv.URL))
+			if (err != nil) != tt.wantErr || service.IsPermanent(err) != tt.permanent {
+				t.Errorf("Deliver() error = %v, permanent = %v, want error %v permanent %v", err, service.IsPermanent(err), tt.wantErr, tt.permanent)
+			}
+		})
+	}
+}
+
+func TestSender_UnreachableHostIsRetried(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	err := NewSender("", time.Second, clock.NewFake(testNow)).Deliver(context.Background(), testPayload(url))
+	if err == nil || service.IsPermanent(err) {
+		t.Errorf("Deliver() error = %v, want a retryable error", err)
+	}
+}
+
+type recordingQueue struct {
+	payloads []DeliveryPayload
+}
+
+func (q *recordingQueue) Enqueue(_ context.Context, jobType string, payload any) (*models.Job, error) {
+	q.payloads = append(q.payloads, payload.(DeliveryPayload))
+	return models.NewJob(jobType, nil, 1, testNow), nil
+}
+
+func TestRelay_EnqueuesOneJobPerURL(t *testing.T) {
+	queue := &recordingQueue{}
+	event, err := models.NewDomainEvent("user.registered", "user", models.NewID(), map[string]string{"email": "budi@mail.com"}, testNow)
+	if err != nil {
+		t.Fatalf("NewDomainEvent() error = %v", err)
+	}
+
+	relay := NewRelay(queue, []string{"http://a.test/hook", "http://b.test/hook"})
+	if err := relay.Handle(context.Background(), event); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if len(queue.payloads) != 2 || queue.payloads[0].URL != "http://a.test/hook" || queue.payloads[1].URL != "http://b.test/hook" {
+		t.Fatalf("payloads = %+v, want one per URL", queue.payloads)
+	}
+
+	if got := queue.payloads[0].Event; got.ID != event.ID.String() || got.Type != event.Type || string(got.Data) != string(event.Payload) {
+		t.Errorf("envelope = %+v", got)
+	}
+}
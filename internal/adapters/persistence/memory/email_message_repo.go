@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+type EmailMessageRepository struct {
+	messages *table[models.ID, models.EmailMessage]
+	clock    ports.Clock
+}
+
+func NewEmailMessageRepository(clock ports.Clock) *EmailMessageRepository {
+	return &EmailMessageRepository{messages: newTable[models.ID, models.EmailMessage](), clock: clockOrSystem(clock)}
+}
+
+func (r *EmailMessageRepository) Save(ctx context.Context, msg *models.EmailMessage) error {
+	row := *msg
+	row.Data = maps.Clone(msg.Data)
+	r.messages.upsert(ctx, msg.ID, row)
+
+	return nil
+}
+
+func (r *EmailMessageRepository) GetByID(_ context.Context, id models.ID) (*models.EmailMessage, error) {
+	msg, ok := r.messages.get(id)
+	if !ok {
+		return nil, appErr.New(appErr.ErrNotFound, "email message not found")
+	}
+
+	return &msg, nil
+}
+
+// List status kosong berarti semua status, terbaru dulu
+func (r *EmailMessageRepository) List(_ context.Context, status models.EmailStatus, limit, offset int) ([]*models.EmailMessage, error) {
+	rows := r.messages.filter(hasEmailStatus(status))
+	slices.SortFunc(rows, func(a, b models.EmailMessage) int { return b.CreatedAt.Compare(a.CreatedAt) })
+
+	return page(rows, limit, offset), nil
+}
+
+func (r *EmailMessageRepository) Count(_ context.Context, status models.EmailStatus) (int64, error) {
+	return int64(len(r.messages.filter(hasEmailStatus(status)))), nil
+}
+
+func (r *EmailMessageRepository) RecordAttempt(ctx context.Context, id models.ID, status models.EmailStatus, providerMessageID, lastError string) error {
+	r.messages.update(ctx, id, func(m *models.EmailMessage) bool {
+		now := r.clock.Now()
+		m.Status, m.ProviderMessageID, m.LastError, m.UpdatedAt = status, providerMessageID, lastError, now
+		m.Attempts++
+		if status == models.EmailStatusSent {
+			m.SentAt = &now
+			purgeEmailData(m, now)
+		}
+		return true
+	})
+
+	return nil
+}
+
+func (r *EmailMessageRepository) Requeue(ctx context.Context, id models.ID) error {
+	ok := r.messages.update(ctx, id, func(m *models.EmailMessage) bool {
+		if m.Status != models.EmailStatusFailed || m.DataPurgedAt != nil {
+			return false
+		}
+
+		m.Status, m.LastError, m.UpdatedAt = models.EmailStatusQueued, "", r.clock.Now()
+		return true
+	})
+	if !ok {
+		return appErr.New(appErr.ErrConflict, "only failed email messages can be resent")
+	}
+
+	return nil
+}
+
+func (r *EmailMessageRepository) PurgeData(ctx context.Context, createdBefore time.Time) (int64, error) {
+	now := r.clock.Now()
+	var purged int64
+	for _, m := range r.messages.filter(func(m models.EmailMessage) bool {
+		return m.DataPurgedAt == nil && m.CreatedAt.Before(createdBefore)
+	}) {
+		r.messages.update(ctx, m.ID, func(m *models.EmailMessage) bool {
+			purgeEmailData(m, now)
+			m.UpdatedAt = now
+			return true
+		})
+		purged++
+	}
+
+	return purged, nil
+}
+
+func purgeEmailData(m *models.EmailMessage, now time.Time) {
+	if m.DataPurgedAt == nil {
+		m.Data, m.DataPurgedAt = map[string]any{}, &now
+	}
+}
+
+func hasEmailStatus(status models.EmailStatus) func(models.EmailMessage) bool {
+	return func(m models.EmailMessage) bool { return status == "" || m.Status == status }
+}
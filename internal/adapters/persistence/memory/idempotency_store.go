@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/idempotency/models"
+)
+
+type idempotencyKey struct{ scope, key string }
+
+type IdempotencyStore struct {
+	records *table[idempotencyKey, models.Record]
+}
+
+func NewIdempotencyStore() *IdempotencyStore {
+	return &IdempotencyStore{records: newTable[idempotencyKey, models.Record]()}
+}
+
+// Acquire mengikuti AcquireIdempotencyKey: record lama hanya boleh diambil alih kalau sudah
+// expired atau masih processing tapi lock-nya basi
+func (s *IdempotencyStore) Acquire(ctx context.Context, record *models.Record, staleBefore time.Time) (*models.Record, bool, error) {
+	var existing models.Record
+	acquired := s.records.put(ctx, idempotencyKey{record.Scope, record.Key}, func(r *models.Record, exists bool) bool {
+		if exists && r.ExpiresAt.After(record.LockedAt) &&
+			(r.Status != models.StatusProcessing || r.LockedAt.After(staleBefore)) {
+			existing = *r
+			return false
+		}
+
+		*r = *record
+		r.Status, r.Response = models.StatusProcessing, nil
+		return true
+	})
+
+	if acquired {
+		acquiredRecord := *record
+		acquiredRecord.Status, acquiredRecord.Response = models.StatusProcessing, nil
+		return &acquiredRecord, true, nil
+	}
+
+	return &existing, false, nil
+}
+
+func (s *IdempotencyStore) Complete(ctx context.Context, scope, key string, resp *models.Response, _, expiresAt time.Time) error {
+	stored := &models.Response{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: append([]byte(nil), resp.Body...)}
+	s.records.update(ctx, idempotencyKey{scope, key}, func(r *models.Record) bool {
+		r.Status, r.Response, r.ExpiresAt = models.StatusCompleted, stored, expiresAt
+		return true
+	})
+
+	return nil
+}
+
+func (s *IdempotencyStore) Release(ctx context.Context, scope, key string) error {
+	k := idempotencyKey{scope, key}
+	s.records.deleteWhere(ctx, func(rk idempotencyKey, r models.Record) bool {
+		return rk == k && r.Status == models.StatusProcessing
+	})
+
+	return nil
+}
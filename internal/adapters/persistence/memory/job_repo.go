@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+type JobRepository struct {
+	jobs  *table[models.ID, models.Job]
+	clock ports.Clock
+}
+
+func NewJobRepository(clock ports.Clock) *JobRepository {
+	return &JobRepository{jobs: newTable[models.ID, models.Job](), clock: clockOrSystem(clock)}
+}
+
+func (r *JobRepository) Enqueue(ctx context.Context, job *models.Job) error {
+	if !r.jobs.insert(ctx, job.ID, *job, nil) {
+		return appErr.New(appErr.ErrConflict, "job already exists")
+	}
+
+	return nil
+}
+
+// Claim sama seperti ClaimJobs di Postgres: job pending yang sudah waktunya atau job running
+// yang lock-nya basi, diurutkan run_at. Syarat dicek ulang saat update supaya satu job tidak
+// diklaim dua worker.
+func (r *JobRepository) Claim(ctx context.Context, limit int, now, staleBefore time.Time) ([]*models.Job, error) {
+	claimable := func(j models.Job) bool {
+		return (j.Status == models.JobStatusPending && !j.RunAt.After(now)) ||
+			(j.Status == models.JobStatusRunning && j.LockedAt != nil && j.LockedAt.Before(staleBefore))
+	}
+
+	candidates := r.jobs.filter(claimable)
+	slices.SortFunc(candidates, func(a, b models.Job) int { return a.RunAt.Compare(b.RunAt) })
+
+	var claimed []*models.Job
+	for _, c := range candidates {
+		if len(claimed) >= limit {
+			break
+		}
+
+		var job models.Job
+		ok := r.jobs.update(ctx, c.ID, func(j *models.Job) bool {
+			if !claimable(*j) {
+				return false
+			}
+
+			lockedAt := now
+			j.Status, j.LockedAt, j.UpdatedAt = models.JobStatusRunning, &lockedAt, r.clock.Now()
+			j.Attempts++
+			job = *j
+			return true
+		})
+		if ok {
+			claimed = append(claimed, &job)
+		}
+	}
+
+	return claimed, nil
+}
+
+func (r *JobRepository) MarkSucceeded(ctx context.Context, id models.ID) error {
+	r.jobs.update(ctx, id, func(j *models.Job) bool {
+		j.Status, j.LockedAt, j.LastError, j.UpdatedAt = models.JobStatusSucceeded, nil, "", r.clock.Now()
+		return true
+	})
+
+	return nil
+}
+
+func (r *JobRepository) Reschedule(ctx context.Context, id models.ID, runAt time.Time, lastError string) error {
+	r.jobs.update(ctx, id, func(j *models.Job) bool {
+		j.Status, j.RunAt, j.LastError, j.LockedAt, j.UpdatedAt = models.JobStatusPending, runAt, lastError, nil, r.clock.Now()
+		return true
+	})
+
+	return nil
+}
+
+func (r *JobRepository) MarkDead(ctx context.Context, id models.ID, lastError string) error {
+	r.jobs.update(ctx, id, func(j *models.Job) bool {
+		j.Status, j.LastError, j.LockedAt, j.UpdatedAt = models.JobStatusDead, lastError, nil, r.clock.Now()
+		return true
+	})
+
+	return nil
+}
+
+func (r *JobRepository) RetryDead(ctx context.Context, id models.ID, runAt time.Time) error {
+	ok := r.jobs.update(ctx, id, func(j *models.Job) bool {
+		if j.Status != models.JobStatusDead {
+			return false
+		}
+
+		j.Status, j.Attempts, j.RunAt, j.LastError, j.LockedAt, j.UpdatedAt = models.JobStatusPending, 0, runAt, "", nil, r.clock.Now()
+		return true
+	})
+	if !ok {
+		return appErr.New(appErr.ErrNotFound, "dead job not found")
+	}
+
+	return nil
+}
+
+func (r *JobRepository) GetByID(_ context.Context, id models.ID) (*models.Job, error) {
+	job, ok := r.jobs.get(id)
+	if !ok {
+		return nil, appErr.New(appErr.ErrNotFound, "job not found")
+	}
+
+	return &job, nil
+}
+
+func (r *JobRepository) ListByStatus(_ context.Context, status models.JobStatus, limit, offset int) ([]*models.Job, error) {
+	rows := r.jobs.filter(func(j models.Job) bool { return j.Status == status })
+	slices.SortFunc(rows, func(a, b models.Job) int { return b.CreatedAt.Compare(a.CreatedAt) })
+
+	return page(rows, limit, offset), nil
+}
+
+func (r *JobRepository) CountByStatus(_ context.Context, status models.JobStatus) (int64, error) {
+	return int64(len(r.jobs.filter(func(j models.Job) bool { return j.Status == status }))), nil
+}
+
+// page memotong hasil seperti LIMIT/OFFSET dan mengembalikan pointer ke salinannya
+func page[V any](rows []V, limit, offset int) []*V {
+	if offset > len(rows) {
+		offset = len(rows)
+	}
+	rows = rows[offset:min(offset+limit, len(rows))]
+
+	out := make([]*V, len(rows))
+	for i := range rows {
+		out[i] = &rows[i]
+	}
+
+	return out
+}
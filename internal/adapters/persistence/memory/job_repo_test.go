@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+func TestJobRepository_Claim(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	repo := NewJobRepository(ports.ClockFunc(func() time.Time { return now }))
+
+	due := models.NewJob("email.deliver", nil, 3, now)
+	due.RunAt = now.Add(-time.Minute)
+	later := models.NewJob("email.deliver", nil, 3, now)
+	later.RunAt = now.Add(time.Hour)
+	for _, job := range []*models.Job{due, later} {
+		if err := repo.Enqueue(ctx, job); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+
+	claimed, err := repo.Claim(ctx, 10, now, now.Add(-5*time.Minute))
+	if err != nil || len(claimed) != 1 || claimed[0].ID != due.ID {
+		t.Fatalf("Claim() = %v, %v, want only the due job", claimed, err)
+	}
+
+	if claimed[0].Status != models.JobStatusRunning || claimed[0].Attempts != 1 {
+		t.Errorf("claimed job = %+v, want running with 1 attempt", claimed[0])
+	}
+
+	// masih dipegang worker lain dan belum basi
+	if again, _ := repo.Claim(ctx, 10, now, now.Add(-5*time.Minute)); len(again) != 0 {
+		t.Errorf("Claim() again = %v, want nothing", again)
+	}
+
+	// lock basi boleh diklaim ulang
+	stale, _ := repo.Claim(ctx, 10, now.Add(10*time.Minute), now.Add(time.Minute))
+	if len(stale) != 1 || stale[0].Attempts != 2 {
+		t.Errorf("Claim() stale = %v, want the due job with 2 attempts", stale)
+	}
+}
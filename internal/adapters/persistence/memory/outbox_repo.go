@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+// outboxRow kolom available_at tidak ada di DomainEvent, jadi disimpan terpisah
+type outboxRow struct {
+	event       models.DomainEvent
+	availableAt time.Time
+}
+
+type OutboxRepository struct {
+	events *table[models.ID, outboxRow]
+}
+
+func NewOutboxRepository() *OutboxRepository {
+	return &OutboxRepository{events: newTable[models.ID, outboxRow]()}
+}
+
+func (r *OutboxRepository) Save(ctx context.Context, event *models.DomainEvent) error {
+	r.events.upsert(ctx, event.ID, outboxRow{event: *event, availableAt: event.OccurredAt})
+	return nil
+}
+
+func (r *OutboxRepository) ClaimUnpublished(_ context.Context, now time.Time, limit, maxAttempts int) ([]*models.DomainEvent, error) {
+	rows := r.events.filter(func(row outboxRow) bool {
+		return row.event.PublishedAt == nil && !row.availableAt.After(now) && row.event.Attempts < maxAttempts
+	})
+	slices.SortFunc(rows, func(a, b outboxRow) int { return a.event.OccurredAt.Compare(b.event.OccurredAt) })
+
+	events := make([]models.DomainEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, row.event)
+	}
+
+	return page(events, limit, 0), nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id models.ID, at time.Time) error {
+	r.events.update(ctx, id, func(row *outboxRow) bool {
+		row.event.PublishedAt, row.event.LastError = &at, ""
+		return true
+	})
+
+	return nil
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id models.ID, lastError string, retryAt time.Time) error {
+	r.events.update(ctx, id, func(row *outboxRow) bool {
+		row.event.Attempts++
+		row.event.LastError, row.availableAt = lastError, retryAt
+		return true
+	})
+
+	return nil
+}
+
+// All semua event termasuk yang sudah dipublish, urut waktu kejadian. Dipakai test untuk
+// memeriksa event yang ditulis ke outbox.
+func (r *OutboxRepository) All() []*models.DomainEvent {
+	rows := r.events.filter(nil)
+	slices.SortFunc(rows, func(a, b outboxRow) int { return a.event.OccurredAt.Compare(b.event.OccurredAt) })
+
+	events := make([]models.DomainEvent, len(rows))
+	for i, row := range rows {
+		events[i] = row.event
+	}
+
+	return page(events, len(events), 0)
+}
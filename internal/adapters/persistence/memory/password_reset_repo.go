@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+type PasswordResetTokenRepository struct {
+	tokens *table[models.ID, models.PasswordResetToken]
+	clock  ports.Clock
+}
+
+func NewPasswordResetTokenRepository(clock ports.Clock) *PasswordResetTokenRepository {
+	return &PasswordResetTokenRepository{tokens: newTable[models.ID, models.PasswordResetToken](), clock: clockOrSystem(clock)}
+}
+
+func (r *PasswordResetTokenRepository) Save(ctx context.Context, t *models.PasswordResetToken) error {
+	r.tokens.upsert(ctx, t.ID, *t)
+	return nil
+}
+
+func (r *PasswordResetTokenRepository) GetByTokenHash(_ context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	t, ok := r.tokens.find(func(t models.PasswordResetToken) bool { return t.TokenHash == tokenHash })
+	if !ok {
+		return nil, appErr.New(appErr.ErrNotFound, "password reset token not found")
+	}
+
+	return &t, nil
+}
+
+func (r *PasswordResetTokenRepository) MarkUsed(ctx context.Context, id models.ID) error {
+	now := r.clock.Now()
+	r.tokens.update(ctx, id, func(t *models.PasswordResetToken) bool {
+		t.UsedAt = &now
+		return true
+	})
+
+	return nil
+}
+
+// DeleteExpired ikut menghapus token yang sudah terpakai
+func (r *PasswordResetTokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	now := r.clock.Now()
+	return r.tokens.deleteWhere(ctx, func(_ models.ID, t models.PasswordResetToken) bool {
+		return t.UsedAt != nil || t.ExpiresAt.Before(now)
+	}), nil
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/ratelimit/models"
+)
+
+const rateLimitSweepInterval = time.Minute
+
+type rateLimitEntry struct {
+	bucket    models.Bucket
+	expiresAt time.Time
+}
+
+// RateLimitStore menyimpan token bucket di memori proses, cocok untuk satu replika atau development
+type RateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]rateLimitEntry
+	lastSweep time.Time
+}
+
+func NewRateLimitStore() *RateLimitStore {
+	return &RateLimitStore{buckets: make(map[string]rateLimitEntry)}
+}
+
+func (s *RateLimitStore) Take(_ context.Context, key string, limit models.Limit, now time.Time) (models.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	entry, ok := s.buckets[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		entry.bucket = models.NewBucket(limit, now)
+	}
+
+	bucket, result := entry.bucket.Take(limit, now)
+	s.buckets[key] = rateLimitEntry{bucket: bucket, expiresAt: bucket.ExpiresAt(limit)}
+
+	return result, nil
+}
+
+// sweep membuang bucket yang sudah penuh kembali supaya map tidak tumbuh terus
+func (s *RateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+
+	for key, entry := range s.buckets {
+		if !now.Before(entry.expiresAt) {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.lastSweep = now
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+type RefreshTokenRepository struct {
+	tokens *table[models.ID, models.RefreshToken]
+	clock  ports.Clock
+}
+
+func NewRefreshTokenRepository(clock ports.Clock) *RefreshTokenRepository {
+	return &RefreshTokenRepository{tokens: newTable[models.ID, models.RefreshToken](), clock: clockOrSystem(clock)}
+}
+
+func (r *RefreshTokenRepository) Save(ctx context.Context, t *models.RefreshToken) error {
+	if t.TokenHash == "" || t.UserID == "" {
+		return appErr.New(appErr.ErrValidation, "token hash and user id are required")
+	}
+
+	r.tokens.upsert(ctx, t.ID, *t)
+	return nil
+}
+
+func (r *RefreshTokenRepository) GetByTokenHash(_ context.Context, tokenHash string) (*models.RefreshToken, error) {
+	t, ok := r.tokens.find(func(t models.RefreshToken) bool { return t.TokenHash == tokenHash })
+	if !ok {
+		return nil, appErr.New(appErr.ErrNotFound, "refresh token not found")
+	}
+
+	return &t, nil
+}
+
+// GetByUserID hanya token yang masih aktif, terbaru lebih dulu
+func (r *RefreshTokenRepository) GetByUserID(_ context.Context, userID models.ID) ([]*models.RefreshToken, error) {
+	now := r.clock.Now()
+	rows := r.tokens.filter(func(t models.RefreshToken) bool {
+		return t.UserID == userID && t.RevokedAt == nil && t.ExpiresAt.After(now)
+	})
+
+	slices.SortFunc(rows, func(a, b models.RefreshToken) int { return b.CreatedAt.Compare(a.CreatedAt) })
+
+	out := make([]*models.RefreshToken, len(rows))
+	for i := range rows {
+		out[i] = &rows[i]
+	}
+
+	return out, nil
+}
+
+func (r *RefreshTokenRepository) Revoke(ctx context.Context, tokenID models.ID) error {
+	now := r.clock.Now()
+	r.tokens.update(ctx, tokenID, func(t *models.RefreshToken) bool {
+		t.RevokedAt, t.UpdatedAt = &now, now
+		return true
+	})
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	now := r.clock.Now()
+	return r.tokens.deleteWhere(ctx, func(_ models.ID, t models.RefreshToken) bool { return t.ExpiresAt.Before(now) }), nil
+}
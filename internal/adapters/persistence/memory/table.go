@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+// table map dengan lock, dipakai semua repository in-memory. Row disimpan sebagai value
+// supaya pemanggil tidak bisa mengubah data tanpa lewat repository, dan setiap write
+// mencatat undo ke transaksi yang ada di ctx.
+type table[K comparable, V any] struct {
+	mu   sync.Mutex
+	rows map[K]V
+}
+
+func newTable[K comparable, V any]() *table[K, V] {
+	return &table[K, V]{rows: make(map[K]V)}
+}
+
+func (t *table[K, V]) get(k K) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	v, ok := t.rows[k]
+	return v, ok
+}
+
+// insert gagal (false) kalau key sudah ada atau ada row yang bentrok menurut conflict
+func (t *table[K, V]) insert(ctx context.Context, k K, v V, conflict func(existing V) bool) bool {
+	t.mu.Lock()
+	if _, ok := t.rows[k]; ok {
+		t.mu.Unlock()
+		return false
+	}
+
+	for _, existing := range t.rows {
+		if conflict != nil && conflict(existing) {
+			t.mu.Unlock()
+			return false
+		}
+	}
+
+	t.rows[k] = v
+	t.mu.Unlock()
+
+	onRollback(ctx, func() { t.restore(k, v, false) })
+	return true
+}
+
+// update mengubah row k lewat fn secara atomik, false kalau row tidak ada atau fn menolak
+func (t *table[K, V]) update(ctx context.Context, k K, fn func(v *V) bool) bool {
+	t.mu.Lock()
+	old, ok := t.rows[k]
+	if !ok {
+		t.mu.Unlock()
+		return false
+	}
+
+	v := old
+	if !fn(&v) {
+		t.mu.Unlock()
+		return false
+	}
+
+	t.rows[k] = v
+	t.mu.Unlock()
+
+	onRollback(ctx, func() { t.restore(k, old, true) })
+	return true
+}
+
+// put menulis row k lewat fn secara atomik, exists=false berarti v masih zero value.
+// Dipakai untuk INSERT ... ON CONFLICT DO UPDATE WHERE, false kalau fn menolak.
+func (t *table[K, V]) put(ctx context.Context, k K, fn func(v *V, exists bool) bool) bool {
+	t.mu.Lock()
+	old, had := t.rows[k]
+
+	v := old
+	if !fn(&v, had) {
+		t.mu.Unlock()
+		return false
+	}
+
+	t.rows[k] = v
+	t.mu.Unlock()
+
+	onRollback(ctx, func() { t.restore(k, old, had) })
+	return true
+}
+
+// upsert menyimpan v apa pun isi sebelumnya
+func (t *table[K, V]) upsert(ctx context.Context, k K, v V) {
+	t.mu.Lock()
+	old, had := t.rows[k]
+	t.rows[k] = v
+	t.mu.Unlock()
+
+	onRollback(ctx, func() { t.restore(k, old, had) })
+}
+
+func (t *table[K, V]) deleteWhere(ctx context.Context, match func(k K, v V) bool) int64 {
+	t.mu.Lock()
+	deleted := make(map[K]V)
+	for k, v := range t.rows {
+		if match(k, v) {
+			deleted[k] = v
+			delete(t.rows, k)
+		}
+	}
+	t.mu.Unlock()
+
+	if len(deleted) > 0 {
+		onRollback(ctx, func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			for k, v := range deleted {
+				t.rows[k] = v
+			}
+		})
+	}
+
+	return int64(len(deleted))
+}
+
+// find mengembalikan row pertama yang cocok, urutan tidak dijamin
+func (t *table[K, V]) find(match func(v V) bool) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, v := range t.rows {
+		if match(v) {
+			return v, true
+		}
+	}
+
+	var zero V
+	return zero, false
+}
+
+func (t *table[K, V]) filter(match func(v V) bool) []V {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var out []V
+	for _, v := range t.rows {
+		if match == nil || match(v) {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// restore mengembalikan row k ke keadaan sebelum write (had=false berarti row belum ada)
+func (t *table[K, V]) restore(k K, v V, had bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if had {
+		t.rows[k] = v
+	} else {
+		delete(t.rows, k)
+	}
+}
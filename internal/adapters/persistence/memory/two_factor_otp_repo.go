@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+type TwoFactorOTPRepository struct {
+	otps  *table[models.ID, models.TwoFactorOTP]
+	clock ports.Clock
+}
+
+func NewTwoFactorOTPRepository(clock ports.Clock) *TwoFactorOTPRepository {
+	return &TwoFactorOTPRepository{otps: newTable[models.ID, models.TwoFactorOTP](), clock: clockOrSystem(clock)}
+}
+
+func (r *TwoFactorOTPRepository) Save(ctx context.Context, otp *models.TwoFactorOTP) error {
+	r.otps.upsert(ctx, otp.ID, *otp)
+	return nil
+}
+
+func (r *TwoFactorOTPRepository) GetByChallengeID(_ context.Context, challengeID string) (*models.TwoFactorOTP, error) {
+	otp, ok := r.otps.find(func(o models.TwoFactorOTP) bool { return o.ChallengeID == challengeID })
+	if !ok {
+		return nil, appErr.New(appErr.ErrNotFound, "2fa challenge not found")
+	}
+
+	return &otp, nil
+}
+
+func (r *TwoFactorOTPRepository) MarkUsed(ctx context.Context, id models.ID) error {
+	now := r.clock.Now()
+	r.otps.update(ctx, id, func(o *models.TwoFactorOTP) bool {
+		o.UsedAt = &now
+		return true
+	})
+
+	return nil
+}
+
+// DeleteExpired ikut menghapus OTP yang sudah terpakai
+func (r *TwoFactorOTPRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	now := r.clock.Now()
+	return r.otps.deleteWhere(ctx, func(_ models.ID, o models.TwoFactorOTP) bool {
+		return o.UsedAt != nil || o.ExpiresAt.Before(now)
+	}), nil
+}
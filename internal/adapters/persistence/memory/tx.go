@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+type txKey struct{}
+
+// tx mencatat undo untuk setiap write, dijalankan terbalik saat rollback
+type tx struct {
+	undo []func()
+}
+
+// TxManager transaksi untuk repository in-memory. Transaksi dijalankan bergantian sehingga
+// setara serializable, dan write di dalamnya dibatalkan kalau fn mengembalikan error.
+// Write di luar transaksi langsung berlaku.
+type TxManager struct {
+	mu sync.Mutex
+}
+
+func NewTxManager() *TxManager {
+	return &TxManager{}
+}
+
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error, _ ...ports.TxOption) error {
+	if _, ok := ctx.Value(txKey{}).(*tx); ok {
+		return fn(ctx)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := &tx{}
+	defer func() {
+		if p := recover(); p != nil {
+			t.rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, t)); err != nil {
+		t.rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (t *tx) rollback() {
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		t.undo[i]()
+	}
+}
+
+// onRollback didaftarkan repository setelah write. Fungsi undo harus mengunci repository-nya
+// sendiri karena dijalankan setelah lock write dilepas.
+func onRollback(ctx context.Context, undo func()) {
+	if t, ok := ctx.Value(txKey{}).(*tx); ok {
+		t.undo = append(t.undo, undo)
+	}
+}
+
+// clockOrSystem sumber waktu repository, nil berarti jam sistem
+func clockOrSystem(clock ports.Clock) ports.Clock {
+	if clock == nil {
+		return ports.SystemClock
+	}
+
+	return clock
+}
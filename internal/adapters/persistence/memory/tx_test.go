@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+func TestWithinTx_RollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	users := NewUserRepository(nil)
+	tx := NewTxManager()
+
+	kept := models.NewUser("kept@mail.com", "Passw0rd123", "Kept", time.Now())
+	if err := users.Save(ctx, kept); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	boom := errors.New("boom")
+	err := tx.WithinTx(ctx, func(ctx context.Context) error {
+		created := models.NewUser("created@mail.com", "Passw0rd123", "Created", time.Now())
+		if err := users.Save(ctx, created); err != nil {
+			return err
+		}
+
+		// nested memakai transaksi yang sama, ikut dibatalkan
+		if err := tx.WithinTx(ctx, func(ctx context.Context) error {
+			return users.UpdateUserPassword(ctx, kept.ID, "changed")
+		}); err != nil {
+			return err
+		}
+
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("WithinTx() error = %v, want boom", err)
+	}
+
+	if _, err := users.GetByEmail(ctx, "created@mail.com"); !appErr.IsKind(err, appErr.ErrNotFound) {
+		t.Errorf("created user survived rollback, err = %v", err)
+	}
+
+	got, err := users.GetByEmail(ctx, "kept@mail.com")
+	if err != nil || got.Password != "Passw0rd123" {
+		t.Errorf("kept user = %+v, %v, want password restored", got, err)
+	}
+}
+
+func TestUserRepository_RejectsDuplicateEmail(t *testing.T) {
+	ctx := context.Background()
+	users := NewUserRepository(nil)
+
+	first := models.NewUser("budi@mail.com", "Passw0rd123", "Budi", time.Now())
+	second := models.NewUser("budi@mail.com", "Passw0rd123", "Budi Lain", time.Now())
+
+	if err := users.Save(ctx, first); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if err := users.Save(ctx, second); !appErr.IsKind(err, appErr.ErrConflict) {
+		t.Errorf("Save() duplicate error = %v, want conflict", err)
+	}
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/internal/core/shared/ports"
+	"villainrsty-ecommerce-server/pkg/i18n"
+	"villainrsty-ecommerce-server/pkg/validator"
+)
+
+// UserRepository implementasi in-memory auth ports.UserRepository dengan aturan (validasi,
+// email unik, error kind) yang sama dengan versi Postgres
+type UserRepository struct {
+	users     *table[models.ID, models.User]
+	validator *validator.Validator
+	clock     ports.Clock
+}
+
+func NewUserRepository(clock ports.Clock) *UserRepository {
+	return &UserRepository{
+		users:     newTable[models.ID, models.User](),
+		validator: validator.NewValidate(),
+		clock:     clockOrSystem(clock),
+	}
+}
+
+func (r *UserRepository) GetByEmail(_ context.Context, email string) (*models.User, error) {
+	if err := r.validator.ValidateEmail(email); err != nil {
+		return nil, appErr.Wrap(appErr.ErrValidation, "invalid email format", err)
+	}
+
+	user, ok := r.users.find(func(u models.User) bool { return u.Email == email })
+	if !ok {
+		return nil, appErr.New(appErr.ErrNotFound, "user not found")
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) GetByID(_ context.Context, id string) (*models.User, error) {
+	if err := r.validator.ValidateRequired("id", id); err != nil {
+		return nil, appErr.Wrap(appErr.ErrValidation, "invalid id", err)
+	}
+
+	user, ok := r.users.get(models.ID(id))
+	if !ok {
+		return nil, appErr.New(appErr.ErrNotFound, "user not found")
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) Save(ctx context.Context, user *models.User) error {
+	if err := user.Validate(); err != nil {
+		return err
+	}
+
+	if !r.users.insert(ctx, user.ID, *user, func(u models.User) bool { return u.Email == user.Email }) {
+		return appErr.New(appErr.ErrConflict, "email already registered")
+	}
+
+	return nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	if err := r.validator.ValidateRequired("id", id); err != nil {
+		return appErr.Wrap(appErr.ErrValidation, "invalid id", err)
+	}
+
+	r.users.deleteWhere(ctx, func(k models.ID, _ models.User) bool { return k == models.ID(id) })
+	return nil
+}
+
+func (r *UserRepository) Exist(_ context.Context, email string) (bool, error) {
+	if err := r.validator.ValidateEmail(email); err != nil {
+		return false, appErr.Wrap(appErr.ErrValidation, "invalid email format", err)
+	}
+
+	_, ok := r.users.find(func(u models.User) bool { return u.Email == email })
+	return ok, nil
+}
+
+func (r *UserRepository) UpdateUserPassword(ctx context.Context, id models.ID, hashed string) error {
+	if err := r.validator.ValidateRequired("password", hashed); err != nil {
+		return appErr.Wrap(appErr.ErrValidation, "invalid password", err)
+	}
+
+	// sama seperti UPDATE di Postgres, id yang tidak ada tidak dianggap error
+	r.users.update(ctx, id, func(u *models.User) bool {
+		u.Password, u.UpdatedAt = hashed, r.clock.Now()
+		return true
+	})
+
+	return nil
+}
+
+func (r *UserRepository) UpdateLocale(ctx context.Context, id models.ID, locale i18n.Locale) error {
+	r.users.update(ctx, id, func(u *models.User) bool {
+		u.Locale, u.UpdatedAt = locale, r.clock.Now()
+		return true
+	})
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
 	// "villainrsty-ecommerce-server/internal/core/auth/models"
 	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/i18n"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -14,6 +15,8 @@ func SQLCUserByEmailToDomain(sqlcUser sqlc.GetUserByEmailRow) *models.User {
 		Email:     sqlcUser.Email,
 		Password:  sqlcUser.Password,
 		Name:      sqlcUser.Name,
+		Locale:    i18n.Locale(sqlcUser.Locale),
+		Role:      models.Role(sqlcUser.Role),
 		CreatedAt: sqlcUser.CreatedAt.Time,
 		UpdatedAt: sqlcUser.UpdatedAt.Time,
 	}
@@ -25,6 +28,8 @@ func SQLCUserByIDToDomain(sqlcUser sqlc.GetUserByIDRow) *models.User {
 		Email:     sqlcUser.Email,
 		Password:  sqlcUser.Password,
 		Name:      sqlcUser.Name,
+		Locale:    i18n.Locale(sqlcUser.Locale),
+		Role:      models.Role(sqlcUser.Role),
 		CreatedAt: sqlcUser.CreatedAt.Time,
 		UpdatedAt: sqlcUser.UpdatedAt.Time,
 	}
@@ -36,6 +41,8 @@ func DomainUserToSQLCParams(user *models.User) sqlc.CreateUserParams {
 		Email:    user.Email,
 		Password: user.Password,
 		Name:     user.Name,
+		Locale:   string(user.Locale),
+		Role:     string(user.Role),
 		CreatedAt: pgtype.Timestamp{
 			Time:  user.CreatedAt,
 			Valid: true,
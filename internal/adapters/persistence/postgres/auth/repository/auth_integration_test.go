@@ -0,0 +1,221 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/pgtest"
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/clock"
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+// expired/valid cukup jauh dari waktu sekarang supaya hasilnya sama di zona waktu mana pun, kolom
+// TIMESTAMP menyimpan jam dinding tanpa zona
+const farAway = 48 * time.Hour
+
+func newUser(t *testing.T, users *UserRepository, email string) *models.User {
+	t.Helper()
+
+	user := models.NewUser(email, "Passw0rd123", "Budi", time.Now())
+	if err := users.Save(context.Background(), user); err != nil {
+		t.Fatalf("Save(%s) error = %v", email, err)
+	}
+
+	return user
+}
+
+func TestUserRepository(t *testing.T) {
+	ctx := context.Background()
+	users := NewUserRepository(postgres.NewQueries(pgtest.Pool(t)))
+
+	user := newUser(t, users, "budi@mail.com")
+
+	got, err := users.GetByEmail(ctx, "budi@mail.com")
+	if err != nil {
+		t.Fatalf("GetByEmail() error = %v", err)
+	}
+
+	if got.ID != user.ID || got.Role != models.RoleUser || got.Locale != i18n.Default || !got.CreatedAt.Equal(pgtest.Timestamp(user.CreatedAt)) {
+		t.Errorf("GetByEmail() = %+v, want %+v", got, user)
+	}
+
+	if err := users.Save(ctx, models.NewUser("budi@mail.com", "Passw0rd123", "Budi Lain", time.Now())); !appErr.IsKind(err, appErr.ErrConflict) {
+		t.Errorf("Save() duplicate error = %v, want conflict", err)
+	}
+
+	if err := users.UpdateUserPassword(ctx, user.ID, "NewPassw0rd1"); err != nil {
+		t.Fatalf("UpdateUserPassword() error = %v", err)
+	}
+
+	if err := users.UpdateLocale(ctx, user.ID, i18n.EN); err != nil {
+		t.Fatalf("UpdateLocale() error = %v", err)
+	}
+
+	got, err = users.GetByID(ctx, user.ID.String())
+	if err != nil || got.Password != "NewPassw0rd1" || got.Locale != i18n.EN {
+		t.Errorf("GetByID() = %+v, %v, want updated password and locale", got, err)
+	}
+
+	if err := users.Delete(ctx, user.ID.String()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if exists, err := users.Exist(ctx, "budi@mail.com"); err != nil || exists {
+		t.Errorf("Exist() after delete = %v, %v", exists, err)
+	}
+
+	if _, err := users.GetByID(ctx, user.ID.String()); !appErr.IsKind(err, appErr.ErrNotFound) {
+		t.Errorf("GetByID() after delete error = %v, want not found", err)
+	}
+}
+
+func TestUserRepository_Role(t *testing.T) {
+	ctx := context.Background()
+	users := NewUserRepository(postgres.NewQueries(pgtest.Pool(t)))
+
+	admin := models.NewUser("admin@mail.com", "Passw0rd123", "Admin", time.Now())
+	admin.Role = models.RoleAdmin
+	if err := users.Save(ctx, admin); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := users.GetByID(ctx, admin.ID.String())
+	if err != nil || !got.IsAdmin() {
+		t.Errorf("GetByID() = %+v, %v, want admin", got, err)
+	}
+}
+
+func TestRefreshTokenRepository(t *testing.T) {
+	ctx := context.Background()
+	q := postgres.NewQueries(pgtest.Pool(t))
+	clk := clock.NewFake(time.Now())
+	tokens := NewRefreshTokenRepository(q, clk)
+	user := newUser(t, NewUserRepository(q), "budi@mail.com")
+
+	active := models.NewRefreshToken(user.ID, "hash-active", farAway, clk.Now())
+	revoked := models.NewRefreshToken(user.ID, "hash-revoked", farAway, clk.Now())
+	expired := models.NewRefreshToken(user.ID, "hash-expired", -farAway, clk.Now())
+	for _, token := range []*models.RefreshToken{active, revoked, expired} {
+		if err := tokens.Save(ctx, token); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	got, err := tokens.GetByTokenHash(ctx, "hash-active")
+	if err != nil {
+		t.Fatalf("GetByTokenHash() error = %v", err)
+	}
+
+	// revoked_at NULL harus kembali sebagai nil, bukan waktu nol
+	if got.RevokedAt != nil || !got.ExpiresAt.Equal(pgtest.Timestamp(active.ExpiresAt)) {
+		t.Errorf("GetByTokenHash() = %+v, want not revoked, expires %v", got, active.ExpiresAt)
+	}
+
+	if err := tokens.Revoke(ctx, revoked.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	got, err = tokens.GetByTokenHash(ctx, "hash-revoked")
+	if err != nil || got.RevokedAt == nil || !got.RevokedAt.Equal(pgtest.Timestamp(clk.Now())) {
+		t.Errorf("GetByTokenHash() after revoke = %+v, %v, want revoked_at from the clock", got, err)
+	}
+
+	list, err := tokens.GetByUserID(ctx, user.ID)
+	if err != nil || len(list) != 1 || list[0].ID != active.ID {
+		t.Errorf("GetByUserID() = %+v, %v, want only the active token", list, err)
+	}
+
+	if n, err := tokens.DeleteExpired(ctx); err != nil || n != 1 {
+		t.Errorf("DeleteExpired() = %d, %v, want 1", n, err)
+	}
+
+	// kedaluwarsa dihitung dari clock, bukan NOW() database
+	clk.Advance(2 * farAway)
+	if list, err := tokens.GetByUserID(ctx, user.ID); err != nil || len(list) != 0 {
+		t.Errorf("GetByUserID() after expiry = %+v, %v, want none", list, err)
+	}
+
+	if _, err := tokens.GetByTokenHash(ctx, "hash-unknown"); !appErr.IsKind(err, appErr.ErrNotFound) {
+		t.Errorf("GetByTokenHash() unknown error = %v, want not found", err)
+	}
+}
+
+func TestPasswordResetTokenRepository(t *testing.T) {
+	ctx := context.Background()
+	q := postgres.NewQueries(pgtest.Pool(t))
+	now := time.Now()
+	resets := NewPasswordResetTokenRepository(q, clock.NewFake(now))
+	user := newUser(t, NewUserRepository(q), "budi@mail.com")
+
+	token := &models.PasswordResetToken{ID: models.NewID(), UserID: user.ID, TokenHash: "hash-reset", ExpiresAt: now.Add(farAway), CreatedAt: now}
+	expired := &models.PasswordResetToken{ID: models.NewID(), UserID: user.ID, TokenHash: "hash-expired", ExpiresAt: now.Add(-farAway), CreatedAt: now}
+	for _, tok := range []*models.PasswordResetToken{token, expired} {
+		if err := resets.Save(ctx, tok); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	got, err := resets.GetByTokenHash(ctx, "hash-reset")
+	if err != nil || got.UsedAt != nil || !got.IsValid(now) {
+		t.Fatalf("GetByTokenHash() = %+v, %v, want unused and valid", got, err)
+	}
+
+	if err := resets.MarkUsed(ctx, token.ID); err != nil {
+		t.Fatalf("MarkUsed() error = %v", err)
+	}
+
+	got, err = resets.GetByTokenHash(ctx, "hash-reset")
+	if err != nil || got.UsedAt == nil || !got.UsedAt.Equal(pgtest.Timestamp(now)) || got.IsValid(now) {
+		t.Errorf("GetByTokenHash() after use = %+v, %v, want used_at from the clock", got, err)
+	}
+
+	// yang expired dan yang sudah terpakai sama-sama dihapus
+	if n, err := resets.DeleteExpired(ctx); err != nil || n != 2 {
+		t.Errorf("DeleteExpired() = %d, %v, want 2", n, err)
+	}
+
+	if _, err := resets.GetByTokenHash(ctx, "hash-reset"); !appErr.IsKind(err, appErr.ErrNotFound) {
+		t.Errorf("GetByTokenHash() after purge error = %v, want not found", err)
+	}
+}
+
+func TestTwoFactorOTPRepository(t *testing.T) {
+	ctx := context.Background()
+	q := postgres.NewQueries(pgtest.Pool(t))
+	now := time.Now()
+	otps := NewTwoFactorOTPRepository(q, clock.NewFake(now))
+	user := newUser(t, NewUserRepository(q), "budi@mail.com")
+
+	otp := &models.TwoFactorOTP{ID: models.NewID(), UserID: user.ID, ChallengeID: models.NewID().String(), CodeHash: "hash-otp", ExpiresAt: now.Add(farAway), CreatedAt: now}
+	if err := otps.Save(ctx, otp); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := otps.GetByChallengeID(ctx, otp.ChallengeID)
+	if err != nil || got.UsedAt != nil || got.CodeHash != "hash-otp" || !got.IsValid(now) {
+		t.Fatalf("GetByChallengeID() = %+v, %v, want unused", got, err)
+	}
+
+	if err := otps.MarkUsed(ctx, otp.ID); err != nil {
+		t.Fatalf("MarkUsed() error = %v", err)
+	}
+
+	got, err = otps.GetByChallengeID(ctx, otp.ChallengeID)
+	if err != nil || got.UsedAt == nil || !got.UsedAt.Equal(pgtest.Timestamp(now)) {
+		t.Errorf("GetByChallengeID() after use = %+v, %v, want used_at from the clock", got, err)
+	}
+
+	if n, err := otps.DeleteExpired(ctx); err != nil || n != 1 {
+		t.Errorf("DeleteExpired() = %d, %v, want 1", n, err)
+	}
+
+	if _, err := otps.GetByChallengeID(ctx, otp.ChallengeID); !appErr.IsKind(err, appErr.ErrNotFound) {
+		t.Errorf("GetByChallengeID() after purge error = %v, want not found", err)
+	}
+}
@@ -2,20 +2,31 @@ package repository
 
 import (
 	"context"
+	"errors"
 
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
 	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/auth/mapper"
 	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
 	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type PasswordResetTokenRepository struct {
-	q *sqlc.Queries
+	q     *sqlc.Queries
+	clock sharedPorts.Clock
 }
 
-func NewPasswordResetTokenRepository(q *sqlc.Queries) *PasswordResetTokenRepository {
-	return &PasswordResetTokenRepository{q: q}
+// NewPasswordResetTokenRepository clock nil berarti jam sistem
+func NewPasswordResetTokenRepository(q *sqlc.Queries, clock sharedPorts.Clock) *PasswordResetTokenRepository {
+	if clock == nil {
+		clock = sharedPorts.SystemClock
+	}
+
+	return &PasswordResetTokenRepository{q: q, clock: clock}
 }
 
 func (r *PasswordResetTokenRepository) Save(ctx context.Context, t *models.PasswordResetToken) error {
@@ -26,7 +37,7 @@ func (r *PasswordResetTokenRepository) Save(ctx context.Context, t *models.Passw
 		used = pgtype.Timestamp{Valid: false}
 	}
 
-	return r.q.CreatePasswordResetToken(ctx, sqlc.CreatePasswordResetTokenParams{
+	return r.db(ctx).CreatePasswordResetToken(ctx, sqlc.CreatePasswordResetTokenParams{
 		ID:        t.ID.String(),
 		UserID:    t.UserID.String(),
 		TokenHash: t.TokenHash,
@@ -37,8 +48,11 @@ func (r *PasswordResetTokenRepository) Save(ctx context.Context, t *models.Passw
 }
 
 func (r *PasswordResetTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
-	row, err := r.q.GetPasswordResetTokenByHash(ctx, tokenHash)
+	row, err := r.db(ctx).GetPasswordResetTokenByHash(ctx, tokenHash)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appErr.New(appErr.ErrNotFound, "password reset token not found")
+		}
 		return nil, err
 	}
 
@@ -46,5 +60,23 @@ func (r *PasswordResetTokenRepository) GetByTokenHash(ctx context.Context, token
 }
 
 func (r *PasswordResetTokenRepository) MarkUsed(ctx context.Context, id models.ID) error {
-	return r.q.MarkPasswordResetTokenUsed(ctx, id.String())
+	return r.db(ctx).MarkPasswordResetTokenUsed(ctx, sqlc.MarkPasswordResetTokenUsedParams{
+		ID:     id.String(),
+		UsedAt: pgtype.Timestamp{Time: r.clock.Now(), Valid: true},
+	})
+}
+
+// DeleteExpired ikut menghapus token yang sudah terpakai
+func (r *PasswordResetTokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	n, err := r.db(ctx).DeleteExpirePasswordResetToken(ctx, pgtype.Timestamp{Time: r.clock.Now(), Valid: true})
+	if err != nil {
+		return 0, appErr.Wrap(appErr.ErrInternal, "failed to delete expired password reset tokens", err)
+	}
+
+	return n, nil
+}
+
+// db memakai transaksi dari ctx kalau ada, selain itu pakai pool
+func (r *PasswordResetTokenRepository) db(ctx context.Context) *sqlc.Queries {
+	return postgres.QueriesFrom(ctx, r.q)
 }
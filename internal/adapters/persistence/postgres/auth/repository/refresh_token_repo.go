@@ -3,12 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
-	"time"
 
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
 	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/auth/mapper"
 	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
 	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
 	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
 	"villainrsty-ecommerce-server/pkg/validator"
 
 	"github.com/jackc/pgx/v5"
@@ -18,12 +19,19 @@ import (
 type RefreshTokenRepository struct {
 	queris    *sqlc.Queries
 	validator *validator.Validator
+	clock     sharedPorts.Clock
 }
 
-func NewRefreshTokenRepository(queries *sqlc.Queries) *RefreshTokenRepository {
+// NewRefreshTokenRepository clock nil berarti jam sistem
+func NewRefreshTokenRepository(queries *sqlc.Queries, clock sharedPorts.Clock) *RefreshTokenRepository {
+	if clock == nil {
+		clock = sharedPorts.SystemClock
+	}
+
 	return &RefreshTokenRepository{
 		queris:    queries,
 		validator: validator.NewValidate(),
+		clock:     clock,
 	}
 }
 
@@ -37,7 +45,7 @@ func (r *RefreshTokenRepository) Save(ctx context.Context, t *models.RefreshToke
 	}
 
 	params := mapper.DomainRefreshTokenToSQLCParams(t)
-	if err := r.queris.CreateRefreshToken(ctx, params); err != nil {
+	if err := r.db(ctx).CreateRefreshToken(ctx, params); err != nil {
 		return appErr.Wrap(appErr.ErrInternal, "failed to create refresh token", err)
 	}
 
@@ -49,7 +57,7 @@ func (r *RefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash s
 		return nil, appErr.Wrap(appErr.ErrValidation, "invalid token hash", err)
 	}
 
-	row, err := r.queris.GetRefreshTokenByHash(ctx, tokenHash)
+	row, err := r.db(ctx).GetRefreshTokenByHash(ctx, tokenHash)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, appErr.New(appErr.ErrNotFound, "refresh token not found")
@@ -68,7 +76,10 @@ func (r *RefreshTokenRepository) GetByUserID(ctx context.Context, userID models.
 		return nil, appErr.Wrap(appErr.ErrValidation, "invalid user id", err)
 	}
 
-	rows, err := r.queris.GetRefreshTokensByUserID(ctx, userID.String())
+	rows, err := r.db(ctx).GetRefreshTokensByUserID(ctx, sqlc.GetRefreshTokensByUserIDParams{
+		UserID:    userID.String(),
+		ExpiresAt: pgtype.Timestamp{Time: r.clock.Now(), Valid: true},
+	})
 	if err != nil {
 		return nil, appErr.Wrap(appErr.ErrInternal, "failed to get refresh token", err)
 	}
@@ -86,8 +97,8 @@ func (r *RefreshTokenRepository) Revoke(ctx context.Context, tokenID models.ID)
 		return appErr.Wrap(appErr.ErrValidation, "invalid token id", err)
 	}
 
-	now := time.Now()
-	if err := r.queris.RevokeRefreshToken(ctx, sqlc.RevokeRefreshTokenParams{
+	now := r.clock.Now()
+	if err := r.db(ctx).RevokeRefreshToken(ctx, sqlc.RevokeRefreshTokenParams{
 		ID:        tokenID.String(),
 		RevokedAt: pgtype.Timestamp{Time: now, Valid: true},
 	}); err != nil {
@@ -97,13 +108,19 @@ func (r *RefreshTokenRepository) Revoke(ctx context.Context, tokenID models.ID)
 	return nil
 }
 
-func (r *RefreshTokenRepository) DeleteExpired(ctx context.Context) error {
-	if err := r.queris.DeleteExpiredRefreshTokens(ctx, pgtype.Timestamp{
-		Time:  time.Now(),
+func (r *RefreshTokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	n, err := r.db(ctx).DeleteExpiredRefreshTokens(ctx, pgtype.Timestamp{
+		Time:  r.clock.Now(),
 		Valid: true,
-	}); err != nil {
-		return appErr.Wrap(appErr.ErrInternal, "failed to delete expired refresh tokens", err)
+	})
+	if err != nil {
+		return 0, appErr.Wrap(appErr.ErrInternal, "failed to delete expired refresh tokens", err)
 	}
 
-	return nil
+	return n, nil
+}
+
+// db memakai transaksi dari ctx kalau ada, selain itu pakai pool
+func (r *RefreshTokenRepository) db(ctx context.Context) *sqlc.Queries {
+	return postgres.QueriesFrom(ctx, r.queris)
 }
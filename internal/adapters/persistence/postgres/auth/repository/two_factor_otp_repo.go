@@ -4,21 +4,29 @@ import (
 	"context"
 	"errors"
 
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
 	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/auth/mapper"
 	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
 	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
 	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type TwoFactorOTPRepository struct {
-	q *sqlc.Queries
+	q     *sqlc.Queries
+	clock sharedPorts.Clock
 }
 
-func NewTwoFactorOTPRepository(q *sqlc.Queries) *TwoFactorOTPRepository {
-	return &TwoFactorOTPRepository{q: q}
+// NewTwoFactorOTPRepository clock nil berarti jam sistem
+func NewTwoFactorOTPRepository(q *sqlc.Queries, clock sharedPorts.Clock) *TwoFactorOTPRepository {
+	if clock == nil {
+		clock = sharedPorts.SystemClock
+	}
+
+	return &TwoFactorOTPRepository{q: q, clock: clock}
 }
 
 func (r *TwoFactorOTPRepository) Save(ctx context.Context, otp *models.TwoFactorOTP) error {
@@ -27,7 +35,7 @@ func (r *TwoFactorOTPRepository) Save(ctx context.Context, otp *models.TwoFactor
 		usedAt = pgtype.Timestamp{Time: *otp.UsedAt, Valid: true}
 	}
 
-	return r.q.CreateTwoFactorOTP(ctx, sqlc.CreateTwoFactorOTPParams{
+	return r.db(ctx).CreateTwoFactorOTP(ctx, sqlc.CreateTwoFactorOTPParams{
 		ID:          otp.ID.String(),
 		UserID:      otp.UserID.String(),
 		ChallengeID: otp.ChallengeID,
@@ -39,7 +47,7 @@ func (r *TwoFactorOTPRepository) Save(ctx context.Context, otp *models.TwoFactor
 }
 
 func (r *TwoFactorOTPRepository) GetByChallengeID(ctx context.Context, challengeID string) (*models.TwoFactorOTP, error) {
-	row, err := r.q.GetTwoFactorOTPByChallengeID(ctx, challengeID)
+	row, err := r.db(ctx).GetTwoFactorOTPByChallengeID(ctx, challengeID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, appErr.New(appErr.ErrNotFound, "2fa challenge not found")
@@ -51,9 +59,23 @@ func (r *TwoFactorOTPRepository) GetByChallengeID(ctx context.Context, challenge
 }
 
 func (r *TwoFactorOTPRepository) MarkUsed(ctx context.Context, id models.ID) error {
-	return r.q.MarkTwoFactorOTPUsed(ctx, id.String())
+	return r.db(ctx).MarkTwoFactorOTPUsed(ctx, sqlc.MarkTwoFactorOTPUsedParams{
+		ID:     id.String(),
+		UsedAt: pgtype.Timestamp{Time: r.clock.Now(), Valid: true},
+	})
+}
+
+// DeleteExpired ikut menghapus OTP yang sudah terpakai
+func (r *TwoFactorOTPRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	n, err := r.db(ctx).DeleteExpiresTwoFactorOTP(ctx, pgtype.Timestamp{Time: r.clock.Now(), Valid: true})
+	if err != nil {
+		return 0, appErr.Wrap(appErr.ErrInternal, "failed to delete expired two factor otps", err)
+	}
+
+	return n, nil
 }
 
-func (r *TwoFactorOTPRepository) DeleteExpired(ctx context.Context) error {
-	return r.q.DeleteExpiresTwoFactorOTP(ctx)
+// db memakai transaksi dari ctx kalau ada, selain itu pakai pool
+func (r *TwoFactorOTPRepository) db(ctx context.Context) *sqlc.Queries {
+	return postgres.QueriesFrom(ctx, r.q)
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
 	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/auth/mapper"
 	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
 	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
 	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/i18n"
 	"villainrsty-ecommerce-server/pkg/validator"
 
 	"github.com/jackc/pgx/v5"
@@ -30,7 +32,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 		return nil, appErr.Wrap(appErr.ErrValidation, "invalid email format", err)
 	}
 
-	row, err := r.queries.GetUserByEmail(ctx, email)
+	row, err := r.db(ctx).GetUserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, appErr.New(appErr.ErrNotFound, "user not found")
@@ -49,7 +51,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*models.User,
 		return nil, appErr.Wrap(appErr.ErrValidation, "invalid id", err)
 	}
 
-	row, err := r.queries.GetUserByID(ctx, id)
+	row, err := r.db(ctx).GetUserByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, appErr.New(appErr.ErrNotFound, "user not found")
@@ -67,7 +69,7 @@ func (r *UserRepository) Save(ctx context.Context, user *models.User) error {
 		return err
 	}
 
-	exists, err := r.queries.UserExists(ctx, user.Email)
+	exists, err := r.db(ctx).UserExists(ctx, user.Email)
 	if err != nil {
 		return appErr.Wrap(appErr.ErrInternal, "failed to check user existence", err)
 	}
@@ -81,7 +83,7 @@ func (r *UserRepository) Save(ctx context.Context, user *models.User) error {
 	}
 
 	params := mapper.DomainUserToSQLCParams(user)
-	if err := r.queries.CreateUser(ctx, params); err != nil {
+	if err := r.db(ctx).CreateUser(ctx, params); err != nil {
 		return appErr.Wrap(appErr.ErrInternal, "failed to create user", err)
 	}
 
@@ -93,7 +95,7 @@ func (r *UserRepository) Delete(ctx context.Context, id string) error {
 		return appErr.Wrap(appErr.ErrValidation, "invalid id", err)
 	}
 
-	if err := r.queries.DeleteUser(ctx, id); err != nil {
+	if err := r.db(ctx).DeleteUser(ctx, id); err != nil {
 		return appErr.Wrap(appErr.ErrInternal, "failed to delete user", err)
 	}
 
@@ -105,7 +107,7 @@ func (r *UserRepository) Exist(ctx context.Context, email string) (bool, error)
 		return false, appErr.Wrap(appErr.ErrValidation, "invalid email format", err)
 	}
 
-	exists, err := r.queries.UserExists(ctx, email)
+	exists, err := r.db(ctx).UserExists(ctx, email)
 	if err != nil {
 		return false, appErr.Wrap(appErr.ErrInternal, "failed to check user existence", err)
 	}
@@ -122,7 +124,7 @@ func (r *UserRepository) UpdateUserPassword(ctx context.Context, id models.ID, h
 		return appErr.Wrap(appErr.ErrValidation, "invalid password", err)
 	}
 
-	err := r.queries.UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
+	err := r.db(ctx).UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
 		ID:       id.String(),
 		Password: hashed,
 	})
@@ -132,3 +134,20 @@ func (r *UserRepository) UpdateUserPassword(ctx context.Context, id models.ID, h
 
 	return nil
 }
+
+func (r *UserRepository) UpdateLocale(ctx context.Context, id models.ID, locale i18n.Locale) error {
+	err := r.db(ctx).UpdateUserLocale(ctx, sqlc.UpdateUserLocaleParams{
+		ID:     id.String(),
+		Locale: string(locale),
+	})
+	if err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to update user locale", err)
+	}
+
+	return nil
+}
+
+// db memakai transaksi dari ctx kalau ada, selain itu pakai pool
+func (r *UserRepository) db(ctx context.Context) *sqlc.Queries {
+	return postgres.QueriesFrom(ctx, r.queries)
+}
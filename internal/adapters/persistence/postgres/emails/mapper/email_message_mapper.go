@@ -0,0 +1,70 @@
+package mapper
+
+import (
+	"encoding/json"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/i18n"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func SQLEmailMessageToDomain(m sqlc.EmailMessage) (*models.EmailMessage, error) {
+	var data map[string]any
+	if len(m.Data) > 0 {
+		if err := json.Unmarshal(m.Data, &data); err != nil {
+			return nil, err
+		}
+	}
+
+	var sentAt *time.Time
+	if m.SentAt.Valid {
+		sentAt = &m.SentAt.Time
+	}
+
+	var dataPurgedAt *time.Time
+	if m.DataPurgedAt.Valid {
+		dataPurgedAt = &m.DataPurgedAt.Time
+	}
+
+	return &models.EmailMessage{
+		ID:                models.ID(m.ID),
+		Template:          m.Template,
+		ToEmail:           m.ToEmail,
+		Locale:            i18n.Locale(m.Locale),
+		Data:              data,
+		Status:            models.EmailStatus(m.Status),
+		Attempts:          int(m.Attempts),
+		ProviderMessageID: m.ProviderMessageID.String,
+		LastError:         m.LastError.String,
+		SentAt:            sentAt,
+		CreatedAt:         m.CreatedAt.Time,
+		UpdatedAt:         m.UpdatedAt.Time,
+		DataPurgedAt:      dataPurgedAt,
+	}, nil
+}
+
+func DomainEmailMessageToSQLCParams(m *models.EmailMessage) (sqlc.CreateEmailMessageParams, error) {
+	data := []byte("{}")
+	if len(m.Data) > 0 {
+		encoded, err := json.Marshal(m.Data)
+		if err != nil {
+			return sqlc.CreateEmailMessageParams{}, err
+		}
+		data = encoded
+	}
+
+	return sqlc.CreateEmailMessageParams{
+		ID:        m.ID.String(),
+		Template:  m.Template,
+		ToEmail:   m.ToEmail,
+		Locale:    string(m.Locale),
+		Data:      data,
+		Status:    string(m.Status),
+		Attempts:  int32(m.Attempts),
+		CreatedAt: pgtype.Timestamp{Time: m.CreatedAt, Valid: true},
+		UpdatedAt: pgtype.Timestamp{Time: m.UpdatedAt, Valid: true},
+	}, nil
+}
@@ -0,0 +1,93 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/pgtest"
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+func TestEmailMessageRepository(t *testing.T) {
+	ctx := context.Background()
+	emails := NewEmailMessageRepository(postgres.NewQueries(pgtest.Pool(t)))
+
+	msg := models.NewEmailMessage("password_reset", "budi@mail.com", i18n.EN, map[string]any{"link": "http://localhost/reset?token=abc"}, time.Now())
+	other := models.NewEmailMessage("login_otp", "siti@mail.com", i18n.ID, nil, time.Now())
+	for _, m := range []*models.EmailMessage{msg, other} {
+		if err := emails.Save(ctx, m); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	got, err := emails.GetByID(ctx, msg.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	if got.Data["link"] != "http://localhost/reset?token=abc" || got.Locale != i18n.EN || got.SentAt != nil || got.ProviderMessageID != "" || got.LastError != "" {
+		t.Errorf("GetByID() = %+v, want data preserved and NULL columns empty", got)
+	}
+
+	if err := emails.RecordAttempt(ctx, msg.ID, models.EmailStatusFailed, "", "connection refused"); err != nil {
+		t.Fatalf("RecordAttempt() error = %v", err)
+	}
+
+	if err := emails.RecordAttempt(ctx, msg.ID, models.EmailStatusSent, "<abc@smtp>", ""); err != nil {
+		t.Fatalf("RecordAttempt() error = %v", err)
+	}
+
+	got, err = emails.GetByID(ctx, msg.ID)
+	if err != nil || got.Status != models.EmailStatusSent || got.Attempts != 2 || got.SentAt == nil || got.ProviderMessageID != "<abc@smtp>" || got.LastError != "" {
+		t.Errorf("GetByID() after send = %+v, %v", got, err)
+	}
+
+	if len(got.Data) != 0 || got.DataPurgedAt == nil {
+		t.Errorf("data after send = %v, purged at %v, want emptied", got.Data, got.DataPurgedAt)
+	}
+
+	if n, err := emails.Count(ctx, ""); err != nil || n != 2 {
+		t.Errorf("Count(all) = %d, %v, want 2", n, err)
+	}
+
+	list, err := emails.List(ctx, models.EmailStatusQueued, 10, 0)
+	if err != nil || len(list) != 1 || list[0].ID != other.ID {
+		t.Errorf("List(queued) = %+v, %v, want only the queued message", list, err)
+	}
+
+	// hanya pesan failed yang bisa diantrikan ulang
+	if err := emails.Requeue(ctx, msg.ID); !appErr.IsKind(err, appErr.ErrConflict) {
+		t.Errorf("Requeue() sent error = %v, want conflict", err)
+	}
+
+	if err := emails.RecordAttempt(ctx, other.ID, models.EmailStatusFailed, "", "connection refused"); err != nil {
+		t.Fatalf("RecordAttempt() error = %v", err)
+	}
+
+	if err := emails.Requeue(ctx, other.ID); err != nil {
+		t.Fatalf("Requeue() error = %v", err)
+	}
+
+	if n, err := emails.Count(ctx, models.EmailStatusQueued); err != nil || n != 1 {
+		t.Errorf("Count(queued) after requeue = %d, %v, want 1", n, err)
+	}
+
+	// pesan yang sudah terkirim tidak ikut dihitung ulang
+	if n, err := emails.PurgeData(ctx, time.Now().Add(time.Minute)); err != nil || n != 1 {
+		t.Errorf("PurgeData() = %d, %v, want 1", n, err)
+	}
+
+	if got, err := emails.GetByID(ctx, other.ID); err != nil || got.DataPurgedAt == nil {
+		t.Errorf("GetByID() after purge = %+v, %v, want data purged", got, err)
+	}
+
+	if err := emails.Requeue(ctx, models.NewID()); !appErr.IsKind(err, appErr.ErrConflict) {
+		t.Errorf("Requeue() unknown error = %v, want conflict", err)
+	}
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/emails/mapper"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type EmailMessageRepository struct {
+	q *sqlc.Queries
+}
+
+func NewEmailMessageRepository(q *sqlc.Queries) *EmailMessageRepository {
+	return &EmailMessageRepository{q: q}
+}
+
+func (r *EmailMessageRepository) Save(ctx context.Context, msg *models.EmailMessage) error {
+	params, err := mapper.DomainEmailMessageToSQLCParams(msg)
+	if err != nil {
+		return appErr.Wrap(appErr.ErrValidation, "email data must be JSON encodable", err)
+	}
+
+	if err := r.db(ctx).CreateEmailMessage(ctx, params); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to save email message", err)
+	}
+
+	return nil
+}
+
+func (r *EmailMessageRepository) GetByID(ctx context.Context, id models.ID) (*models.EmailMessage, error) {
+	row, err := r.db(ctx).GetEmailMessageByID(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appErr.New(appErr.ErrNotFound, "email message not found")
+		}
+
+		return nil, appErr.Wrap(appErr.ErrInternal, "failed to get email message", err)
+	}
+
+	msg, err := mapper.SQLEmailMessageToDomain(row)
+	if err != nil {
+		return nil, appErr.Wrap(appErr.ErrInternal, "failed to decode email message", err)
+	}
+
+	return msg, nil
+}
+
+func (r *EmailMessageRepository) List(ctx context.Context, status models.EmailStatus, limit, offset int) ([]*models.EmailMessage, error) {
+	rows, err := r.db(ctx).ListEmailMessages(ctx, sqlc.ListEmailMessagesParams{
+		Status:      string(status),
+		LimitCount:  int32(limit),
+		OffsetCount: int32(offset),
+	})
+	if err != nil {
+		return nil, appErr.Wrap(appErr.ErrInternal, "failed to list email messages", err)
+	}
+
+	msgs := make([]*models.EmailMessage, len(rows))
+	for i, row := range rows {
+		msg, err := mapper.SQLEmailMessageToDomain(row)
+		if err != nil {
+			return nil, appErr.Wrap(appErr.ErrInternal, "failed to decode email message", err)
+		}
+		msgs[i] = msg
+	}
+
+	return msgs, nil
+}
+
+func (r *EmailMessageRepository) Count(ctx context.Context, status models.EmailStatus) (int64, error) {
+	total, err := r.db(ctx).CountEmailMessages(ctx, string(status))
+	if err != nil {
+		return 0, appErr.Wrap(appErr.ErrInternal, "failed to count email messages", err)
+	}
+
+	return total, nil
+}
+
+func (r *EmailMessageRepository) RecordAttempt(ctx context.Context, id models.ID, status models.EmailStatus, providerMessageID, lastError string) error {
+	if err := r.db(ctx).RecordEmailAttempt(ctx, sqlc.RecordEmailAttemptParams{
+		ID:                id.String(),
+		Status:            string(status),
+		ProviderMessageID: pgtype.Text{String: providerMessageID, Valid: providerMessageID != ""},
+		LastError:         pgtype.Text{String: lastError, Valid: lastError != ""},
+	}); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to record email attempt", err)
+	}
+
+	return nil
+}
+
+func (r *EmailMessageRepository) Requeue(ctx context.Context, id models.ID) error {
+	affected, err := r.db(ctx).RequeueEmailMessage(ctx, id.String())
+	if err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to requeue email message", err)
+	}
+
+	// 0 baris berarti pesan tidak ada, tidak berstatus failed atau datanya sudah dihapus;
+	// service sudah mengecek keberadaannya
+	if affected == 0 {
+		return appErr.New(appErr.ErrConflict, "only failed email messages can be resent")
+	}
+
+	return nil
+}
+
+func (r *EmailMessageRepository) PurgeData(ctx context.Context, createdBefore time.Time) (int64, error) {
+	affected, err := r.db(ctx).PurgeEmailMessageData(ctx, pgtype.Timestamp{Time: createdBefore, Valid: true})
+	if err != nil {
+		return 0, appErr.Wrap(appErr.ErrInternal, "failed to purge email message data", err)
+	}
+
+	return affected, nil
+}
+
+// db memakai transaksi dari ctx kalau ada, selain itu pakai pool
+func (r *EmailMessageRepository) db(ctx context.Context) *sqlc.Queries {
+	return postgres.QueriesFrom(ctx, r.q)
+}
@@ -0,0 +1,51 @@
+package mapper
+
+import (
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func SQLOutboxEventToDomain(e sqlc.OutboxEvent) *models.DomainEvent {
+	var publishedAt *time.Time
+	if e.PublishedAt.Valid {
+		publishedAt = &e.PublishedAt.Time
+	}
+
+	return &models.DomainEvent{
+		ID:            models.ID(e.ID),
+		AggregateType: e.AggregateType,
+		AggregateID:   models.ID(e.AggregateID),
+		Type:          e.EventType,
+		Payload:       e.Payload,
+		OccurredAt:    e.OccurredAt.Time,
+		PublishedAt:   publishedAt,
+		Attempts:      int(e.Attempts),
+		LastError:     e.LastError.String,
+	}
+}
+
+func DomainEventToSQLCParams(e *models.DomainEvent) sqlc.CreateOutboxEventParams {
+	return sqlc.CreateOutboxEventParams{
+		ID:            e.ID.String(),
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID.String(),
+		EventType:     e.Type,
+		Payload:       e.Payload,
+		OccurredAt: pgtype.Timestamp{
+			Time:  e.OccurredAt,
+			Valid: true,
+		},
+		AvailableAt: pgtype.Timestamp{
+			Time:  e.OccurredAt,
+			Valid: true,
+		},
+		CreatedAt: pgtype.Timestamp{
+			Time:  e.OccurredAt,
+			Valid: true,
+		},
+	}
+}
@@ -0,0 +1,68 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/pgtest"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	ctx := context.Background()
+	outbox := NewOutboxRepository(postgres.NewQueries(pgtest.Pool(t)))
+
+	first, err := models.NewDomainEvent("user.registered", "user", models.NewID(), map[string]string{"email": "budi@mail.com"}, time.Now())
+	if err != nil {
+		t.Fatalf("NewDomainEvent() error = %v", err)
+	}
+	second, _ := models.NewDomainEvent("user.password_changed", "user", first.AggregateID, nil, time.Now())
+	second.OccurredAt = first.OccurredAt.Add(time.Second)
+	for _, event := range []*models.DomainEvent{second, first} {
+		if err := outbox.Save(ctx, event); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	now := second.OccurredAt.Add(time.Second)
+	claimed, err := outbox.ClaimUnpublished(ctx, now, 10, 3)
+	if err != nil || len(claimed) != 2 || claimed[0].ID != first.ID {
+		t.Fatalf("ClaimUnpublished() = %+v, %v, want both events oldest first", claimed, err)
+	}
+
+	var payload map[string]string
+	if err := json.Unmarshal(claimed[0].Payload, &payload); err != nil || payload["email"] != "budi@mail.com" {
+		t.Errorf("payload = %s, %v", claimed[0].Payload, err)
+	}
+
+	if claimed[0].PublishedAt != nil || claimed[0].LastError != "" || !claimed[0].OccurredAt.Equal(pgtest.Timestamp(first.OccurredAt)) {
+		t.Errorf("claimed event = %+v, want unpublished without error", claimed[0])
+	}
+
+	if err := outbox.MarkPublished(ctx, first.ID, now); err != nil {
+		t.Fatalf("MarkPublished() error = %v", err)
+	}
+
+	if err := outbox.MarkFailed(ctx, second.ID, "handler failed", now.Add(time.Minute)); err != nil {
+		t.Fatalf("MarkFailed() error = %v", err)
+	}
+
+	// yang sudah dipublish tidak diklaim lagi, yang gagal menunggu available_at
+	if claimed, err := outbox.ClaimUnpublished(ctx, now, 10, 3); err != nil || len(claimed) != 0 {
+		t.Errorf("ClaimUnpublished() before retry = %+v, %v, want nothing", claimed, err)
+	}
+
+	claimed, err = outbox.ClaimUnpublished(ctx, now.Add(time.Minute), 10, 3)
+	if err != nil || len(claimed) != 1 || claimed[0].Attempts != 1 || claimed[0].LastError != "handler failed" {
+		t.Errorf("ClaimUnpublished() after retry = %+v, %v, want the failed event with 1 attempt", claimed, err)
+	}
+
+	if claimed, err := outbox.ClaimUnpublished(ctx, now.Add(time.Minute), 10, 1); err != nil || len(claimed) != 0 {
+		t.Errorf("ClaimUnpublished() with maxAttempts 1 = %+v, %v, want nothing", claimed, err)
+	}
+}
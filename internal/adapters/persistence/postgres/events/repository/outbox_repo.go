@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/events/mapper"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type OutboxRepository struct {
+	q *sqlc.Queries
+}
+
+func NewOutboxRepository(q *sqlc.Queries) *OutboxRepository {
+	return &OutboxRepository{q: q}
+}
+
+func (r *OutboxRepository) Save(ctx context.Context, event *models.DomainEvent) error {
+	if event.Payload == nil {
+		event.Payload = []byte("{}")
+	}
+
+	if err := r.db(ctx).CreateOutboxEvent(ctx, mapper.DomainEventToSQLCParams(event)); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to save outbox event", err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) ClaimUnpublished(ctx context.Context, now time.Time, limit, maxAttempts int) ([]*models.DomainEvent, error) {
+	rows, err := r.db(ctx).ClaimOutboxEvents(ctx, sqlc.ClaimOutboxEventsParams{
+		Now:         pgtype.Timestamp{Time: now, Valid: true},
+		MaxAttempts: int32(maxAttempts),
+		BatchSize:   int32(limit),
+	})
+	if err != nil {
+		return nil, appErr.Wrap(appErr.ErrInternal, "failed to claim outbox events", err)
+	}
+
+	events := make([]*models.DomainEvent, len(rows))
+	for i, row := range rows {
+		events[i] = mapper.SQLOutboxEventToDomain(row)
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id models.ID, at time.Time) error {
+	if err := r.db(ctx).MarkOutboxEventPublished(ctx, sqlc.MarkOutboxEventPublishedParams{
+		ID:          id.String(),
+		PublishedAt: pgtype.Timestamp{Time: at, Valid: true},
+	}); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to mark outbox event published", err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id models.ID, lastError string, retryAt time.Time) error {
+	if err := r.db(ctx).MarkOutboxEventFailed(ctx, sqlc.MarkOutboxEventFailedParams{
+		ID:          id.String(),
+		LastError:   pgtype.Text{String: lastError, Valid: lastError != ""},
+		AvailableAt: pgtype.Timestamp{Time: retryAt, Valid: true},
+	}); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to mark outbox event failed", err)
+	}
+
+	return nil
+}
+
+// db memakai transaksi dari ctx kalau ada, selain itu pakai pool
+func (r *OutboxRepository) db(ctx context.Context) *sqlc.Queries {
+	return postgres.QueriesFrom(ctx, r.q)
+}
@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"context"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/migrate"
+	"villainrsty-ecommerce-server/internal/core/health/ports"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PingCheck memastikan pool masih bisa mendapatkan koneksi ke Postgres
+func PingCheck(pool *pgxpool.Pool) ports.HealthCheck {
+	return ports.CheckFunc(func(ctx context.Context) error {
+		return pool.Ping(ctx)
+	})
+}
+
+// MigrationCheck gagal kalau schema dirty atau masih ada migration yang di-embed ke binary
+// tapi belum diterapkan, sama dengan pengecekan saat serve start
+func MigrationCheck(migrator *migrate.Migrator) ports.HealthCheck {
+	return ports.CheckFunc(func(ctx context.Context) error {
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		return status.Err()
+	})
+}
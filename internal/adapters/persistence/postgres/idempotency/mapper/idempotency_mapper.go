@@ -0,0 +1,40 @@
+package mapper
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+	"villainrsty-ecommerce-server/internal/core/idempotency/models"
+)
+
+func SQLIdempotencyKeyToDomain(k sqlc.IdempotencyKey) (*models.Record, error) {
+	record := &models.Record{
+		Scope:       k.Scope,
+		Key:         k.Key,
+		Method:      k.Method,
+		Path:        k.Path,
+		Fingerprint: k.Fingerprint,
+		Status:      models.Status(k.Status),
+		LockedAt:    k.LockedAt.Time,
+		ExpiresAt:   k.ExpiresAt.Time,
+		CreatedAt:   k.CreatedAt.Time,
+	}
+
+	if k.ResponseStatus.Valid {
+		header := http.Header{}
+		if len(k.ResponseHeaders) > 0 {
+			if err := json.Unmarshal(k.ResponseHeaders, &header); err != nil {
+				return nil, err
+			}
+		}
+
+		record.Response = &models.Response{
+			StatusCode: int(k.ResponseStatus.Int32),
+			Header:     header,
+			Body:       k.ResponseBody,
+		}
+	}
+
+	return record, nil
+}
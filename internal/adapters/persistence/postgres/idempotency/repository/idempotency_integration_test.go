@@ -0,0 +1,85 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/pgtest"
+	"villainrsty-ecommerce-server/internal/core/idempotency/models"
+)
+
+func TestIdempotencyRepository(t *testing.T) {
+	ctx := context.Background()
+	store := NewIdempotencyRepository(postgres.NewQueries(pgtest.Pool(t)))
+
+	now := time.Now()
+	fingerprint := models.Fingerprint(http.MethodPost, "/auth/register", []byte(`{"email":"budi@mail.com"}`))
+	record := models.NewRecord("anon:127.0.0.1", "key-1", http.MethodPost, "/auth/register", fingerprint, now, time.Hour)
+
+	got, acquired, err := store.Acquire(ctx, record, now.Add(-time.Minute))
+	if err != nil || !acquired || got.Status != models.StatusProcessing || got.Response != nil {
+		t.Fatalf("Acquire() = %+v, %v, %v, want a fresh processing record without response", got, acquired, err)
+	}
+
+	// request kedua dengan key yang sama selagi yang pertama masih jalan
+	got, acquired, err = store.Acquire(ctx, record, now.Add(-time.Minute))
+	if err != nil || acquired || got.Fingerprint != fingerprint {
+		t.Fatalf("Acquire() in flight = %+v, %v, %v, want the existing record", got, acquired, err)
+	}
+
+	resp := &models.Response{StatusCode: http.StatusCreated, Header: http.Header{"Content-Type": {"application/json"}}, Body: []byte(`{"success":true}`)}
+	if err := store.Complete(ctx, record.Scope, record.Key, resp, now, now.Add(time.Hour)); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	// Release hanya melepas key yang masih processing
+	if err := store.Release(ctx, record.Scope, record.Key); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+
+	got, acquired, err = store.Acquire(ctx, record, now.Add(-time.Minute))
+	if err != nil || acquired || got.Status != models.StatusCompleted || got.Response == nil {
+		t.Fatalf("Acquire() after complete = %+v, %v, %v, want the completed record", got, acquired, err)
+	}
+
+	if got.Response.StatusCode != http.StatusCreated || got.Response.Header.Get("Content-Type") != "application/json" || string(got.Response.Body) != `{"success":true}` {
+		t.Errorf("stored response = %+v", got.Response)
+	}
+
+	// setelah expired key boleh dipakai lagi
+	later := now.Add(2 * time.Hour)
+	retry := models.NewRecord(record.Scope, record.Key, http.MethodPost, "/auth/register", fingerprint, later, time.Hour)
+	got, acquired, err = store.Acquire(ctx, retry, later.Add(-time.Minute))
+	if err != nil || !acquired || got.Response != nil {
+		t.Errorf("Acquire() after expiry = %+v, %v, %v, want a fresh record", got, acquired, err)
+	}
+}
+
+func TestIdempotencyRepository_StaleAndRelease(t *testing.T) {
+	ctx := context.Background()
+	store := NewIdempotencyRepository(postgres.NewQueries(pgtest.Pool(t)))
+
+	now := time.Now()
+	record := models.NewRecord("user:1", "key-1", http.MethodPost, "/admin/jobs/1/retry", "fp", now, time.Hour)
+	if _, acquired, err := store.Acquire(ctx, record, now.Add(-time.Minute)); err != nil || !acquired {
+		t.Fatalf("Acquire() = %v, %v", acquired, err)
+	}
+
+	// pemegang lock dianggap mati setelah lewat staleBefore
+	if _, acquired, err := store.Acquire(ctx, record, now.Add(time.Second)); err != nil || !acquired {
+		t.Errorf("Acquire() stale = %v, %v, want acquired", acquired, err)
+	}
+
+	if err := store.Release(ctx, record.Scope, record.Key); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+
+	if _, acquired, err := store.Acquire(ctx, record, now.Add(-time.Minute)); err != nil || !acquired {
+		t.Errorf("Acquire() after release = %v, %v, want acquired", acquired, err)
+	}
+}
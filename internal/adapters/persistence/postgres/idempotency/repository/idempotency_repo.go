@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/idempotency/mapper"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+	"villainrsty-ecommerce-server/internal/core/idempotency/models"
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const idempotencyPurgeInterval = 10 * time.Minute
+
+type IdempotencyRepository struct {
+	q         *sqlc.Queries
+	lastPurge atomic.Int64
+}
+
+func NewIdempotencyRepository(q *sqlc.Queries) *IdempotencyRepository {
+	return &IdempotencyRepository{q: q}
+}
+
+func (r *IdempotencyRepository) Acquire(ctx context.Context, record *models.Record, staleBefore time.Time) (*models.Record, bool, error) {
+	r.purgeExpired(ctx, record.LockedAt)
+
+	row, err := r.db(ctx).AcquireIdempotencyKey(ctx, sqlc.AcquireIdempotencyKeyParams{
+		Scope:       record.Scope,
+		Key:         record.Key,
+		Method:      record.Method,
+		Path:        record.Path,
+		Fingerprint: record.Fingerprint,
+		LockedAt:    pgtype.Timestamp{Time: record.LockedAt, Valid: true},
+		ExpiresAt:   pgtype.Timestamp{Time: record.ExpiresAt, Valid: true},
+		CreatedAt:   pgtype.Timestamp{Time: record.CreatedAt, Valid: true},
+		StaleBefore: pgtype.Timestamp{Time: staleBefore, Valid: true},
+	})
+	if err == nil {
+		acquired, err := mapper.SQLIdempotencyKeyToDomain(row)
+		if err != nil {
+			return nil, false, appErr.Wrap(appErr.ErrInternal, "failed to decode idempotency key", err)
+		}
+		return acquired, true, nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, appErr.Wrap(appErr.ErrInternal, "failed to acquire idempotency key", err)
+	}
+
+	// Tidak ada baris yang di-insert/update: key masih dipegang request lain atau sudah selesai
+	row, err = r.db(ctx).GetIdempotencyKey(ctx, sqlc.GetIdempotencyKeyParams{Scope: record.Scope, Key: record.Key})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// baru saja di-release oleh request lain, client cukup mengulang
+			return nil, false, appErr.New(appErr.ErrConflict, "idempotency key changed concurrently")
+		}
+		return nil, false, appErr.Wrap(appErr.ErrInternal, "failed to get idempotency key", err)
+	}
+
+	existing, err := mapper.SQLIdempotencyKeyToDomain(row)
+	if err != nil {
+		return nil, false, appErr.Wrap(appErr.ErrInternal, "failed to decode idempotency key", err)
+	}
+
+	return existing, false, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, scope, key string, resp *models.Response, completedAt, expiresAt time.Time) error {
+	header, err := json.Marshal(resp.Header)
+	if err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to encode response headers", err)
+	}
+
+	if err := r.db(ctx).CompleteIdempotencyKey(ctx, sqlc.CompleteIdempotencyKeyParams{
+		Scope:           scope,
+		Key:             key,
+		ResponseStatus:  pgtype.Int4{Int32: int32(resp.StatusCode), Valid: true},
+		ResponseHeaders: header,
+		ResponseBody:    resp.Body,
+		CompletedAt:     pgtype.Timestamp{Time: completedAt, Valid: true},
+		ExpiresAt:       pgtype.Timestamp{Time: expiresAt, Valid: true},
+	}); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to complete idempotency key", err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, scope, key string) error {
+	if err := r.db(ctx).ReleaseIdempotencyKey(ctx, sqlc.ReleaseIdempotencyKeyParams{Scope: scope, Key: key}); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to release idempotency key", err)
+	}
+
+	return nil
+}
+
+// purgeExpired menghapus key yang sudah lewat masa berlaku, paling sering sekali per idempotencyPurgeInterval
+func (r *IdempotencyRepository) purgeExpired(ctx context.Context, now time.Time) {
+	last := r.lastPurge.Load()
+	if now.UnixNano()-last < int64(idempotencyPurgeInterval) {
+		return
+	}
+
+	if !r.lastPurge.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+
+	_, _ = r.db(ctx).DeleteExpiredIdempotencyKeys(ctx, pgtype.Timestamp{Time: now, Valid: true})
+}
+
+// db memakai transaksi dari ctx kalau ada, selain itu pakai pool
+func (r *IdempotencyRepository) db(ctx context.Context) *sqlc.Queries {
+	return postgres.QueriesFrom(ctx, r.q)
+}
@@ -0,0 +1,54 @@
+package mapper
+
+import (
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func SQLJobToDomain(j sqlc.Job) *models.Job {
+	var lockedAt *time.Time
+	if j.LockedAt.Valid {
+		lockedAt = &j.LockedAt.Time
+	}
+
+	return &models.Job{
+		ID:          models.ID(j.ID),
+		Type:        j.Type,
+		Payload:     j.Payload,
+		Status:      models.JobStatus(j.Status),
+		Attempts:    int(j.Attempts),
+		MaxAttempts: int(j.MaxAttempts),
+		RunAt:       j.RunAt.Time,
+		LockedAt:    lockedAt,
+		LastError:   j.LastError.String,
+		CreatedAt:   j.CreatedAt.Time,
+		UpdatedAt:   j.UpdatedAt.Time,
+	}
+}
+
+func DomainJobToSQLCParams(j *models.Job) sqlc.CreateJobParams {
+	return sqlc.CreateJobParams{
+		ID:          j.ID.String(),
+		Type:        j.Type,
+		Payload:     j.Payload,
+		Status:      string(j.Status),
+		Attempts:    int32(j.Attempts),
+		MaxAttempts: int32(j.MaxAttempts),
+		RunAt: pgtype.Timestamp{
+			Time:  j.RunAt,
+			Valid: true,
+		},
+		CreatedAt: pgtype.Timestamp{
+			Time:  j.CreatedAt,
+			Valid: true,
+		},
+		UpdatedAt: pgtype.Timestamp{
+			Time:  j.UpdatedAt,
+			Valid: true,
+		},
+	}
+}
@@ -0,0 +1,114 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/pgtest"
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+func TestJobRepository_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	jobs := NewJobRepository(postgres.NewQueries(pgtest.Pool(t)))
+
+	now := time.Now()
+	due := models.NewJob("email.deliver", []byte(`{"message_id":"m-1"}`), 3, time.Now())
+	due.RunAt = now.Add(-time.Minute)
+	later := models.NewJob("email.deliver", nil, 3, time.Now())
+	later.RunAt = now.Add(time.Hour)
+	for _, job := range []*models.Job{due, later} {
+		if err := jobs.Enqueue(ctx, job); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+
+	got, err := jobs.GetByID(ctx, later.ID)
+	if err != nil || got.LockedAt != nil || got.LastError != "" || string(got.Payload) != "{}" {
+		t.Fatalf("GetByID() = %+v, %v, want NULL locked_at/last_error and empty payload", got, err)
+	}
+
+	claimed, err := jobs.Claim(ctx, 10, now, now.Add(-5*time.Minute))
+	if err != nil || len(claimed) != 1 || claimed[0].ID != due.ID {
+		t.Fatalf("Claim() = %+v, %v, want only the due job", claimed, err)
+	}
+
+	if c := claimed[0]; c.Status != models.JobStatusRunning || c.Attempts != 1 || c.LockedAt == nil || !c.LockedAt.Equal(pgtest.Timestamp(now)) {
+		t.Errorf("claimed job = %+v, want running, 1 attempt, locked at %v", c, now)
+	}
+
+	if again, err := jobs.Claim(ctx, 10, now, now.Add(-5*time.Minute)); err != nil || len(again) != 0 {
+		t.Errorf("Claim() again = %+v, %v, want nothing while locked", again, err)
+	}
+
+	if err := jobs.Reschedule(ctx, due.ID, now.Add(-time.Second), "smtp timeout"); err != nil {
+		t.Fatalf("Reschedule() error = %v", err)
+	}
+
+	got, err = jobs.GetByID(ctx, due.ID)
+	if err != nil || got.Status != models.JobStatusPending || got.LockedAt != nil || got.LastError != "smtp timeout" {
+		t.Errorf("GetByID() after reschedule = %+v, %v", got, err)
+	}
+
+	if err := jobs.MarkDead(ctx, due.ID, "gave up"); err != nil {
+		t.Fatalf("MarkDead() error = %v", err)
+	}
+
+	if n, err := jobs.CountByStatus(ctx, models.JobStatusDead); err != nil || n != 1 {
+		t.Errorf("CountByStatus(dead) = %d, %v, want 1", n, err)
+	}
+
+	if err := jobs.RetryDead(ctx, later.ID, now); !appErr.IsKind(err, appErr.ErrNotFound) {
+		t.Errorf("RetryDead() on a pending job error = %v, want not found", err)
+	}
+
+	if err := jobs.RetryDead(ctx, due.ID, now); err != nil {
+		t.Fatalf("RetryDead() error = %v", err)
+	}
+
+	got, err = jobs.GetByID(ctx, due.ID)
+	if err != nil || got.Status != models.JobStatusPending || got.Attempts != 0 {
+		t.Errorf("GetByID() after retry = %+v, %v, want pending with 0 attempts", got, err)
+	}
+
+	if err := jobs.MarkSucceeded(ctx, due.ID); err != nil {
+		t.Fatalf("MarkSucceeded() error = %v", err)
+	}
+
+	list, err := jobs.ListByStatus(ctx, models.JobStatusPending, 10, 0)
+	if err != nil || len(list) != 1 || list[0].ID != later.ID {
+		t.Errorf("ListByStatus(pending) = %+v, %v, want only the later job", list, err)
+	}
+
+	if _, err := jobs.GetByID(ctx, models.NewID()); !appErr.IsKind(err, appErr.ErrNotFound) {
+		t.Errorf("GetByID() unknown error = %v, want not found", err)
+	}
+}
+
+func TestJobRepository_ClaimStale(t *testing.T) {
+	ctx := context.Background()
+	jobs := NewJobRepository(postgres.NewQueries(pgtest.Pool(t)))
+
+	now := time.Now()
+	job := models.NewJob("email.deliver", nil, 3, time.Now())
+	job.RunAt = now.Add(-time.Minute)
+	if err := jobs.Enqueue(ctx, job); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	if _, err := jobs.Claim(ctx, 1, now, now.Add(-5*time.Minute)); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+
+	// worker pertama mati, lock-nya dianggap basi setelah staleBefore lewat
+	later := now.Add(10 * time.Minute)
+	claimed, err := jobs.Claim(ctx, 1, later, later.Add(-5*time.Minute))
+	if err != nil || len(claimed) != 1 || claimed[0].Attempts != 2 {
+		t.Errorf("Claim() stale = %+v, %v, want the job again with 2 attempts", claimed, err)
+	}
+}
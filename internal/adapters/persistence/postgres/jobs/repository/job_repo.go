@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/jobs/mapper"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type JobRepository struct {
+	q *sqlc.Queries
+}
+
+func NewJobRepository(q *sqlc.Queries) *JobRepository {
+	return &JobRepository{q: q}
+}
+
+func (r *JobRepository) Enqueue(ctx context.Context, job *models.Job) error {
+	if job.Payload == nil {
+		job.Payload = []byte("{}")
+	}
+
+	if err := r.db(ctx).CreateJob(ctx, mapper.DomainJobToSQLCParams(job)); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to enqueue job", err)
+	}
+
+	return nil
+}
+
+func (r *JobRepository) Claim(ctx context.Context, limit int, now, staleBefore time.Time) ([]*models.Job, error) {
+	rows, err := r.db(ctx).ClaimJobs(ctx, sqlc.ClaimJobsParams{
+		Now:         pgtype.Timestamp{Time: now, Valid: true},
+		StaleBefore: pgtype.Timestamp{Time: staleBefore, Valid: true},
+		BatchSize:   int32(limit),
+	})
+	if err != nil {
+		return nil, appErr.Wrap(appErr.ErrInternal, "failed to claim jobs", err)
+	}
+
+	jobs := make([]*models.Job, len(rows))
+	for i, row := range rows {
+		jobs[i] = mapper.SQLJobToDomain(row)
+	}
+
+	return jobs, nil
+}
+
+func (r *JobRepository) MarkSucceeded(ctx context.Context, id models.ID) error {
+	if err := r.db(ctx).CompleteJob(ctx, id.String()); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to complete job", err)
+	}
+
+	return nil
+}
+
+func (r *JobRepository) Reschedule(ctx context.Context, id models.ID, runAt time.Time, lastError string) error {
+	if err := r.db(ctx).RescheduleJob(ctx, sqlc.RescheduleJobParams{
+		ID:        id.String(),
+		RunAt:     pgtype.Timestamp{Time: runAt, Valid: true},
+		LastError: pgtype.Text{String: lastError, Valid: lastError != ""},
+	}); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to reschedule job", err)
+	}
+
+	return nil
+}
+
+func (r *JobRepository) MarkDead(ctx context.Context, id models.ID, lastError string) error {
+	if err := r.db(ctx).MarkJobDead(ctx, sqlc.MarkJobDeadParams{
+		ID:        id.String(),
+		LastError: pgtype.Text{String: lastError, Valid: lastError != ""},
+	}); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to mark job dead", err)
+	}
+
+	return nil
+}
+
+func (r *JobRepository) RetryDead(ctx context.Context, id models.ID, runAt time.Time) error {
+	affected, err := r.db(ctx).RetryDeadJob(ctx, sqlc.RetryDeadJobParams{
+		ID:    id.String(),
+		RunAt: pgtype.Timestamp{Time: runAt, Valid: true},
+	})
+	if err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to retry job", err)
+	}
+
+	if affected == 0 {
+		return appErr.New(appErr.ErrNotFound, "dead job not found")
+	}
+
+	return nil
+}
+
+func (r *JobRepository) GetByID(ctx context.Context, id models.ID) (*models.Job, error) {
+	row, err := r.db(ctx).GetJobByID(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appErr.New(appErr.ErrNotFound, "job not found")
+		}
+
+		return nil, appErr.Wrap(appErr.ErrInternal, "failed to get job", err)
+	}
+
+	return mapper.SQLJobToDomain(row), nil
+}
+
+func (r *JobRepository) ListByStatus(ctx context.Context, status models.JobStatus, limit, offset int) ([]*models.Job, error) {
+	rows, err := r.db(ctx).ListJobsByStatus(ctx, sqlc.ListJobsByStatusParams{
+		Status: string(status),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, appErr.Wrap(appErr.ErrInternal, "failed to list jobs", err)
+	}
+
+	jobs := make([]*models.Job, len(rows))
+	for i, row := range rows {
+		jobs[i] = mapper.SQLJobToDomain(row)
+	}
+
+	return jobs, nil
+}
+
+func (r *JobRepository) CountByStatus(ctx context.Context, status models.JobStatus) (int64, error) {
+	total, err := r.db(ctx).CountJobsByStatus(ctx, string(status))
+	if err != nil {
+		return 0, appErr.Wrap(appErr.ErrInternal, "failed to count jobs", err)
+	}
+
+	return total, nil
+}
+
+// db memakai transaksi dari ctx kalau ada, selain itu pakai pool
+func (r *JobRepository) db(ctx context.Context) *sqlc.Queries {
+	return postgres.QueriesFrom(ctx, r.q)
+}
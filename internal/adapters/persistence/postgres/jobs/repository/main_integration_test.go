@@ -0,0 +1,14 @@
+//go:build integration
+
+package repository
+
+import (
+	"os"
+	"testing"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/pgtest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
@@ -0,0 +1,283 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockID kunci advisory supaya dua proses (mis. dua replica saat deploy) tidak migrate bersamaan
+const lockID int64 = 7_301_840_219
+
+// nilVersion versi "belum ada migration" di schema_migrations, sama dengan golang-migrate
+const nilVersion int64 = -1
+
+const pgUndefinedTable = "42P01"
+
+// ErrDirty migration terakhir gagal di tengah jalan, schema harus dibereskan manual lalu di-force
+var ErrDirty = errors.New("database schema is dirty")
+
+// Status posisi schema dibanding migration yang di-embed. Version 0 berarti belum ada migration.
+type Status struct {
+	Version uint
+	Dirty   bool
+	Latest  uint
+	Pending []Migration
+}
+
+// Err nil kalau schema bersih dan tidak ada migration tertunda
+func (s Status) Err() error {
+	if s.Dirty {
+		return fmt.Errorf("%w at version %d", ErrDirty, s.Version)
+	}
+
+	if len(s.Pending) > 0 {
+		names := make([]string, len(s.Pending))
+		for i, mig := range s.Pending {
+			names[i] = mig.String()
+		}
+
+		return fmt.Errorf("schema version %d is behind %d, pending: %s", s.Version, s.Latest, strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+// Migrator menjalankan migration memakai tabel schema_migrations yang formatnya sama dengan
+// golang-migrate, jadi database yang dulu di-migrate lewat binary migrate tetap terbaca.
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+	logger     *slog.Logger
+}
+
+func New(pool *pgxpool.Pool, fsys fs.FS, logger *slog.Logger) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{pool: pool, migrations: migrations, logger: logger}, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	version, dirty, err := readVersion(ctx, m.pool)
+	if err != nil {
+		return Status{}, err
+	}
+
+	status := Status{Dirty: dirty}
+	if version > 0 {
+		status.Version = uint(version)
+	}
+
+	for _, mig := range m.migrations {
+		status.Latest = max(status.Latest, mig.Version)
+		if mig.Version > status.Version {
+			status.Pending = append(status.Pending, mig)
+		}
+	}
+
+	return status, nil
+}
+
+// Up menjalankan semua migration yang belum diterapkan dan mengembalikan daftar yang berhasil
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		version, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if int64(mig.Version) <= version {
+				continue
+			}
+
+			if err := m.run(ctx, conn, mig, "up", int64(mig.Version), mig.Up); err != nil {
+				return err
+			}
+
+			applied = append(applied, mig)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down me-rollback sejumlah steps migration terakhir
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		version, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		idx := m.indexOf(version)
+		if version != nilVersion && idx < 0 {
+			return fmt.Errorf("schema version %d is not one of the embedded migrations", version)
+		}
+
+		for ; steps > 0 && idx >= 0; steps, idx = steps-1, idx-1 {
+			mig := m.migrations[idx]
+			if mig.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", mig.Version, mig.Name)
+			}
+
+			target := nilVersion
+			if idx > 0 {
+				target = int64(m.migrations[idx-1].Version)
+			}
+
+			if err := m.run(ctx, conn, mig, "down", target, mig.Down); err != nil {
+				return err
+			}
+
+			reverted = append(reverted, mig)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Force menandai schema berada di version tanpa menjalankan SQL apa pun (version -1 berarti kosong).
+// Dipakai setelah schema yang dirty dibereskan manual.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if version < nilVersion {
+		return fmt.Errorf("invalid version %d", version)
+	}
+
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		return setVersion(ctx, conn, version, false)
+	})
+}
+
+// run menandai target sebagai dirty sebelum SQL dijalankan, jadi kalau proses mati di tengah
+// jalan status dirty tertinggal dan Up berikutnya menolak jalan
+func (m *Migrator) run(ctx context.Context, conn *pgxpool.Conn, mig Migration, direction string, target int64, sql string) error {
+	start := time.Now()
+
+	if err := setVersion(ctx, conn, target, true); err != nil {
+		return err
+	}
+
+	// tanpa argumen pgx memakai simple protocol, jadi satu file boleh berisi banyak statement
+	if _, err := conn.Exec(ctx, sql); err != nil {
+		return fmt.Errorf("migration %d_%s %s failed: %w", mig.Version, mig.Name, direction, err)
+	}
+
+	if err := setVersion(ctx, conn, target, false); err != nil {
+		return err
+	}
+
+	m.logger.Info("migration applied", "version", mig.Version, "name", mig.Name, "direction", direction, "duration", time.Since(start).String())
+	return nil
+}
+
+func (m *Migrator) cleanVersion(ctx context.Context, conn *pgxpool.Conn) (int64, error) {
+	version, dirty, err := readVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	if dirty {
+		return 0, fmt.Errorf("%w at version %d, fix it manually then run migrate force", ErrDirty, version)
+	}
+
+	return version, nil
+}
+
+func (m *Migrator) indexOf(version int64) int {
+	for i, mig := range m.migrations {
+		if int64(mig.Version) == version {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// withLock menjalankan fn di satu koneksi yang memegang advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		_, _ = conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", lockID)
+	}()
+
+	if _, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// readVersion tabel yang belum ada dibaca sebagai nilVersion supaya status tidak perlu membuat tabel
+func readVersion(ctx context.Context, q querier) (int64, bool, error) {
+	var version int64
+	var dirty bool
+
+	err := q.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nilVersion, false, nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUndefinedTable {
+		return nilVersion, false, nil
+	}
+
+	if err != nil {
+		return 0, false, fmt.Errorf("read schema_migrations: %w", err)
+	}
+
+	return version, dirty, nil
+}
+
+// setVersion tabel hanya berisi satu baris. nilVersion yang bersih disimpan sebagai tabel kosong.
+func setVersion(ctx context.Context, conn *pgxpool.Conn, version int64, dirty bool) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	if _, err := tx.Exec(ctx, "TRUNCATE schema_migrations"); err != nil {
+		return fmt.Errorf("reset schema_migrations: %w", err)
+	}
+
+	if version != nilVersion || dirty {
+		if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)", version, dirty); err != nil {
+			return fmt.Errorf("set schema version: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,73 @@
+//go:build integration
+
+package migrate_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+
+	migrations "villainrsty-ecommerce-server/db"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/migrate"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/pgtest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+// TestMigrator_DownAndUp menjalankan semua down migration lalu up lagi, memastikan setiap
+// pasangan file bisa dibalik
+func TestMigrator_DownAndUp(t *testing.T) {
+	ctx := context.Background()
+	m, err := migrate.New(pgtest.Pool(t), migrations.Files(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	status, err := m.Status(ctx)
+	if err != nil || status.Version != status.Latest || status.Dirty || len(status.Pending) != 0 {
+		t.Fatalf("Status() = %+v, %v, want fully migrated", status, err)
+	}
+
+	reverted, err := m.Down(ctx, int(status.Latest))
+	if err != nil || len(reverted) != int(status.Latest) {
+		t.Fatalf("Down() = %d migrations, %v, want %d", len(reverted), err, status.Latest)
+	}
+
+	if status, err := m.Status(ctx); err != nil || status.Version != 0 || len(status.Pending) != int(status.Latest) {
+		t.Fatalf("Status() after down = %+v, %v, want empty schema", status, err)
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil || len(applied) != int(status.Latest) {
+		t.Fatalf("Up() = %d migrations, %v, want %d", len(applied), err, status.Latest)
+	}
+}
+
+func TestMigrator_Force(t *testing.T) {
+	ctx := context.Background()
+	m, err := migrate.New(pgtest.Pool(t), migrations.Files(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	status, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	if err := m.Force(ctx, int64(status.Latest)-1); err != nil {
+		t.Fatalf("Force() error = %v", err)
+	}
+
+	if got, err := m.Status(ctx); err != nil || len(got.Pending) != 1 {
+		t.Errorf("Status() after force = %+v, %v, want 1 pending", got, err)
+	}
+
+	if err := m.Force(ctx, int64(status.Latest)); err != nil {
+		t.Fatalf("Force() error = %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	migrations "villainrsty-ecommerce-server/db"
+)
+
+func TestLoad_SortsAndPairsFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_add_locale.up.sql":   {Data: []byte("ALTER TABLE users ADD COLUMN locale text;")},
+		"000002_add_locale.down.sql": {Data: []byte("ALTER TABLE users DROP COLUMN locale;")},
+		"000001_create_users.up.sql": {Data: []byte("CREATE TABLE users (id text);")},
+		"README.md":                  {Data: []byte("bukan migration")},
+	}
+
+	got, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(got) != 2 || got[0].Version != 1 || got[1].Version != 2 {
+		t.Fatalf("versions = %+v, want [1 2]", got)
+	}
+
+	if got[0].Down != "" || got[1].Down == "" || got[1].Name != "add_locale" {
+		t.Errorf("migrations = %+v", got)
+	}
+}
+
+func TestLoad_RejectsBrokenSets(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"duplicate version": {
+			"000001_a.up.sql": {Data: []byte("SELECT 1;")},
+			"000001_b.up.sql": {Data: []byte("SELECT 1;")},
+		},
+		"down without up": {
+			"000001_a.down.sql": {Data: []byte("SELECT 1;")},
+		},
+	}
+
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Load(fsys); err == nil {
+				t.Fatal("Load() error = nil, want error")
+			}
+		})
+	}
+}
+
+// Migration yang di-embed ke binary harus berurutan dan bisa di-rollback satu per satu
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	got, err := Load(migrations.Files())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(got) == 0 {
+		t.Fatal("no embedded migrations")
+	}
+
+	for i, mig := range got {
+		if mig.Version != uint(i+1) {
+			t.Errorf("migration %d_%s out of sequence, want version %d", mig.Version, mig.Name, i+1)
+		}
+
+		if mig.Down == "" {
+			t.Errorf("migration %d_%s has no down file", mig.Version, mig.Name)
+		}
+	}
+}
+
+func TestStatus_Err(t *testing.T) {
+	pending := []Migration{{Version: 2, Name: "add_locale"}, {Version: 3, Name: "add_role"}}
+	tests := []struct {
+		name   string
+		status Status
+		want   string
+	}{
+		{name: "up to date", status: Status{Version: 3, Latest: 3}},
+		{name: "dirty", status: Status{Version: 3, Dirty: true, Latest: 3}, want: "database schema is dirty at version 3"},
+		{name: "behind", status: Status{Version: 1, Latest: 3, Pending: pending}, want: "schema version 1 is behind 3, pending: 000002_add_locale, 000003_add_role"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.status.Err()
+			if got := fmt.Sprint(err); (err == nil) != (tt.want == "") || (err != nil && got != tt.want) {
+				t.Errorf("Err() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	if err := (Status{Dirty: true}).Err(); !errors.Is(err, ErrDirty) {
+		t.Errorf("Err() = %v, want ErrDirty", err)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/pgtest"
+	"villainrsty-ecommerce-server/internal/core/ratelimit/models"
+)
+
+func TestBucketRepository_Take(t *testing.T) {
+	ctx := context.Background()
+	pool := pgtest.Pool(t)
+	buckets := NewBucketRepository(postgres.NewQueries(pool), postgres.NewTxManager(pool, 3))
+
+	// sengaja waktu lokal, seperti yang dikirim middleware rate limit
+	now := time.Now()
+	limit := models.Limit{Requests: 2, Per: time.Minute}
+
+	for i := range 2 {
+		res, err := buckets.Take(ctx, "ip:127.0.0.1", limit, now)
+		if err != nil || !res.Allowed {
+			t.Fatalf("Take() #%d = %+v, %v, want allowed", i+1, res, err)
+		}
+	}
+
+	res, err := buckets.Take(ctx, "ip:127.0.0.1", limit, now)
+	if err != nil || res.Allowed || res.RetryAfter <= 0 {
+		t.Errorf("Take() over limit = %+v, %v, want denied with Retry-After", res, err)
+	}
+
+	if res, err := buckets.Take(ctx, "ip:10.0.0.1", limit, now); err != nil || !res.Allowed {
+		t.Errorf("Take() other key = %+v, %v, want allowed", res, err)
+	}
+
+	// setengah menit kemudian satu token sudah terisi lagi
+	if res, err := buckets.Take(ctx, "ip:127.0.0.1", limit, now.Add(30*time.Second)); err != nil || !res.Allowed {
+		t.Errorf("Take() after refill = %+v, %v, want allowed", res, err)
+	}
+}
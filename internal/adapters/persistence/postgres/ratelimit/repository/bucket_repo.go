@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+	"villainrsty-ecommerce-server/internal/core/ratelimit/models"
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const bucketPurgeInterval = time.Minute
+
+// BucketRepository menyimpan token bucket di Postgres supaya limit berlaku sama di semua replika
+type BucketRepository struct {
+	q         *sqlc.Queries
+	txManager sharedPorts.TxManager
+	lastPurge atomic.Int64
+}
+
+func NewBucketRepository(q *sqlc.Queries, txManager sharedPorts.TxManager) *BucketRepository {
+	return &BucketRepository{q: q, txManager: txManager}
+}
+
+// Take mengunci baris bucket (FOR UPDATE) supaya request paralel untuk key yang sama antre
+func (r *BucketRepository) Take(ctx context.Context, key string, limit models.Limit, now time.Time) (models.Result, error) {
+	// kolom TIMESTAMP tanpa zona dibaca kembali sebagai UTC, simpan juga dalam UTC supaya
+	// selisih waktu refill benar di server dengan zona waktu lain
+	now = now.UTC()
+
+	var result models.Result
+
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		fresh := models.NewBucket(limit, now)
+		if err := r.db(ctx).EnsureRateLimitBucket(ctx, sqlc.EnsureRateLimitBucketParams{
+			Key:       key,
+			Tokens:    fresh.Tokens,
+			UpdatedAt: pgtype.Timestamp{Time: now, Valid: true},
+			ExpiresAt: pgtype.Timestamp{Time: now, Valid: true},
+		}); err != nil {
+			return err
+		}
+
+		row, err := r.db(ctx).GetRateLimitBucketForUpdate(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		bucket := models.Bucket{Tokens: row.Tokens, UpdatedAt: row.UpdatedAt.Time}
+		if !now.Before(row.ExpiresAt.Time) {
+			bucket = fresh
+		}
+
+		var next models.Bucket
+		next, result = bucket.Take(limit, now)
+
+		return r.db(ctx).UpdateRateLimitBucket(ctx, sqlc.UpdateRateLimitBucketParams{
+			Key:       key,
+			Tokens:    next.Tokens,
+			UpdatedAt: pgtype.Timestamp{Time: next.UpdatedAt, Valid: true},
+			ExpiresAt: pgtype.Timestamp{Time: next.ExpiresAt(limit), Valid: true},
+		})
+	})
+	if err != nil {
+		return models.Result{}, appErr.Wrap(appErr.ErrInternal, "failed to take rate limit token", err)
+	}
+
+	r.purgeExpired(ctx, now)
+
+	return result, nil
+}
+
+// purgeExpired menghapus bucket yang sudah penuh kembali, paling sering sekali per bucketPurgeInterval
+func (r *BucketRepository) purgeExpired(ctx context.Context, now time.Time) {
+	last := r.lastPurge.Load()
+	if now.UnixNano()-last < int64(bucketPurgeInterval) {
+		return
+	}
+
+	if !r.lastPurge.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+
+	_, _ = r.db(ctx).DeleteExpiredRateLimitBuckets(ctx, pgtype.Timestamp{Time: now, Valid: true})
+}
+
+// db memakai transaksi dari ctx kalau ada, selain itu pakai pool
+func (r *BucketRepository) db(ctx context.Context) *sqlc.Queries {
+	return postgres.QueriesFrom(ctx, r.q)
+}
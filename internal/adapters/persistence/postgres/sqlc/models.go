@@ -8,6 +8,66 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type EmailMessage struct {
+	ID                string           `json:"id"`
+	Template          string           `json:"template"`
+	ToEmail           string           `json:"to_email"`
+	Locale            string           `json:"locale"`
+	Data              []byte           `json:"data"`
+	Status            string           `json:"status"`
+	Attempts          int32            `json:"attempts"`
+	ProviderMessageID pgtype.Text      `json:"provider_message_id"`
+	LastError         pgtype.Text      `json:"last_error"`
+	SentAt            pgtype.Timestamp `json:"sent_at"`
+	DataPurgedAt      pgtype.Timestamp `json:"data_purged_at"`
+	CreatedAt         pgtype.Timestamp `json:"created_at"`
+	UpdatedAt         pgtype.Timestamp `json:"updated_at"`
+}
+
+type IdempotencyKey struct {
+	Scope           string           `json:"scope"`
+	Key             string           `json:"key"`
+	Method          string           `json:"method"`
+	Path            string           `json:"path"`
+	Fingerprint     string           `json:"fingerprint"`
+	Status          string           `json:"status"`
+	ResponseStatus  pgtype.Int4      `json:"response_status"`
+	ResponseHeaders []byte           `json:"response_headers"`
+	ResponseBody    []byte           `json:"response_body"`
+	LockedAt        pgtype.Timestamp `json:"locked_at"`
+	CompletedAt     pgtype.Timestamp `json:"completed_at"`
+	ExpiresAt       pgtype.Timestamp `json:"expires_at"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+}
+
+type Job struct {
+	ID          string           `json:"id"`
+	Type        string           `json:"type"`
+	Payload     []byte           `json:"payload"`
+	Status      string           `json:"status"`
+	Attempts    int32            `json:"attempts"`
+	MaxAttempts int32            `json:"max_attempts"`
+	RunAt       pgtype.Timestamp `json:"run_at"`
+	LockedAt    pgtype.Timestamp `json:"locked_at"`
+	LastError   pgtype.Text      `json:"last_error"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+}
+
+type OutboxEvent struct {
+	ID            string           `json:"id"`
+	AggregateType string           `json:"aggregate_type"`
+	AggregateID   string           `json:"aggregate_id"`
+	EventType     string           `json:"event_type"`
+	Payload       []byte           `json:"payload"`
+	OccurredAt    pgtype.Timestamp `json:"occurred_at"`
+	PublishedAt   pgtype.Timestamp `json:"published_at"`
+	Attempts      int32            `json:"attempts"`
+	LastError     pgtype.Text      `json:"last_error"`
+	AvailableAt   pgtype.Timestamp `json:"available_at"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+}
+
 type PasswordResetToken struct {
 	ID        string           `json:"id"`
 	UserID    string           `json:"user_id"`
@@ -17,6 +77,13 @@ type PasswordResetToken struct {
 	CreatedAt pgtype.Timestamp `json:"created_at"`
 }
 
+type RateLimitBucket struct {
+	Key       string           `json:"key"`
+	Tokens    float64          `json:"tokens"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+}
+
 type RefreshToken struct {
 	ID        string           `json:"id"`
 	UserID    string           `json:"user_id"`
@@ -44,4 +111,6 @@ type User struct {
 	Password  string           `json:"password"`
 	CreatedAt pgtype.Timestamp `json:"created_at"`
 	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+	Locale    string           `json:"locale"`
+	Role      string           `json:"role"`
 }
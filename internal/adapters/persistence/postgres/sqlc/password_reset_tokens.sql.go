@@ -37,14 +37,17 @@ func (q *Queries) CreatePasswordResetToken(ctx context.Context, arg CreatePasswo
 	return err
 }
 
-const deleteExpirePasswordResetToken = `-- name: DeleteExpirePasswordResetToken :exec
+const deleteExpirePasswordResetToken = `-- name: DeleteExpirePasswordResetToken :execrows
 DELETE FROM password_reset_tokens
-WHERE expires_at < NOW() OR used_at IS NOT NULL
+WHERE expires_at < $1 OR used_at IS NOT NULL
 `
 
-func (q *Queries) DeleteExpirePasswordResetToken(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, deleteExpirePasswordResetToken)
-	return err
+func (q *Queries) DeleteExpirePasswordResetToken(ctx context.Context, expiresAt pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpirePasswordResetToken, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getPasswordResetTokenByHash = `-- name: GetPasswordResetTokenByHash :one
@@ -70,11 +73,16 @@ func (q *Queries) GetPasswordResetTokenByHash(ctx context.Context, tokenHash str
 
 const markPasswordResetTokenUsed = `-- name: MarkPasswordResetTokenUsed :exec
 UPDATE password_reset_tokens
-SET used_at = NOW()
+SET used_at = $2
 WHERE id = $1
 `
 
-func (q *Queries) MarkPasswordResetTokenUsed(ctx context.Context, id string) error {
-	_, err := q.db.Exec(ctx, markPasswordResetTokenUsed, id)
+type MarkPasswordResetTokenUsedParams struct {
+	ID     string           `json:"id"`
+	UsedAt pgtype.Timestamp `json:"used_at"`
+}
+
+func (q *Queries) MarkPasswordResetTokenUsed(ctx context.Context, arg MarkPasswordResetTokenUsedParams) error {
+	_, err := q.db.Exec(ctx, markPasswordResetTokenUsed, arg.ID, arg.UsedAt)
 	return err
 }
@@ -39,14 +39,17 @@ func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshToken
 	return err
 }
 
-const deleteExpiredRefreshTokens = `-- name: DeleteExpiredRefreshTokens :exec
+const deleteExpiredRefreshTokens = `-- name: DeleteExpiredRefreshTokens :execrows
 DELETE FROM refresh_tokens
 WHERE expires_at < $1
 `
 
-func (q *Queries) DeleteExpiredRefreshTokens(ctx context.Context, expiresAt pgtype.Timestamp) error {
-	_, err := q.db.Exec(ctx, deleteExpiredRefreshTokens, expiresAt)
-	return err
+func (q *Queries) DeleteExpiredRefreshTokens(ctx context.Context, expiresAt pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredRefreshTokens, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getRefreshTokenByHash = `-- name: GetRefreshTokenByHash :one
@@ -73,12 +76,17 @@ func (q *Queries) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (
 const getRefreshTokensByUserID = `-- name: GetRefreshTokensByUserID :many
 SELECT id, user_id, token_hash, expires_at, revoked_at, created_at, updated_at
 FROM refresh_tokens
-WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetRefreshTokensByUserID(ctx context.Context, userID string) ([]RefreshToken, error) {
-	rows, err := q.db.Query(ctx, getRefreshTokensByUserID, userID)
+type GetRefreshTokensByUserIDParams struct {
+	UserID    string           `json:"user_id"`
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+}
+
+func (q *Queries) GetRefreshTokensByUserID(ctx context.Context, arg GetRefreshTokensByUserIDParams) ([]RefreshToken, error) {
+	rows, err := q.db.Query(ctx, getRefreshTokensByUserID, arg.UserID, arg.ExpiresAt)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +115,7 @@ func (q *Queries) GetRefreshTokensByUserID(ctx context.Context, userID string) (
 
 const revokeRefreshToken = `-- name: RevokeRefreshToken :exec
 UPDATE refresh_tokens
-SET revoked_at = $2, updated_at = NOW()
+SET revoked_at = $2, updated_at = $2
 WHERE id = $1
 `
 
@@ -39,14 +39,17 @@ func (q *Queries) CreateTwoFactorOTP(ctx context.Context, arg CreateTwoFactorOTP
 	return err
 }
 
-const deleteExpiresTwoFactorOTP = `-- name: DeleteExpiresTwoFactorOTP :exec
+const deleteExpiresTwoFactorOTP = `-- name: DeleteExpiresTwoFactorOTP :execrows
 DELETE FROM two_factor_otps
-WHERE expires_at < NOW() OR used_at IS NOT NULL
+WHERE expires_at < $1 OR used_at IS NOT NULL
 `
 
-func (q *Queries) DeleteExpiresTwoFactorOTP(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, deleteExpiresTwoFactorOTP)
-	return err
+func (q *Queries) DeleteExpiresTwoFactorOTP(ctx context.Context, expiresAt pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiresTwoFactorOTP, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getTwoFactorOTPByChallengeID = `-- name: GetTwoFactorOTPByChallengeID :one
@@ -73,11 +76,16 @@ func (q *Queries) GetTwoFactorOTPByChallengeID(ctx context.Context, challengeID
 
 const markTwoFactorOTPUsed = `-- name: MarkTwoFactorOTPUsed :exec
 UPDATE two_factor_otps
-SET used_at = NOW()
+SET used_at = $2
 WHERE id = $1
 `
 
-func (q *Queries) MarkTwoFactorOTPUsed(ctx context.Context, id string) error {
-	_, err := q.db.Exec(ctx, markTwoFactorOTPUsed, id)
+type MarkTwoFactorOTPUsedParams struct {
+	ID     string           `json:"id"`
+	UsedAt pgtype.Timestamp `json:"used_at"`
+}
+
+func (q *Queries) MarkTwoFactorOTPUsed(ctx context.Context, arg MarkTwoFactorOTPUsedParams) error {
+	_, err := q.db.Exec(ctx, markTwoFactorOTPUsed, arg.ID, arg.UsedAt)
 	return err
 }
@@ -12,8 +12,8 @@ import (
 )
 
 const createUser = `-- name: CreateUser :exec
-INSERT INTO users (id, email, password, name, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO users (id, email, password, name, locale, role, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type CreateUserParams struct {
@@ -21,6 +21,8 @@ type CreateUserParams struct {
 	Email     string           `json:"email"`
 	Password  string           `json:"password"`
 	Name      string           `json:"name"`
+	Locale    string           `json:"locale"`
+	Role      string           `json:"role"`
 	CreatedAt pgtype.Timestamp `json:"created_at"`
 	UpdatedAt pgtype.Timestamp `json:"updated_at"`
 }
@@ -31,6 +33,8 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
 		arg.Email,
 		arg.Password,
 		arg.Name,
+		arg.Locale,
+		arg.Role,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -48,7 +52,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id string) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password, name, created_at, updated_at
+SELECT id, email, password, name, locale, role, created_at, updated_at
 FROM users
 WHERE email = $1
 `
@@ -58,6 +62,8 @@ type GetUserByEmailRow struct {
 	Email     string           `json:"email"`
 	Password  string           `json:"password"`
 	Name      string           `json:"name"`
+	Locale    string           `json:"locale"`
+	Role      string           `json:"role"`
 	CreatedAt pgtype.Timestamp `json:"created_at"`
 	UpdatedAt pgtype.Timestamp `json:"updated_at"`
 }
@@ -70,6 +76,8 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 		&i.Email,
 		&i.Password,
 		&i.Name,
+		&i.Locale,
+		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -77,7 +85,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, password, name, created_at, updated_at
+SELECT id, email, password, name, locale, role, created_at, updated_at
 FROM users
 WHERE id = $1
 LIMIT 1
@@ -88,6 +96,8 @@ type GetUserByIDRow struct {
 	Email     string           `json:"email"`
 	Password  string           `json:"password"`
 	Name      string           `json:"name"`
+	Locale    string           `json:"locale"`
+	Role      string           `json:"role"`
 	CreatedAt pgtype.Timestamp `json:"created_at"`
 	UpdatedAt pgtype.Timestamp `json:"updated_at"`
 }
@@ -100,6 +110,8 @@ func (q *Queries) GetUserByID(ctx context.Context, id string) (GetUserByIDRow, e
 		&i.Email,
 		&i.Password,
 		&i.Name,
+		&i.Locale,
+		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -147,6 +159,22 @@ func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPassword
 	return err
 }
 
+const updateUserLocale = `-- name: UpdateUserLocale :exec
+UPDATE users
+SET locale = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateUserLocaleParams struct {
+	ID     string `json:"id"`
+	Locale string `json:"locale"`
+}
+
+func (q *Queries) UpdateUserLocale(ctx context.Context, arg UpdateUserLocaleParams) error {
+	_, err := q.db.Exec(ctx, updateUserLocale, arg.ID, arg.Locale)
+	return err
+}
+
 const userExists = `-- name: UserExists :one
 SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)
 `
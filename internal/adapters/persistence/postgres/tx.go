@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+type txKey struct{}
+
+type TxManager struct {
+	pool       *pgxpool.Pool
+	maxRetries int
+}
+
+func NewTxManager(pool *pgxpool.Pool, maxRetries int) *TxManager {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	return &TxManager{pool: pool, maxRetries: maxRetries}
+}
+
+// WithinTx membuka transaksi baru, kecuali ctx sudah membawa transaksi (nested call ikut transaksi luar).
+// Serialization failure dan deadlock di-retry sampai maxRetries kali.
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error, opts ...ports.TxOption) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	txOpts := toPgxTxOptions(ports.ApplyTxOptions(opts...))
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = m.runTx(ctx, txOpts, fn)
+		if err == nil || !IsRetryable(err) || attempt >= m.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(retryDelay(attempt)):
+		}
+	}
+}
+
+func (m *TxManager) runTx(ctx context.Context, txOpts pgx.TxOptions, fn func(ctx context.Context) error) error {
+	tx, err := m.pool.BeginTx(ctx, txOpts)
+	if err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to begin transaction", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(context.WithoutCancel(ctx))
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return appErr.Wrap(appErr.ErrInternal, "failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// IsRetryable cek apakah error berasal dari konflik transaksi yang aman untuk diulang
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+	}
+
+	return false
+}
+
+func retryDelay(attempt int) time.Duration {
+	base := time.Duration(attempt+1) * 10 * time.Millisecond
+	return base + time.Duration(rand.Int64N(int64(base)))
+}
+
+func toPgxTxOptions(o ports.TxOptions) pgx.TxOptions {
+	switch o.Isolation {
+	case ports.IsolationSerializable:
+		return pgx.TxOptions{IsoLevel: pgx.Serializable}
+	default:
+		return pgx.TxOptions{}
+	}
+}
+
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// QueriesFrom mengembalikan sqlc.Queries yang terikat ke transaksi di ctx kalau ada
+func QueriesFrom(ctx context.Context, q *sqlc.Queries) *sqlc.Queries {
+	if tx, ok := TxFromContext(ctx); ok {
+		return q.WithTx(tx)
+	}
+
+	return q
+}
@@ -0,0 +1,109 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"testing/fstest"
+
+	migrations "villainrsty-ecommerce-server/db"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/migrate"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/pgtest"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/sqlc"
+)
+
+func createUser(ctx context.Context, q *sqlc.Queries, email string) error {
+	return QueriesFrom(ctx, q).CreateUser(ctx, sqlc.CreateUserParams{
+		ID:       email,
+		Email:    email,
+		Password: "hash",
+		Name:     "Budi",
+		Locale:   "id",
+		Role:     "user",
+	})
+}
+
+func userExists(t *testing.T, q *sqlc.Queries, email string) bool {
+	t.Helper()
+
+	exists, err := q.UserExists(context.Background(), email)
+	if err != nil {
+		t.Fatalf("UserExists() error = %v", err)
+	}
+
+	return exists
+}
+
+func TestTxManager_CommitAndRollback(t *testing.T) {
+	ctx := context.Background()
+	pool := pgtest.Pool(t)
+	q := NewQueries(pool)
+	txManager := NewTxManager(pool, 3)
+
+	if err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return createUser(ctx, q, "committed@mail.com")
+	}); err != nil {
+		t.Fatalf("WithinTx() error = %v", err)
+	}
+
+	boom := errors.New("boom")
+	err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := createUser(ctx, q, "outer@mail.com"); err != nil {
+			return err
+		}
+
+		// nested ikut transaksi luar, jadi ikut di-rollback
+		if err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			return createUser(ctx, q, "nested@mail.com")
+		}); err != nil {
+			return err
+		}
+
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("WithinTx() error = %v, want boom", err)
+	}
+
+	if !userExists(t, q, "committed@mail.com") {
+		t.Error("committed user is missing")
+	}
+
+	if userExists(t, q, "outer@mail.com") || userExists(t, q, "nested@mail.com") {
+		t.Error("rolled back users are still visible")
+	}
+}
+
+func TestHealthChecks(t *testing.T) {
+	ctx := context.Background()
+	pool := pgtest.Pool(t)
+
+	if err := PingCheck(pool).Check(ctx); err != nil {
+		t.Errorf("PingCheck() error = %v", err)
+	}
+
+	current, err := migrate.New(pool, migrations.Files(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("migrate.New() error = %v", err)
+	}
+
+	if err := MigrationCheck(current).Check(ctx); err != nil {
+		t.Errorf("MigrationCheck() error = %v", err)
+	}
+
+	// binary yang membawa migration lebih baru dari schema
+	future, err := migrate.New(pool, fstest.MapFS{
+		"999999_future.up.sql": {Data: []byte("SELECT 1;")},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("migrate.New() error = %v", err)
+	}
+
+	if err := MigrationCheck(future).Check(ctx); err == nil {
+		t.Error("MigrationCheck() error = nil, want schema behind")
+	}
+}
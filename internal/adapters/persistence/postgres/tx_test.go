@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	appErr "villainrsty-ecommerce-server/internal/core/shared/errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, true},
+		{"wrapped in AppError", appErr.Wrap(appErr.ErrInternal, "failed to save", &pgconn.PgError{Code: "40001"}), true},
+		{"wrapped with fmt", fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40P01"}), true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"plain error", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
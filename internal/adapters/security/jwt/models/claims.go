@@ -6,5 +6,7 @@ type Claims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
 	Name   string `json:"name"`
+	Locale string `json:"locale,omitempty"`
+	Role   string `json:"role,omitempty"`
 	jwt.RegisteredClaims
 }
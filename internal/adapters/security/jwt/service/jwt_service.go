@@ -6,6 +6,8 @@ import (
 	claims "villainrsty-ecommerce-server/internal/adapters/security/jwt/models"
 	"villainrsty-ecommerce-server/internal/core/shared/errors"
 	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+	"villainrsty-ecommerce-server/pkg/i18n"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -14,25 +16,37 @@ type JWTService struct {
 	secretKey          string
 	accessTokenExpiry  time.Duration
 	refreshTokenExpiry time.Duration
+	clock              sharedPorts.Clock
 }
 
-func NewJWTService(secretKey string) *JWTService {
+// NewJWTService clock dipakai untuk exp/iat dan validasi exp, nil berarti jam sistem
+func NewJWTService(secretKey string, clock sharedPorts.Clock) *JWTService {
+	if clock == nil {
+		clock = sharedPorts.SystemClock
+	}
+
 	return &JWTService{
 		secretKey:          secretKey,
 		accessTokenExpiry:  15 * time.Minute,
 		refreshTokenExpiry: 7 * 24 * time.Hour,
+		clock:              clock,
 	}
 }
 
+// GenerateAccessToken setiap token punya jti sendiri, jadi dua token di detik yang sama tetap berbeda
 func (s *JWTService) GenerateAccessToken(user *models.User) (string, error) {
+	now := s.clock.Now()
 	claims := &claims.Claims{
 		UserID: user.ID.String(),
 		Email:  user.Email,
 		Name:   user.Name,
+		Locale: string(user.Locale),
+		Role:   string(user.Role),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.accessTokenExpiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Issuer:    "ecommerce-api",
+			ID:        models.NewID().String(),
 		},
 	}
 
@@ -46,14 +60,18 @@ func (s *JWTService) GenerateAccessToken(user *models.User) (string, error) {
 }
 
 func (s *JWTService) GenerateRefreshToken(user *models.User) (string, error) {
+	now := s.clock.Now()
 	claims := &claims.Claims{
 		UserID: user.ID.String(),
 		Email:  user.Email,
 		Name:   user.Name,
+		Locale: string(user.Locale),
+		Role:   string(user.Role),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.refreshTokenExpiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.refreshTokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Issuer:    "ecommerce-api",
+			ID:        models.NewID().String(),
 		},
 	}
 
@@ -69,24 +87,26 @@ func (s *JWTService) GenerateRefreshToken(user *models.User) (string, error) {
 func (s *JWTService) ValidateToken(tokenString string) (*models.User, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &claims.Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New(errors.ErrUnauthorized, "invalid signin method")
+			return nil, errors.NewCode(errors.CodeInvalidToken, "invalid signin method")
 		}
 
 		return []byte(s.secretKey), nil
-	})
+	}, jwt.WithTimeFunc(s.clock.Now))
 	if err != nil {
-		return nil, errors.Wrap(errors.ErrUnauthorized, "failed to parse token", err)
+		return nil, errors.WrapCode(errors.CodeInvalidToken, "failed to parse token", err)
 	}
 
 	claims, ok := token.Claims.(*claims.Claims)
 	if !ok || !token.Valid {
-		return nil, errors.New(errors.ErrUnauthorized, "invalid token")
+		return nil, errors.NewCode(errors.CodeInvalidToken, "invalid token")
 	}
 
 	user := &models.User{
-		ID:    models.ID(claims.UserID),
-		Email: claims.Email,
-		Name:  claims.Name,
+		ID:     models.ID(claims.UserID),
+		Email:  claims.Email,
+		Name:   claims.Name,
+		Locale: i18n.Locale(claims.Locale),
+		Role:   models.Role(claims.Role),
 	}
 
 	return user, nil
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/clock"
+)
+
+func TestJWTService_ExpiryFollowsClock(t *testing.T) {
+	// jauh di masa lalu supaya jelas exp tidak dihitung dari jam sistem
+	clk := clock.NewFake(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	svc := NewJWTService("rahasia", clk)
+	user := &models.User{ID: models.NewID(), Email: "budi@mail.com", Name: "Budi", Role: models.RoleUser}
+
+	token, err := svc.GenerateAccessToken(user)
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+
+	got, err := svc.ValidateToken(token)
+	if err != nil || got.ID != user.ID || got.Email != user.Email {
+		t.Fatalf("ValidateToken() = %+v, %v, want the user back", got, err)
+	}
+
+	if again, _ := svc.GenerateAccessToken(user); again == token {
+		t.Error("tokens issued at the same instant are identical, want a unique jti")
+	}
+
+	clk.Advance(16 * time.Minute)
+	if _, err := svc.ValidateToken(token); !errors.IsKind(err, errors.ErrUnauthorized) {
+		t.Errorf("ValidateToken() after expiry error = %v, want unauthorized", err)
+	}
+
+	// token tahun 2020 sudah kedaluwarsa menurut jam sekarang
+	if _, err := NewJWTService("rahasia", clock.NewFake(time.Now())).ValidateToken(token); err == nil {
+		t.Error("token issued in 2020 validated against the system time")
+	}
+}
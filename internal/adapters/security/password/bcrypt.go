@@ -1,29 +1,53 @@
 package password
 
 import (
+	"context"
+
 	"villainrsty-ecommerce-server/internal/core/shared/errors"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type BcryptHasher struct {
-	cost int
+	cost   int
+	tracer trace.Tracer
 }
 
 func NewBcryptHasher() *BcryptHasher {
-	return &BcryptHasher{cost: bcrypt.DefaultCost}
+	return NewBcryptHasherWithCost(bcrypt.DefaultCost)
+}
+
+// NewBcryptHasherWithCost cost di bawah default hanya untuk test supaya hashing cepat
+func NewBcryptHasherWithCost(cost int) *BcryptHasher {
+	return &BcryptHasher{
+		cost:   cost,
+		tracer: otel.Tracer("villainrsty-ecommerce-server/internal/adapters/security/password"),
+	}
 }
 
-func (h *BcryptHasher) Hash(password string) (string, error) {
+func (h *BcryptHasher) Hash(ctx context.Context, password string) (string, error) {
+	_, span := h.tracer.Start(ctx, "bcrypt.hash", trace.WithAttributes(attribute.Int("bcrypt.cost", h.cost)))
+	defer span.End()
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return "", errors.Wrap(errors.ErrInternal, "failed to hash password", err)
 	}
 
 	return string(hashedPassword), nil
 }
 
-func (h *BcryptHasher) Verify(hashedPassword, plainPassword string) bool {
+func (h *BcryptHasher) Verify(ctx context.Context, hashedPassword, plainPassword string) bool {
+	_, span := h.tracer.Start(ctx, "bcrypt.verify")
+	defer span.End()
+
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword))
+	span.SetAttributes(attribute.Bool("bcrypt.match", err == nil))
+
 	return err == nil
 }
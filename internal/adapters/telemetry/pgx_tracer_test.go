@@ -0,0 +1,24 @@
+package telemetry
+
+import "testing"
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{"sqlc one", "-- name: GetUserByEmail :one\nSELECT * FROM users WHERE email = $1\n", "GetUserByEmail"},
+		{"sqlc exec with indent", "\n  -- name: CreateUser :exec\nINSERT INTO users VALUES ($1)", "CreateUser"},
+		{"plain query", "SELECT 1", ""},
+		{"empty name", "-- name:\nSELECT 1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := queryName(tt.sql); got != tt.want {
+				t.Errorf("queryName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,58 +1,299 @@
 package app
 
 import (
+	"context"
 	"log/slog"
+	"net/http"
+	"time"
 
+	migrations "villainrsty-ecommerce-server/db"
 	"villainrsty-ecommerce-server/internal/adapters/http/auth/handler"
+	authRoutes "villainrsty-ecommerce-server/internal/adapters/http/auth/routes"
+	emailHandler "villainrsty-ecommerce-server/internal/adapters/http/emails/handler"
+	healthHandler "villainrsty-ecommerce-server/internal/adapters/http/health/handler"
+	jobHandler "villainrsty-ecommerce-server/internal/adapters/http/jobs/handler"
+	jobRoutes "villainrsty-ecommerce-server/internal/adapters/http/jobs/routes"
+	"villainrsty-ecommerce-server/internal/adapters/http/middleware"
+	"villainrsty-ecommerce-server/internal/adapters/metrics"
+	"villainrsty-ecommerce-server/internal/adapters/notifications/logmail"
+	"villainrsty-ecommerce-server/internal/adapters/notifications/queue"
 	"villainrsty-ecommerce-server/internal/adapters/notifications/smtp"
+	"villainrsty-ecommerce-server/internal/adapters/notifications/templates"
+	"villainrsty-ecommerce-server/internal/adapters/notifications/webhook"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/memory"
 	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres"
 	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/auth/repository"
+	emailRepository "villainrsty-ecommerce-server/internal/adapters/persistence/postgres/emails/repository"
+	eventRepository "villainrsty-ecommerce-server/internal/adapters/persistence/postgres/events/repository"
+	idempotencyRepository "villainrsty-ecommerce-server/internal/adapters/persistence/postgres/idempotency/repository"
+	jobRepository "villainrsty-ecommerce-server/internal/adapters/persistence/postgres/jobs/repository"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/postgres/migrate"
+	rateLimitRepository "villainrsty-ecommerce-server/internal/adapters/persistence/postgres/ratelimit/repository"
 	tokenHasher "villainrsty-ecommerce-server/internal/adapters/security/hasher"
 	jwtService "villainrsty-ecommerce-server/internal/adapters/security/jwt/service"
 	"villainrsty-ecommerce-server/internal/adapters/security/password"
 	"villainrsty-ecommerce-server/internal/config"
+	"villainrsty-ecommerce-server/internal/core/auth/ports"
 	"villainrsty-ecommerce-server/internal/core/auth/service"
+	emailPorts "villainrsty-ecommerce-server/internal/core/emails/ports"
+	emailService "villainrsty-ecommerce-server/internal/core/emails/service"
+	eventModels "villainrsty-ecommerce-server/internal/core/events/models"
+	eventPorts "villainrsty-ecommerce-server/internal/core/events/ports"
+	eventService "villainrsty-ecommerce-server/internal/core/events/service"
+	healthPorts "villainrsty-ecommerce-server/internal/core/health/ports"
+	healthService "villainrsty-ecommerce-server/internal/core/health/service"
+	idempotencyPorts "villainrsty-ecommerce-server/internal/core/idempotency/ports"
+	jobPorts "villainrsty-ecommerce-server/internal/core/jobs/ports"
+	jobService "villainrsty-ecommerce-server/internal/core/jobs/service"
+	rateLimitPorts "villainrsty-ecommerce-server/internal/core/ratelimit/ports"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+	"villainrsty-ecommerce-server/internal/seed"
 
+	"github.com/go-chi/cors"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Container struct {
-	AuthHandler *handler.AuthHandler
+	AuthHandler  *handler.AuthHandler
+	JobHandler   *jobHandler.JobHandler
+	EmailHandler *emailHandler.EmailHandler
+	JobWorker    *jobService.Worker
+	Dispatcher   *eventService.Dispatcher
+	JWTService   ports.JWTService
+	AuthService  ports.AuthService
+	EmailService emailPorts.EmailService
+	Seeder       *seed.Seeder
+	AdminEmails  []string
+	RateLimiter  *middleware.RateLimiter
+	Idempotency  func(http.Handler) http.Handler
+	CORS         func(http.Handler) http.Handler
+	Security     func(http.Handler) http.Handler
+	DocsCSP      func(http.Handler) http.Handler
+	// ValidateOpenAPI pasang validator request/response terhadap dokumen OpenAPI (lihat config.ValidateOpenAPI)
+	ValidateOpenAPI bool
+	TrustProxy      bool
+	Logger          *slog.Logger
+	Metrics         *metrics.Metrics
+
+	Health        *healthService.HealthChecker
+	HealthHandler *healthHandler.HealthHandler
+}
+
+// Ports adapter outbound yang dipakai container. New mengisinya dengan Postgres, test
+// end-to-end memakai implementasi in-memory.
+type Ports struct {
+	Users          ports.UserRepository
+	RefreshTokens  ports.RefreshTokenRepository
+	PasswordResets ports.PasswordResetTokenRepository
+	TwoFactorOTPs  ports.TwoFactorOTPRepository
+	Jobs           jobPorts.JobRepository
+	Outbox         eventPorts.OutboxRepository
+	EmailMessages  emailPorts.EmailMessageRepository
+	Idempotency    idempotencyPorts.IdempotencyStore
+	TxManager      sharedPorts.TxManager
+	// RateLimit nil berarti store in-memory per proses
+	RateLimit rateLimitPorts.BucketStore
+	// Hasher nil berarti bcrypt dengan cost default
+	Hasher ports.PasswordHasher
+	// EmailSender kalau diisi dipakai auth service menggantikan email service (tanpa job queue)
+	EmailSender ports.EmailSender
+	// Clock nil berarti jam sistem
+	Clock sharedPorts.Clock
 }
 
 func New(cfg config.Config, db *pgxpool.Pool, logger *slog.Logger) *Container {
 	queries := postgres.NewQueries(db)
-	userRepo := repository.NewUserRepository(queries)
-	refreshTokenRepo := repository.NewRefreshTokenRepository(queries)
-	passwordResetRepo := repository.NewPasswordResetTokenRepository(queries)
-	twoFactorOTPRepo := repository.NewTwoFactorOTPRepository(queries)
-	hasher := password.NewBcryptHasher()
+	txManager := postgres.NewTxManager(db, cfg.Database.TxMaxRetries)
+	clock := sharedPorts.SystemClock
+
+	var rateLimitStore rateLimitPorts.BucketStore
+	if cfg.RateLimit.Store == "postgres" {
+		rateLimitStore = rateLimitRepository.NewBucketRepository(queries, txManager)
+	}
+
+	c := NewWithPorts(cfg, Ports{
+		Users:          repository.NewUserRepository(queries),
+		RefreshTokens:  repository.NewRefreshTokenRepository(queries, clock),
+		PasswordResets: repository.NewPasswordResetTokenRepository(queries, clock),
+		TwoFactorOTPs:  repository.NewTwoFactorOTPRepository(queries, clock),
+		Jobs:           jobRepository.NewJobRepository(queries),
+		Outbox:         eventRepository.NewOutboxRepository(queries),
+		EmailMessages:  emailRepository.NewEmailMessageRepository(queries),
+		Idempotency:    idempotencyRepository.NewIdempotencyRepository(queries),
+		TxManager:      txManager,
+		RateLimit:      rateLimitStore,
+		Clock:          clock,
+	}, logger)
+
+	c.Metrics.Register(metrics.NewPoolCollector(db))
+	c.Health.Register("postgres", postgres.PingCheck(db), healthService.CheckOptions{Timeout: cfg.Health.CheckTimeout, Critical: true})
+	c.Health.Register("migrations", migrationCheck(db, logger), healthService.CheckOptions{Timeout: cfg.Health.CheckTimeout, Critical: true})
+
+	return c
+}
+
+// NewWithPorts merakit service, handler dan middleware di atas adapter outbound p.
+// Health check dan metrics khusus database didaftarkan oleh New.
+func NewWithPorts(cfg config.Config, p Ports, logger *slog.Logger) *Container {
+	appMetrics := metrics.New()
+	appMetrics.Register(metrics.NewJobQueueCollector(p.Jobs, logger))
+
+	clock := p.Clock
+	if clock == nil {
+		clock = sharedPorts.SystemClock
+	}
+
+	hasher := p.Hasher
+	if hasher == nil {
+		hasher = password.NewBcryptHasher()
+	}
 	tokenHasher := tokenHasher.NewSHA256TokenHasher()
-	jwtService := jwtService.NewJWTService(cfg.CookieSecret)
-	emailSender := smtp.NewEmailSender(
-		cfg.SMTPHost,
-		cfg.SMTPPort,
-		cfg.SMTPUsername,
-		cfg.SMTPPassword,
-		cfg.SMTPFromEmail,
-		cfg.SMTPFromName)
+	jwtService := jwtService.NewJWTService(cfg.Auth.Secret, clock)
+	emailTemplates := templates.NewRenderer()
+	var mailTransport emailPorts.EmailTransport = logmail.NewEmailSender(logger)
+	var smtpSender *smtp.EmailSender
+	switch cfg.Mail.Driver {
+	case config.MailDriverSMTP:
+		smtpSender = smtp.NewEmailSender(
+			cfg.Mail.SMTP.Host,
+			cfg.Mail.SMTP.Port,
+			cfg.Mail.SMTP.Username,
+			cfg.Mail.SMTP.Password,
+			cfg.Mail.SMTP.FromEmail,
+			cfg.Mail.SMTP.FromName,
+			emailTemplates,
+			logger)
+		mailTransport = smtpSender
+	case config.MailDriverMailbox:
+		mailTransport = smtp.NewMailbox(cfg.Mail.MailboxDir, cfg.Mail.SMTP.FromEmail, cfg.Mail.SMTP.FromName, emailTemplates, logger)
+	}
+
+	jobSvc := jobService.NewJobService(p.Jobs, cfg.Jobs.MaxAttempts, clock)
+	worker := jobService.NewWorker(p.Jobs, jobService.WorkerOptions{
+		Concurrency:  cfg.Jobs.WorkerConcurrency,
+		PollInterval: cfg.Jobs.PollInterval,
+		BackoffBase:  cfg.Jobs.BackoffBase,
+		Clock:        clock,
+	}, logger)
+	emailSvc := emailService.NewEmailService(
+		p.EmailMessages,
+		jobSvc,
+		p.TxManager,
+		clock,
+		metrics.InstrumentEmailTransport(mailTransport, appMetrics),
+		logger,
+		// link reset dan kode OTP tidak berguna lagi setelah kedaluwarsa
+		max(cfg.Auth.ResetPasswordTTL, cfg.Auth.TwoFactorOTPTTL),
+	)
+	queue.RegisterEmailHandlers(worker, emailSvc)
+	webhook.RegisterWebhookHandlers(worker, webhook.NewSender(cfg.Webhook.Secret, cfg.Webhook.Timeout, clock))
+
+	var emailSender ports.EmailSender = emailSvc
+	if p.EmailSender != nil {
+		emailSender = p.EmailSender
+	}
+
+	bus := eventService.NewBus()
+	bus.Subscribe(eventModels.PasswordChanged, service.NewRevokeSessionsHandler(p.RefreshTokens))
+	if len(cfg.Webhook.URLs) > 0 {
+		bus.Subscribe(eventService.AllEvents, webhook.NewRelay(jobSvc, cfg.Webhook.URLs).Handle)
+	}
+	dispatcher := eventService.NewDispatcher(p.Outbox, p.TxManager, bus, eventService.DispatcherOptions{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		Clock:        clock,
+	}, logger)
+
 	authService := service.NewAuthService(
-		userRepo,
-		refreshTokenRepo,
-		passwordResetRepo,
-		twoFactorOTPRepo,
+		p.Users,
+		p.RefreshTokens,
+		p.PasswordResets,
+		p.TwoFactorOTPs,
 		emailSender,
 		hasher,
 		tokenHasher,
 		jwtService,
+		p.TxManager,
+		clock,
+		eventService.NewOutboxPublisher(p.Outbox),
+		appMetrics,
 		logger,
-		cfg.ResetPasswordURL,
-		cfg.ResetPasswordTTL,
-		cfg.TwoFactorOTPTTL,
+		cfg.Auth.ResetPasswordURL,
+		cfg.Auth.ResetPasswordTTL,
+		cfg.Auth.TwoFactorOTPTTL,
 	)
+	rateLimitStore := p.RateLimit
+	if rateLimitStore == nil {
+		rateLimitStore = memory.NewRateLimitStore()
+	}
+	rateLimiter := middleware.NewRateLimiter(rateLimitStore, map[string]middleware.RateLimitPolicy{
+		authRoutes.RateLimitAuth:     {Limit: cfg.RateLimit.Auth, Key: middleware.KeyByIP},
+		authRoutes.RateLimitRegister: {Limit: cfg.RateLimit.Register, Key: middleware.KeyByIP},
+		authRoutes.RateLimitEmail:    {Limit: cfg.RateLimit.Email, Key: middleware.KeyByJSONField("email")},
+		authRoutes.RateLimitOTP:      {Limit: cfg.RateLimit.OTP, Key: middleware.KeyByJSONField("challenge_id")},
+		jobRoutes.RateLimitAdmin:     {Limit: cfg.RateLimit.Admin, Key: middleware.KeyByUser},
+	}, clock, logger)
+
+	idempotency := middleware.Idempotency(p.Idempotency, middleware.IdempotencyOptions{
+		TTL:         cfg.Idempotency.TTL,
+		LockTimeout: cfg.Idempotency.LockTimeout,
+		Clock:       clock,
+	}, logger)
+
+	health := healthService.NewHealthChecker(cfg.Health.CacheTTL, clock)
+	if smtpSender != nil {
+		// email dikirim lewat job queue, SMTP down tidak menghalangi request lain
+		health.Register("smtp", smtpSender, healthService.CheckOptions{Timeout: cfg.Health.CheckTimeout})
+	}
+
 	authHandler := handler.NewAuthHandler(authService, logger)
+	jobHandler := jobHandler.NewJobHandler(jobSvc, logger)
 
 	return &Container{
-		AuthHandler: authHandler,
+		AuthHandler:  authHandler,
+		JobHandler:   jobHandler,
+		EmailHandler: emailHandler.NewEmailHandler(emailSvc, emailTemplates, logger),
+		JobWorker:    worker,
+		Dispatcher:   dispatcher,
+		JWTService:   jwtService,
+		AuthService:  authService,
+		EmailService: emailSvc,
+		Seeder:       seed.NewSeeder(p.Users, hasher, p.TxManager, clock, logger),
+		AdminEmails:  cfg.Auth.AdminEmails,
+		RateLimiter:  rateLimiter,
+		Idempotency:  idempotency,
+		CORS: cors.Handler(cors.Options{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedMethods:   cfg.CORS.AllowedMethods,
+			AllowedHeaders:   cfg.CORS.AllowedHeaders,
+			ExposedHeaders:   cfg.CORS.ExposedHeaders,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           int(cfg.CORS.MaxAge / time.Second),
+		}),
+		Security: middleware.SecurityHeaders(middleware.SecurityHeadersOptions{
+			HSTSMaxAge:            cfg.Security.HSTSMaxAge,
+			HSTSIncludeSubdomains: cfg.Security.HSTSIncludeSubdomains,
+			FrameOptions:          cfg.Security.FrameOptions,
+			ReferrerPolicy:        cfg.Security.ReferrerPolicy,
+			ContentSecurityPolicy: cfg.Security.ContentSecurityPolicy,
+			TrustProxy:            cfg.App.TrustProxy,
+		}),
+		DocsCSP:         middleware.ContentSecurityPolicy(cfg.Security.DocsContentSecurityPolicy),
+		ValidateOpenAPI: cfg.ValidateOpenAPI(),
+		Logger:          logger,
+		TrustProxy:      cfg.App.TrustProxy,
+		Metrics:         appMetrics,
+
+		Health:        health,
+		HealthHandler: healthHandler.NewHealthHandler(health, clock),
 	}
 }
+
+func migrationCheck(db *pgxpool.Pool, logger *slog.Logger) healthPorts.HealthCheck {
+	migrator, err := migrate.New(db, migrations.Files(), logger)
+	if err != nil {
+		return healthPorts.CheckFunc(func(context.Context) error { return err })
+	}
+
+	return postgres.MigrationCheck(migrator)
+}
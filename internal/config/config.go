@@ -1,113 +1,256 @@
 package config
 
 import (
-	"log"
-	"os"
-	"strconv"
+	"log/slog"
 	"time"
+
+	rateLimitModels "villainrsty-ecommerce-server/internal/core/ratelimit/models"
 )
 
-type Config struct {
-	Addr         string
-	DatabaseUrl  string
-	CookieSecret string
+const (
+	MailDriverSMTP = "smtp"
+	// MailDriverLog menulis email ke log, untuk development tanpa server SMTP
+	MailDriverLog = "log"
+	// MailDriverMailbox menulis email lengkap (.eml) ke folder lokal, untuk development
+	MailDriverMailbox = "mailbox"
+)
 
-	AccessTTL  time.Duration
-	RefreshTTL time.Duration
+type (
+	Config struct {
+		App         AppConfig
+		Database    DatabaseConfig
+		Auth        AuthConfig
+		Cookie      CookieConfig
+		Mail        MailConfig
+		Jobs        JobsConfig
+		Outbox      OutboxConfig
+		Webhook     WebhookConfig
+		RateLimit   RateLimitConfig
+		Idempotency IdempotencyConfig
+		Health      HealthConfig
+		Tracing     TracingConfig
+		CORS        CORSConfig
+		Security    SecurityConfig
+		OpenAPI     OpenAPIConfig
+	}
 
-	CookieDomain string
-	CookieSecure bool
+	AppConfig struct {
+		Env                string
+		Addr               string
+		LogLevel           slog.Level
+		ShutdownDrainDelay time.Duration
+		// TrustProxy membaca X-Forwarded-For/X-Real-IP dan X-Forwarded-Proto dari reverse proxy.
+		// Berlaku untuk semua yang memakai IP klien (rate limit, idempotency, log) dan HSTS.
+		TrustProxy bool
+		// MetricsAddr listener terpisah untuk /metrics, kosong berarti metrics tidak dilayani lewat HTTP
+		MetricsAddr string
+	}
 
-	SMTPHost      string
-	SMTPPort      string
-	SMTPUsername  string
-	SMTPPassword  string
-	SMTPFromEmail string
-	SMTPFromName  string
+	DatabaseConfig struct {
+		URL          string
+		TxMaxRetries int
+	}
 
-	ResetPasswordTTL time.Duration
-	ResetPasswordURL string
-	TwoFactorOTPTTL  time.Duration
-}
+	AuthConfig struct {
+		Secret           string
+		AccessTTL        time.Duration
+		RefreshTTL       time.Duration
+		ResetPasswordTTL time.Duration
+		ResetPasswordURL string
+		TwoFactorOTPTTL  time.Duration
+		AdminEmails      []string
+	}
 
-func MustLoad() Config {
-	addr := getEnv("APP_ADDR", ":8080")
-	dbURL := mustEnv("DATABASE_URL")
-	secret := mustEnv("COOKIE_SECRET")
-	accessTTL := mustDuration("ACCESS_TTL", 15*time.Minute)
-	refreshTTL := mustDuration("REFRESH_TTL", 7*24*time.Hour)
-	smtpHost := mustEnv("SMTP_HOST")
-	smtpPort := mustEnv("SMTP_PORT")
-	smtpUsername := mustEnv("SMTP_USERNAME")
-	smtpPassword := mustEnv("SMTP_PASSWORD")
-	smtpFromEmail := mustEnv("SMTP_FROM_EMAIL")
-	smtpFromName := mustEnv("SMTP_FROM_NAME")
-	resetPasswordTTL := mustDuration("RESET_PASSWORD_TTL", 30*time.Minute)
-	resetPasswordURL := mustEnv("FRONTEND_RESET_PASSWORD_URL")
-	twoFactorOTPTTL := mustDuration("TWO_FACTOR_OTP_TTL", 5*time.Minute)
+	CookieConfig struct {
+		Domain string
+		Secure bool
+	}
 
-	return Config{
-		Addr:             addr,
-		DatabaseUrl:      dbURL,
-		CookieSecret:     secret,
-		AccessTTL:        accessTTL,
-		RefreshTTL:       refreshTTL,
-		CookieDomain:     getEnv("COOKIEE_DOMAIN", "localhost"),
-		CookieSecure:     getEnv("COOKIE_SECURE", "false") == "true",
-		SMTPHost:         smtpHost,
-		SMTPPort:         smtpPort,
-		SMTPUsername:     smtpUsername,
-		SMTPPassword:     smtpPassword,
-		SMTPFromEmail:    smtpFromEmail,
-		SMTPFromName:     smtpFromName,
-		ResetPasswordTTL: resetPasswordTTL,
-		ResetPasswordURL: resetPasswordURL,
-		TwoFactorOTPTTL:  twoFactorOTPTTL,
+	MailConfig struct {
+		// Driver smtp, log atau mailbox; setting SMTP hanya wajib kalau driver smtp
+		Driver     string
+		MailboxDir string
+		SMTP       SMTPConfig
 	}
-}
 
-func getEnv(k, def string) string {
-	v := os.Getenv(k)
-	if v == "" {
-		return def
+	SMTPConfig struct {
+		Host      string
+		Port      string
+		Username  string
+		Password  string
+		FromEmail string
+		FromName  string
 	}
 
-	return v
-}
+	JobsConfig struct {
+		WorkerConcurrency int
+		PollInterval      time.Duration
+		MaxAttempts       int
+		BackoffBase       time.Duration
+	}
 
-func mustEnv(k string) string {
-	v := os.Getenv(k)
-	if v == "" {
-		log.Fatalf("missing env %s", k)
+	OutboxConfig struct {
+		PollInterval time.Duration
+		BatchSize    int
 	}
 
-	return v
-}
+	WebhookConfig struct {
+		URLs    []string
+		Secret  string
+		Timeout time.Duration
+	}
 
-func mustDuration(k string, def time.Duration) time.Duration {
-	v := os.Getenv(k)
-	if v == "" {
-		return def
+	RateLimitConfig struct {
+		// Store memory atau postgres
+		Store    string
+		Auth     rateLimitModels.Limit
+		Register rateLimitModels.Limit
+		Email    rateLimitModels.Limit
+		OTP      rateLimitModels.Limit
+		Admin    rateLimitModels.Limit
 	}
 
-	d, err := time.ParseDuration(v)
-	if err != nil {
-		log.Fatalf("invalid duration %s=%q: %v", k, v, err)
+	IdempotencyConfig struct {
+		TTL         time.Duration
+		LockTimeout time.Duration
 	}
 
-	return d
-}
+	HealthConfig struct {
+		CacheTTL     time.Duration
+		CheckTimeout time.Duration
+	}
 
-func mustBool(k string, def bool) bool {
-	v := os.Getenv(k)
-	if v == "" {
-		return def
+	CORSConfig struct {
+		// AllowedOrigins mendukung wildcard subdomain, misal "https://*.villainrsty.com"
+		AllowedOrigins   []string
+		AllowedMethods   []string
+		AllowedHeaders   []string
+		ExposedHeaders   []string
+		AllowCredentials bool
+		MaxAge           time.Duration
 	}
 
-	b, err := strconv.ParseBool(v)
-	if err != nil {
-		log.Fatalf("invalid bool %s=%q: %v", k, v, err)
+	SecurityConfig struct {
+		// HSTSMaxAge 0 mematikan header Strict-Transport-Security
+		HSTSMaxAge            time.Duration
+		HSTSIncludeSubdomains bool
+		FrameOptions          string
+		ReferrerPolicy        string
+		// ContentSecurityPolicy untuk response API, DocsContentSecurityPolicy untuk Swagger UI di /docs
+		ContentSecurityPolicy     string
+		DocsContentSecurityPolicy string
 	}
 
-	return b
+	OpenAPIConfig struct {
+		// Validation auto (aktif hanya di development/test), on atau off
+		Validation string
+	}
+
+	TracingConfig struct {
+		ServiceName string
+		Exporter    string
+		File        string
+		SampleRatio float64
+	}
+)
+
+// Defaults lapisan paling bawah, ditimpa file YAML, env, lalu flag
+func Defaults() Config {
+	return Config{
+		App: AppConfig{
+			Env:                "development",
+			Addr:               ":8080",
+			LogLevel:           slog.LevelInfo,
+			ShutdownDrainDelay: 5 * time.Second,
+		},
+		Database: DatabaseConfig{
+			TxMaxRetries: 3,
+		},
+		Auth: AuthConfig{
+			AccessTTL:        15 * time.Minute,
+			RefreshTTL:       7 * 24 * time.Hour,
+			ResetPasswordTTL: 30 * time.Minute,
+			TwoFactorOTPTTL:  5 * time.Minute,
+		},
+		Cookie: CookieConfig{
+			Domain: "localhost",
+		},
+		Mail: MailConfig{
+			Driver:     MailDriverSMTP,
+			MailboxDir: "./tmp/mailbox",
+		},
+		Jobs: JobsConfig{
+			WorkerConcurrency: 4,
+			PollInterval:      2 * time.Second,
+			MaxAttempts:       5,
+			BackoffBase:       10 * time.Second,
+		},
+		Outbox: OutboxConfig{
+			PollInterval: time.Second,
+			BatchSize:    50,
+		},
+		Webhook: WebhookConfig{
+			Timeout: 10 * time.Second,
+		},
+		RateLimit: RateLimitConfig{
+			Store:    "memory",
+			Auth:     rateLimitModels.Limit{Requests: 30, Per: time.Minute},
+			Register: rateLimitModels.Limit{Requests: 5, Per: time.Hour},
+			Email:    rateLimitModels.Limit{Requests: 5, Per: 15 * time.Minute},
+			OTP:      rateLimitModels.Limit{Requests: 5, Per: 15 * time.Minute},
+			Admin:    rateLimitModels.Limit{Requests: 120, Per: time.Minute},
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:         24 * time.Hour,
+			LockTimeout: time.Minute,
+		},
+		Health: HealthConfig{
+			CacheTTL:     2 * time.Second,
+			CheckTimeout: 2 * time.Second,
+		},
+		Tracing: TracingConfig{
+			ServiceName: "villainrsty-ecommerce-server",
+			Exporter:    "none",
+			File:        "traces.jsonl",
+			SampleRatio: 1,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"http://localhost:5500", "http://127.0.0.1:5500"},
+			AllowedMethods: []string{"GET", "POST", "PATCH", "DELETE", "PUT", "OPTIONS"},
+			AllowedHeaders: []string{"Content-Type", "Authorization", "Idempotency-Key", "X-Request-ID", "traceparent", "tracestate"},
+			ExposedHeaders: []string{
+				"X-Request-ID", "Idempotent-Replayed",
+				"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After",
+				"Deprecation", "Sunset", "Link",
+			},
+			AllowCredentials: true,
+			MaxAge:           5 * time.Minute,
+		},
+		Security: SecurityConfig{
+			HSTSMaxAge:            180 * 24 * time.Hour,
+			HSTSIncludeSubdomains: true,
+			FrameOptions:          "DENY",
+			ReferrerPolicy:        "strict-origin-when-cross-origin",
+			ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+			// Swagger UI memakai inline script/style dan gambar data: URI
+			DocsContentSecurityPolicy: "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; " +
+				"img-src 'self' data:; connect-src 'self'; object-src 'none'; base-uri 'self'; frame-ancestors 'none'",
+		},
+		OpenAPI: OpenAPIConfig{
+			Validation: "auto",
+		},
+	}
+}
+
+// ValidateOpenAPI apakah request/response divalidasi terhadap dokumen OpenAPI. Default-nya hanya
+// di development/test karena setiap response disalin dan di-parse ulang.
+func (c Config) ValidateOpenAPI() bool {
+	switch c.OpenAPI.Validation {
+	case "on":
+		return true
+	case "auto":
+		return c.App.Env == "development" || c.App.Env == "test"
+	default:
+		return false
+	}
 }
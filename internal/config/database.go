@@ -5,10 +5,12 @@ import (
 	"log"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func ConnectDB(databaseURL string) *pgxpool.Pool {
+// ConnectDB membuka pool Postgres. tracer opsional, dipakai untuk span per query.
+func ConnectDB(databaseURL string, tracer pgx.QueryTracer) *pgxpool.Pool {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -23,6 +25,10 @@ func ConnectDB(databaseURL string) *pgxpool.Pool {
 	config.MaxConnLifetime = 1 * time.Minute
 	config.MaxConnIdleTime = 30 * time.Minute
 
+	if tracer != nil {
+		config.ConnConfig.Tracer = tracer
+	}
+
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		log.Fatalf("failed to connect database: %v", err)
@@ -0,0 +1,295 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	rateLimitModels "villainrsty-ecommerce-server/internal/core/ratelimit/models"
+)
+
+const redacted = "********"
+
+// field menghubungkan satu nilai config dengan key YAML/flag ("mail.smtp.host") dan nama env.
+// Semua lapisan (file, env, flag) masuk sebagai string lewat set supaya parsing & pesan error seragam.
+type field struct {
+	key    string
+	env    string
+	secret bool
+	// redact opsional, dipakai untuk nilai yang hanya sebagian rahasia (misal password di DSN)
+	redact func(string) string
+	set    func(string) error
+	get    func() string
+}
+
+func (f field) printable() string {
+	v := f.get()
+	switch {
+	case v == "":
+		return ""
+	case f.redact != nil:
+		return f.redact(v)
+	case f.secret:
+		return redacted
+	default:
+		return v
+	}
+}
+
+// fields daftar semua setting. Urutan di sini juga urutan output `config print`.
+func (c *Config) fields() []field {
+	return []field{
+		stringField("app.env", "APP_ENV", &c.App.Env),
+		stringField("app.addr", "APP_ADDR", &c.App.Addr),
+		stringField("app.metrics_addr", "METRICS_ADDR", &c.App.MetricsAddr),
+		boolField("app.trust_proxy", "TRUST_PROXY", &c.App.TrustProxy),
+		logLevelField("app.log_level", "LOG_LEVEL", &c.App.LogLevel),
+		durationField("app.shutdown_drain_delay", "SHUTDOWN_DRAIN_DELAY", &c.App.ShutdownDrainDelay),
+
+		withRedact(stringField("database.url", "DATABASE_URL", &c.Database.URL), redactURL),
+		intField("database.tx_max_retries", "DB_TX_MAX_RETRIES", &c.Database.TxMaxRetries),
+
+		secret(stringField("auth.secret", "COOKIE_SECRET", &c.Auth.Secret)),
+		durationField("auth.access_ttl", "ACCESS_TTL", &c.Auth.AccessTTL),
+		durationField("auth.refresh_ttl", "REFRESH_TTL", &c.Auth.RefreshTTL),
+		durationField("auth.reset_password_ttl", "RESET_PASSWORD_TTL", &c.Auth.ResetPasswordTTL),
+		stringField("auth.reset_password_url", "FRONTEND_RESET_PASSWORD_URL", &c.Auth.ResetPasswordURL),
+		durationField("auth.two_factor_otp_ttl", "TWO_FACTOR_OTP_TTL", &c.Auth.TwoFactorOTPTTL),
+		listField("auth.admin_emails", "ADMIN_EMAILS", &c.Auth.AdminEmails),
+
+		stringField("cookie.domain", "COOKIE_DOMAIN", &c.Cookie.Domain),
+		boolField("cookie.secure", "COOKIE_SECURE", &c.Cookie.Secure),
+
+		stringField("mail.driver", "MAIL_DRIVER", &c.Mail.Driver),
+		stringField("mail.mailbox_dir", "MAIL_MAILBOX_DIR", &c.Mail.MailboxDir),
+		stringField("mail.smtp.host", "SMTP_HOST", &c.Mail.SMTP.Host),
+		stringField("mail.smtp.port", "SMTP_PORT", &c.Mail.SMTP.Port),
+		stringField("mail.smtp.username", "SMTP_USERNAME", &c.Mail.SMTP.Username),
+		secret(stringField("mail.smtp.password", "SMTP_PASSWORD", &c.Mail.SMTP.Password)),
+		stringField("mail.smtp.from_email", "SMTP_FROM_EMAIL", &c.Mail.SMTP.FromEmail),
+		stringField("mail.smtp.from_name", "SMTP_FROM_NAME", &c.Mail.SMTP.FromName),
+
+		intField("jobs.worker_concurrency", "JOB_WORKER_CONCURRENCY", &c.Jobs.WorkerConcurrency),
+		durationField("jobs.poll_interval", "JOB_POLL_INTERVAL", &c.Jobs.PollInterval),
+		intField("jobs.max_attempts", "JOB_MAX_ATTEMPTS", &c.Jobs.MaxAttempts),
+		durationField("jobs.backoff_base", "JOB_BACKOFF_BASE", &c.Jobs.BackoffBase),
+
+		durationField("outbox.poll_interval", "OUTBOX_POLL_INTERVAL", &c.Outbox.PollInterval),
+		intField("outbox.batch_size", "OUTBOX_BATCH_SIZE", &c.Outbox.BatchSize),
+
+		listField("webhook.urls", "WEBHOOK_URLS", &c.Webhook.URLs),
+		secret(stringField("webhook.secret", "WEBHOOK_SECRET", &c.Webhook.Secret)),
+		durationField("webhook.timeout", "WEBHOOK_TIMEOUT", &c.Webhook.Timeout),
+
+		stringField("rate_limit.store", "RATE_LIMIT_STORE", &c.RateLimit.Store),
+		limitField("rate_limit.auth", "RATE_LIMIT_AUTH", &c.RateLimit.Auth),
+		limitField("rate_limit.register", "RATE_LIMIT_REGISTER", &c.RateLimit.Register),
+		limitField("rate_limit.email", "RATE_LIMIT_EMAIL", &c.RateLimit.Email),
+		limitField("rate_limit.otp", "RATE_LIMIT_OTP", &c.RateLimit.OTP),
+		limitField("rate_limit.admin", "RATE_LIMIT_ADMIN", &c.RateLimit.Admin),
+
+		durationField("idempotency.ttl", "IDEMPOTENCY_TTL", &c.Idempotency.TTL),
+		durationField("idempotency.lock_timeout", "IDEMPOTENCY_LOCK_TIMEOUT", &c.Idempotency.LockTimeout),
+
+		durationField("health.cache_ttl", "HEALTH_CACHE_TTL", &c.Health.CacheTTL),
+		durationField("health.check_timeout", "HEALTH_CHECK_TIMEOUT", &c.Health.CheckTimeout),
+
+		stringField("tracing.service_name", "OTEL_SERVICE_NAME", &c.Tracing.ServiceName),
+		stringField("tracing.exporter", "OTEL_TRACES_EXPORTER", &c.Tracing.Exporter),
+		stringField("tracing.file", "OTEL_TRACES_FILE", &c.Tracing.File),
+		floatField("tracing.sample_ratio", "OTEL_TRACES_SAMPLE_RATIO", &c.Tracing.SampleRatio),
+
+		listField("cors.allowed_origins", "CORS_ALLOWED_ORIGINS", &c.CORS.AllowedOrigins),
+		listField("cors.allowed_methods", "CORS_ALLOWED_METHODS", &c.CORS.AllowedMethods),
+		listField("cors.allowed_headers", "CORS_ALLOWED_HEADERS", &c.CORS.AllowedHeaders),
+		listField("cors.exposed_headers", "CORS_EXPOSED_HEADERS", &c.CORS.ExposedHeaders),
+		boolField("cors.allow_credentials", "CORS_ALLOW_CREDENTIALS", &c.CORS.AllowCredentials),
+		durationField("cors.max_age", "CORS_MAX_AGE", &c.CORS.MaxAge),
+
+		durationField("security.hsts_max_age", "SECURITY_HSTS_MAX_AGE", &c.Security.HSTSMaxAge),
+		boolField("security.hsts_include_subdomains", "SECURITY_HSTS_INCLUDE_SUBDOMAINS", &c.Security.HSTSIncludeSubdomains),
+		stringField("security.frame_options", "SECURITY_FRAME_OPTIONS", &c.Security.FrameOptions),
+		stringField("security.referrer_policy", "SECURITY_REFERRER_POLICY", &c.Security.ReferrerPolicy),
+		stringField("security.content_security_policy", "SECURITY_CSP", &c.Security.ContentSecurityPolicy),
+		stringField("security.docs_content_security_policy", "SECURITY_DOCS_CSP", &c.Security.DocsContentSecurityPolicy),
+
+		stringField("openapi.validation", "OPENAPI_VALIDATION", &c.OpenAPI.Validation),
+	}
+}
+
+func secret(f field) field {
+	f.secret = true
+	return f
+}
+
+func withRedact(f field, redact func(string) string) field {
+	f.redact = redact
+	return f
+}
+
+func stringField(key, env string, p *string) field {
+	return field{
+		key: key,
+		env: env,
+		set: func(v string) error {
+			*p = strings.TrimSpace(v)
+			return nil
+		},
+		get: func() string { return *p },
+	}
+}
+
+func intField(key, env string, p *int) field {
+	return field{
+		key: key,
+		env: env,
+		set: func(v string) error {
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil {
+				return fmt.Errorf("invalid int %q", v)
+			}
+			*p = n
+			return nil
+		},
+		get: func() string { return strconv.Itoa(*p) },
+	}
+}
+
+func floatField(key, env string, p *float64) field {
+	return field{
+		key: key,
+		env: env,
+		set: func(v string) error {
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return fmt.Errorf("invalid float %q", v)
+			}
+			*p = f
+			return nil
+		},
+		get: func() string { return strconv.FormatFloat(*p, 'g', -1, 64) },
+	}
+}
+
+func boolField(key, env string, p *bool) field {
+	return field{
+		key: key,
+		env: env,
+		set: func(v string) error {
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return fmt.Errorf("invalid bool %q", v)
+			}
+			*p = b
+			return nil
+		},
+		get: func() string { return strconv.FormatBool(*p) },
+	}
+}
+
+func durationField(key, env string, p *time.Duration) field {
+	return field{
+		key: key,
+		env: env,
+		set: func(v string) error {
+			d, err := time.ParseDuration(strings.TrimSpace(v))
+			if err != nil {
+				return fmt.Errorf("invalid duration %q", v)
+			}
+			*p = d
+			return nil
+		},
+		get: func() string { return p.String() },
+	}
+}
+
+// listField membaca daftar dipisah koma, item kosong dibuang
+func listField(key, env string, p *[]string) field {
+	return field{
+		key: key,
+		env: env,
+		set: func(v string) error {
+			*p = splitList(v)
+			return nil
+		},
+		get: func() string { return strings.Join(*p, ",") },
+	}
+}
+
+func logLevelField(key, env string, p *slog.Level) field {
+	return field{
+		key: key,
+		env: env,
+		set: func(v string) error {
+			var level slog.Level
+			if err := level.UnmarshalText([]byte(strings.TrimSpace(v))); err != nil {
+				return fmt.Errorf("invalid log level %q", v)
+			}
+			*p = level
+			return nil
+		},
+		get: func() string { return p.String() },
+	}
+}
+
+// limitField membaca limit format "<requests>/<durasi>[:burst]", nilai "off" mematikan policy
+func limitField(key, env string, p *rateLimitModels.Limit) field {
+	return field{
+		key: key,
+		env: env,
+		set: func(v string) error {
+			v = strings.TrimSpace(v)
+			if v == "off" {
+				*p = rateLimitModels.Limit{}
+				return nil
+			}
+
+			limit, err := rateLimitModels.ParseLimit(v)
+			if err != nil {
+				return err
+			}
+			*p = limit
+			return nil
+		},
+		get: func() string {
+			if p.IsZero() {
+				return "off"
+			}
+
+			s := strconv.Itoa(p.Requests) + "/" + p.Per.String()
+			if p.Burst > 0 {
+				s += ":" + strconv.Itoa(p.Burst)
+			}
+			return s
+		},
+	}
+}
+
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+
+	items := make([]string, 0)
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// redactURL menyembunyikan password di DSN tapi tetap menampilkan host & database
+func redactURL(v string) string {
+	u, err := url.Parse(v)
+	if err != nil || u.Scheme == "" {
+		// format key=value ("host=... password=...") tidak bisa disensor sebagian
+		return redacted
+	}
+
+	return u.Redacted()
+}
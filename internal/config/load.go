@@ -0,0 +1,192 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError berisi semua masalah config sekaligus, bukan hanya yang pertama ditemukan
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+type loader struct {
+	lookupEnv func(string) (string, bool)
+	readFile  func(string) ([]byte, error)
+	problems  []string
+}
+
+// Load membaca config berlapis, lapisan berikutnya menimpa yang sebelumnya:
+// default, file YAML (flag -config atau env CONFIG_FILE), env, lalu flag (-mail.smtp.host=...).
+// Env apa pun bisa dibaca dari file lewat <NAMA>_FILE, misal SMTP_PASSWORD_FILE=/run/secrets/smtp.
+func Load(args []string) (Config, error) {
+	l := &loader{lookupEnv: os.LookupEnv, readFile: os.ReadFile}
+	return l.load(args)
+}
+
+func (l *loader) load(args []string) (Config, error) {
+	cfg := Defaults()
+	fields := cfg.fields()
+
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configPath := fs.String("config", "", "path file config YAML")
+
+	// nilai flag ditampung dulu dan baru diterapkan setelah file & env
+	var flagValues [][2]string
+	for _, f := range fields {
+		fs.Func(f.key, "env "+f.env, func(v string) error {
+			flagValues = append(flagValues, [2]string{f.key, v})
+			return nil
+		})
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fs.SetOutput(os.Stderr)
+			fs.PrintDefaults()
+			return cfg, err
+		}
+
+		return cfg, &ValidationError{Problems: []string{err.Error()}}
+	}
+
+	if fs.NArg() > 0 {
+		l.problem("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	byKey := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+
+	path := *configPath
+	if path == "" {
+		path, _ = l.lookupEnv("CONFIG_FILE")
+	}
+
+	if path != "" {
+		l.applyFile(path, byKey)
+	}
+
+	for _, f := range fields {
+		l.applyEnv(f)
+	}
+
+	for _, kv := range flagValues {
+		if err := byKey[kv[0]].set(kv[1]); err != nil {
+			l.problem("flag -%s: %v", kv[0], err)
+		}
+	}
+
+	l.problems = append(l.problems, cfg.validate()...)
+	if len(l.problems) > 0 {
+		return cfg, &ValidationError{Problems: l.problems}
+	}
+
+	return cfg, nil
+}
+
+func (l *loader) problem(format string, args ...any) {
+	l.problems = append(l.problems, fmt.Sprintf(format, args...))
+}
+
+func (l *loader) applyFile(path string, byKey map[string]field) {
+	data, err := l.readFile(path)
+	if err != nil {
+		l.problem("read config file: %v", err)
+		return
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		l.problem("%s: %v", path, err)
+		return
+	}
+
+	values := map[string]string{}
+	flatten("", doc, values)
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		f, ok := byKey[key]
+		if !ok {
+			l.problem("%s: unknown key %q", path, key)
+			continue
+		}
+
+		if err := f.set(values[key]); err != nil {
+			l.problem("%s: %s: %v", path, key, err)
+		}
+	}
+}
+
+func (l *loader) applyEnv(f field) {
+	value, _ := l.lookupEnv(f.env)
+	file, _ := l.lookupEnv(f.env + "_FILE")
+
+	if file != "" {
+		if value != "" {
+			l.problem("%s and %s_FILE are both set, use only one", f.env, f.env)
+			return
+		}
+
+		data, err := l.readFile(file)
+		if err != nil {
+			l.problem("%s_FILE: %v", f.env, err)
+			return
+		}
+
+		// file secret biasanya diakhiri newline
+		value = strings.TrimRight(string(data), "\r\n")
+	}
+
+	if value == "" {
+		return
+	}
+
+	if err := f.set(value); err != nil {
+		l.problem("%s: %v", f.env, err)
+	}
+}
+
+// flatten mengubah YAML bertingkat menjadi key bertitik ("mail.smtp.host"), list digabung koma
+func flatten(prefix string, node map[string]any, out map[string]string) {
+	for k, v := range node {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		switch val := v.(type) {
+		case map[string]any:
+			flatten(key, val, out)
+		case []any:
+			items := make([]string, len(val))
+			for i, item := range val {
+				items[i] = fmt.Sprint(item)
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(val)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// validate mengembalikan semua masalah sekaligus. Nama env ikut disebut supaya mudah dicari di .env.
+func (c *Config) validate() []string {
+	var problems []string
+	env := map[string]string{}
+	for _, f := range c.fields() {
+		env[f.key] = f.env
+	}
+
+	add := func(key, format string, args ...any) {
+		problems = append(problems, fmt.Sprintf("%s (%s) ", key, env[key])+fmt.Sprintf(format, args...))
+	}
+
+	required := func(key, value string) {
+		if value == "" {
+			add(key, "is required")
+		}
+	}
+
+	oneOf := func(key, value string, allowed ...string) {
+		if !slices.Contains(allowed, value) {
+			add(key, "must be one of %v, got %q", allowed, value)
+		}
+	}
+
+	positive := func(key string, d time.Duration) {
+		if d <= 0 {
+			add(key, "must be positive")
+		}
+	}
+
+	required("database.url", c.Database.URL)
+	required("auth.secret", c.Auth.Secret)
+	required("auth.reset_password_url", c.Auth.ResetPasswordURL)
+	if c.Auth.ResetPasswordURL != "" {
+		if u, err := url.Parse(c.Auth.ResetPasswordURL); err != nil || !u.IsAbs() {
+			add("auth.reset_password_url", "must be an absolute URL")
+		}
+	}
+
+	oneOf("mail.driver", c.Mail.Driver, MailDriverSMTP, MailDriverLog, MailDriverMailbox)
+	if c.Mail.Driver == MailDriverSMTP {
+		required("mail.smtp.host", c.Mail.SMTP.Host)
+		required("mail.smtp.port", c.Mail.SMTP.Port)
+		required("mail.smtp.from_email", c.Mail.SMTP.FromEmail)
+	}
+	if c.Mail.Driver == MailDriverMailbox {
+		required("mail.mailbox_dir", c.Mail.MailboxDir)
+	}
+
+	if c.App.MetricsAddr != "" && c.App.MetricsAddr == c.App.Addr {
+		add("app.metrics_addr", "must differ from app.addr")
+	}
+
+	oneOf("rate_limit.store", c.RateLimit.Store, "memory", "postgres")
+	oneOf("openapi.validation", c.OpenAPI.Validation, "auto", "on", "off")
+	oneOf("tracing.exporter", c.Tracing.Exporter, "none", "otlp", "stdout", "file")
+	if c.Tracing.Exporter == "file" {
+		required("tracing.file", c.Tracing.File)
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		add("tracing.sample_ratio", "must be between 0 and 1")
+	}
+
+	if c.Database.TxMaxRetries < 0 {
+		add("database.tx_max_retries", "must not be negative")
+	}
+
+	if c.Jobs.WorkerConcurrency < 1 {
+		add("jobs.worker_concurrency", "must be at least 1")
+	}
+
+	if c.Jobs.MaxAttempts < 1 {
+		add("jobs.max_attempts", "must be at least 1")
+	}
+
+	if c.Outbox.BatchSize < 1 {
+		add("outbox.batch_size", "must be at least 1")
+	}
+
+	positive("auth.access_ttl", c.Auth.AccessTTL)
+	positive("auth.refresh_ttl", c.Auth.RefreshTTL)
+	positive("auth.reset_password_ttl", c.Auth.ResetPasswordTTL)
+	positive("auth.two_factor_otp_ttl", c.Auth.TwoFactorOTPTTL)
+	positive("jobs.poll_interval", c.Jobs.PollInterval)
+	positive("jobs.backoff_base", c.Jobs.BackoffBase)
+	positive("outbox.poll_interval", c.Outbox.PollInterval)
+	positive("webhook.timeout", c.Webhook.Timeout)
+	positive("idempotency.ttl", c.Idempotency.TTL)
+	positive("idempotency.lock_timeout", c.Idempotency.LockTimeout)
+	positive("health.check_timeout", c.Health.CheckTimeout)
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				add("cors.allowed_origins", `"*" cannot be combined with cors.allow_credentials`)
+			}
+			continue
+		}
+
+		u, err := url.Parse(strings.Replace(origin, "*", "wildcard", 1))
+		if err != nil || u.Scheme == "" || u.Host == "" || strings.Count(origin, "*") > 1 || (u.Path != "" && u.Path != "/") {
+			add("cors.allowed_origins", "invalid origin %q, want scheme://host[:port] with at most one *", origin)
+		}
+	}
+
+	if c.CORS.MaxAge < 0 {
+		add("cors.max_age", "must not be negative")
+	}
+
+	if c.Security.HSTSMaxAge < 0 {
+		add("security.hsts_max_age", "must not be negative")
+	}
+
+	if c.App.ShutdownDrainDelay < 0 {
+		add("app.shutdown_drain_delay", "must not be negative")
+	}
+
+	if c.Health.CacheTTL < 0 {
+		add("health.cache_ttl", "must not be negative")
+	}
+
+	return problems
+}
@@ -16,8 +16,7 @@ type User struct {
 	UpdatedAt time.Time
 }
 
-func NewUser(email, password, name string) *User {
-	now := time.Now()
+func NewUser(email, password, name string, now time.Time) *User {
 	return &User{
 		ID:        models.NewID(),
 		Email:     email,
@@ -17,10 +17,29 @@ type (
 		Logout(ctx context.Context, refreshToken string) error
 		RequestPasswordReset(ctx context.Context, email string) error
 		ConfirmPasswordReset(ctx context.Context, token, newPassword string) error
+		UpdateLocale(ctx context.Context, userID, locale string) error
+		CreateUser(ctx context.Context, email, password, name string, role models.Role) (*models.User, error)
+		PurgeExpiredTokens(ctx context.Context) (TokenPurgeResult, error)
 	}
 
+	// TokenPurgeResult jumlah baris yang dihapus per jenis token
+	TokenPurgeResult struct {
+		RefreshTokens       int64
+		TwoFactorOTPs       int64
+		PasswordResetTokens int64
+	}
+
+	// EmailSender memakai bahasa dari i18n.FromContext(ctx), biasanya locale milik penerima.
+	// Email baru cukup menambah template, data harus bisa di-encode ke JSON (dibawa job queue).
 	EmailSender interface {
-		SendPasswordReset(ctx context.Context, toEmail, resetLink string) error
-		SendLoginOTP(ctx context.Context, toEmail, otpCode string) error
+		Send(ctx context.Context, templateID, toEmail string, data map[string]any) error
 	}
 )
+
+// Template email yang dikirim oleh auth service beserta key data yang dibutuhkan
+const (
+	// EmailPasswordReset data: "link"
+	EmailPasswordReset = "password_reset"
+	// EmailLoginOTP data: "code"
+	EmailLoginOTP = "login_otp"
+)
@@ -4,6 +4,15 @@ import (
 	"context"
 
 	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+const (
+	LoginMethodPassword = "password"
+	LoginMethod2FA      = "2fa"
+
+	TwoFactorStepChallenge = "challenge"
+	TwoFactorStepVerify    = "verify"
 )
 
 type (
@@ -14,13 +23,14 @@ type (
 		Delete(ctx context.Context, id string) error
 		Exist(ctx context.Context, email string) (bool, error)
 		UpdateUserPassword(ctx context.Context, id models.ID, hashed string) error
+		UpdateLocale(ctx context.Context, id models.ID, locale i18n.Locale) error
 	}
 
 	TwoFactorOTPRepository interface {
 		Save(ctx context.Context, otpCode *models.TwoFactorOTP) error
 		GetByChallengeID(ctx context.Context, challengeID string) (*models.TwoFactorOTP, error)
 		MarkUsed(ctx context.Context, id models.ID) error
-		DeleteExpired(ctx context.Context) error
+		DeleteExpired(ctx context.Context) (int64, error)
 	}
 
 	RefreshTokenRepository interface {
@@ -28,12 +38,18 @@ type (
 		GetByTokenHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
 		GetByUserID(ctx context.Context, userID models.ID) ([]*models.RefreshToken, error)
 		Revoke(ctx context.Context, tokenID models.ID) error
-		DeleteExpired(ctx context.Context) error
+		DeleteExpired(ctx context.Context) (int64, error)
 	}
 
 	PasswordHasher interface {
-		Hash(password string) (string, error)
-		Verify(hash, password string) bool
+		Hash(ctx context.Context, password string) (string, error)
+		Verify(ctx context.Context, hash, password string) bool
+	}
+
+	// AuthMetrics mencatat hasil login dan 2FA, semua error (termasuk internal) dihitung gagal
+	AuthMetrics interface {
+		ObserveLogin(method string, success bool)
+		ObserveTwoFactor(step string, success bool)
 	}
 
 	TokenHasher interface {
@@ -50,5 +66,6 @@ type (
 		Save(ctx context.Context, t *models.PasswordResetToken) error
 		GetByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
 		MarkUsed(ctx context.Context, id models.ID) error
+		DeleteExpired(ctx context.Context) (int64, error)
 	}
 )
@@ -10,8 +10,13 @@ import (
 	"time"
 
 	"villainrsty-ecommerce-server/internal/core/auth/ports"
+	eventModels "villainrsty-ecommerce-server/internal/core/events/models"
+	eventPorts "villainrsty-ecommerce-server/internal/core/events/ports"
 	"villainrsty-ecommerce-server/internal/core/shared/errors"
 	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+	"villainrsty-ecommerce-server/pkg/i18n"
+	"villainrsty-ecommerce-server/pkg/logger"
 )
 
 type AuthService struct {
@@ -23,6 +28,10 @@ type AuthService struct {
 	hasher            ports.PasswordHasher
 	tokenHasher       ports.TokenHasher
 	jwtService        ports.JWTService
+	txManager         sharedPorts.TxManager
+	clock             sharedPorts.Clock
+	publisher         eventPorts.EventPublisher
+	metrics           ports.AuthMetrics
 	logger            *slog.Logger
 	resetURL          string
 	resetTTL          time.Duration
@@ -38,6 +47,10 @@ func NewAuthService(
 	hasher ports.PasswordHasher,
 	tokenHasher ports.TokenHasher,
 	jwtService ports.JWTService,
+	txManager sharedPorts.TxManager,
+	clock sharedPorts.Clock,
+	publisher eventPorts.EventPublisher,
+	metrics ports.AuthMetrics,
 	logger *slog.Logger,
 	resetURL string,
 	resetTTL time.Duration,
@@ -52,6 +65,10 @@ func NewAuthService(
 		emailSender:       emailSender,
 		refreshTokenRepo:  refreshTokenRepo,
 		jwtService:        jwtService,
+		txManager:         txManager,
+		clock:             clock,
+		publisher:         publisher,
+		metrics:           metrics,
 		logger:            logger,
 		resetURL:          resetURL,
 		resetTTL:          resetTTL,
@@ -59,7 +76,9 @@ func NewAuthService(
 	}
 }
 
-func (s *AuthService) Login(ctx context.Context, email, password string, rememberMe bool) (*models.User, string, string, error) {
+func (s *AuthService) Login(ctx context.Context, email, password string, rememberMe bool) (_ *models.User, _, _ string, err error) {
+	defer func() { s.metrics.ObserveLogin(ports.LoginMethodPassword, err == nil) }()
+
 	if email == "" || password == "" {
 		return nil, "", "", errors.New(errors.ErrValidation, "email and password are required")
 	}
@@ -70,11 +89,11 @@ func (s *AuthService) Login(ctx context.Context, email, password string, remembe
 	}
 
 	if user == nil {
-		return nil, "", "", errors.New(errors.ErrUnauthorized, "invalid email or password")
+		return nil, "", "", errors.NewCode(errors.CodeInvalidCredentials, "invalid email or password")
 	}
 
-	if !s.hasher.Verify(user.Password, password) {
-		return nil, "", "", errors.New(errors.ErrUnauthorized, "invalid email or password")
+	if !s.hasher.Verify(ctx, user.Password, password) {
+		return nil, "", "", errors.NewCode(errors.CodeInvalidCredentials, "invalid email or password")
 	}
 
 	accessToken, err := s.jwtService.GenerateAccessToken(user)
@@ -88,24 +107,17 @@ func (s *AuthService) Login(ctx context.Context, email, password string, remembe
 	}
 
 	tokenHash, err := s.tokenHasher.Hash(refreshTokenString)
-	s.logger.Info("refresh token debug (login)",
-		"refresh_len", len(refreshTokenString),
-		"hash_len", len(tokenHash),
-		"hash_prefix", tokenHash[:8],
-	)
-
 	if err != nil {
-		s.logger.Warn("token hash", "error", err)
+		s.log(ctx).Warn("token hash", "error", err)
 		return nil, "", "", errors.Wrap(errors.ErrInternal, "failed to hash token", err)
 	}
 
 	ttl := 24 * time.Hour
 	if rememberMe {
 		ttl = 30 * 24 * time.Hour
-		s.logger.Info("ttl", "ttl sekarang: ", ttl)
 	}
 
-	refreshToken := models.NewRefreshToken(user.ID, tokenHash, ttl)
+	refreshToken := models.NewRefreshToken(user.ID, tokenHash, ttl, s.clock.Now())
 	if err := s.refreshTokenRepo.Save(ctx, refreshToken); err != nil {
 		return nil, "", "", errors.Wrap(errors.ErrInternal, "failed to save refresh token", err)
 	}
@@ -113,18 +125,20 @@ func (s *AuthService) Login(ctx context.Context, email, password string, remembe
 	return user, accessToken, refreshTokenString, nil
 }
 
-func (s *AuthService) LoginWith2FA(ctx context.Context, email, password string, _ bool) (string, error) {
+func (s *AuthService) LoginWith2FA(ctx context.Context, email, password string, _ bool) (_ string, err error) {
+	defer func() { s.metrics.ObserveTwoFactor(ports.TwoFactorStepChallenge, err == nil) }()
+
 	if email == "" || password == "" {
 		return "", errors.New(errors.ErrValidation, "email and password is required")
 	}
 
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
-		return "", errors.New(errors.ErrUnauthorized, "invalid email or password")
+		return "", errors.NewCode(errors.CodeInvalidCredentials, "invalid email or password")
 	}
 
-	if !s.hasher.Verify(user.Password, password) {
-		return "", errors.New(errors.ErrUnauthorized, "invalid email or password")
+	if !s.hasher.Verify(ctx, user.Password, password) {
+		return "", errors.NewCode(errors.CodeInvalidCredentials, "invalid email or password")
 	}
 
 	challengeID, err := generateSecureToken(24)
@@ -142,90 +156,122 @@ func (s *AuthService) LoginWith2FA(ctx context.Context, email, password string,
 		return "", errors.Wrap(errors.ErrInternal, "failed to hash otp", err)
 	}
 
+	now := s.clock.Now()
 	otp := &models.TwoFactorOTP{
 		ID:          models.NewID(),
 		UserID:      user.ID,
 		ChallengeID: challengeID,
 		CodeHash:    otpHash,
-		ExpiresAt:   time.Now().Add(s.twoFactorOTPTTL),
-		CreatedAt:   time.Now(),
+		ExpiresAt:   now.Add(s.twoFactorOTPTTL),
+		CreatedAt:   now,
 	}
 
-	if err := s.twoFactorOTPRepo.Save(ctx, otp); err != nil {
-		return "", errors.Wrap(errors.ErrInternal, "failed to save otp", err)
-	}
+	// OTP dan job email disimpan atomik, jadi tidak ada challenge tanpa email (atau sebaliknya)
+	if err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.twoFactorOTPRepo.Save(ctx, otp); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to save otp", err)
+		}
+
+		if err := s.emailSender.Send(i18n.WithLocale(ctx, user.Locale), ports.EmailLoginOTP, user.Email, map[string]any{"code": otpCode}); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to send otp", err)
+		}
 
-	if err := s.emailSender.SendLoginOTP(ctx, user.Email, otpCode); err != nil {
-		return "", errors.Wrap(errors.ErrInternal, "failed to send otp", err)
+		return nil
+	}); err != nil {
+		return "", err
 	}
 
 	return challengeID, nil
 }
 
-func (s *AuthService) VerifyLogin2FA(ctx context.Context, challengeID, otpCode string, rememberMe bool) (*models.User, string, string, error) {
+func (s *AuthService) VerifyLogin2FA(ctx context.Context, challengeID, otpCode string, rememberMe bool) (_ *models.User, _, _ string, err error) {
+	defer func() {
+		s.metrics.ObserveTwoFactor(ports.TwoFactorStepVerify, err == nil)
+		s.metrics.ObserveLogin(ports.LoginMethod2FA, err == nil)
+	}()
+
 	if challengeID == "" || otpCode == "" {
 		return nil, "", "", errors.New(errors.ErrValidation, "challenge_id and otp_code is required")
 	}
 
-	otp, err := s.twoFactorOTPRepo.GetByChallengeID(ctx, challengeID)
-	if err != nil {
-		return nil, "", "", errors.New(errors.ErrUnauthorized, "invalid or expired otp")
-	}
-
-	if !otp.IsValid() {
-		return nil, "", "", errors.New(errors.ErrUnauthorized, "invalid or expired otp")
-	}
-
 	hash, err := s.tokenHasher.Hash(otpCode)
 	if err != nil {
 		return nil, "", "", errors.Wrap(errors.ErrInternal, "failed to hash otp", err)
 	}
 
-	if hash != otp.CodeHash {
-		return nil, "", "", errors.New(errors.ErrUnauthorized, "invalid or expired otp")
-	}
+	var user *models.User
+	var accessToken, refreshTokenString string
 
-	if err := s.twoFactorOTPRepo.MarkUsed(ctx, otp.ID); err != nil {
-		s.logger.Info("isi markused", "used_at", otp.ID.String())
-		return nil, "", "", errors.Wrap(errors.ErrInternal, "failed to mark otp used", err)
-	}
+	// OTP ditandai terpakai dan refresh token disimpan dalam satu transaksi serializable,
+	// jadi OTP yang sama tidak bisa ditukar dua kali oleh request yang berbarengan
+	if err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		otp, err := s.twoFactorOTPRepo.GetByChallengeID(ctx, challengeID)
+		if err != nil {
+			if errors.IsKind(err, errors.ErrNotFound) {
+				return errors.NewCode(errors.CodeInvalidOTP, "invalid or expired otp")
+			}
+			return err
+		}
 
-	user, err := s.userRepo.GetByID(ctx, otp.UserID.String())
-	s.logger.Info("isi otp id", "otp", otp.ID.String())
-	if err != nil {
-		s.logger.Info("error di getbyid verify", "error", err)
-		return nil, "", "", errors.New(errors.ErrUnauthorized, "user not found")
-	}
+		if !otp.IsValid(s.clock.Now()) || hash != otp.CodeHash {
+			return errors.NewCode(errors.CodeInvalidOTP, "invalid or expired otp")
+		}
 
-	accessToken, err := s.jwtService.GenerateAccessToken(user)
-	if err != nil {
-		return nil, "", "", errors.Wrap(errors.ErrInternal, "failed to generate access token", err)
-	}
+		if err := s.twoFactorOTPRepo.MarkUsed(ctx, otp.ID); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to mark otp used", err)
+		}
 
-	refreshTokenString, err := s.jwtService.GenerateRefreshToken(user)
-	if err != nil {
-		return nil, "", "", errors.Wrap(errors.ErrUnauthorized, "failed to generate refresh token", err)
-	}
+		user, err = s.userRepo.GetByID(ctx, otp.UserID.String())
+		if err != nil {
+			if errors.IsKind(err, errors.ErrNotFound) {
+				return errors.New(errors.ErrUnauthorized, "user not found")
+			}
+			return err
+		}
 
-	refreshHash, err := s.tokenHasher.Hash(refreshTokenString)
-	if err != nil {
-		return nil, "", "", errors.Wrap(errors.ErrInternal, "failed to hash refresh token", err)
-	}
+		accessToken, err = s.jwtService.GenerateAccessToken(user)
+		if err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to generate access token", err)
+		}
 
-	ttl := 24 * time.Hour
-	if rememberMe {
-		ttl = 30 * 24 * time.Hour
-	}
+		refreshTokenString, err = s.jwtService.GenerateRefreshToken(user)
+		if err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to generate refresh token", err)
+		}
 
-	refreshToken := models.NewRefreshToken(user.ID, refreshHash, ttl)
-	if err := s.refreshTokenRepo.Save(ctx, refreshToken); err != nil {
-		return nil, "", "", errors.Wrap(errors.ErrInternal, "failed to save refresh token", err)
+		refreshHash, err := s.tokenHasher.Hash(refreshTokenString)
+		if err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to hash refresh token", err)
+		}
+
+		ttl := 24 * time.Hour
+		if rememberMe {
+			ttl = 30 * 24 * time.Hour
+		}
+
+		if err := s.refreshTokenRepo.Save(ctx, models.NewRefreshToken(user.ID, refreshHash, ttl, s.clock.Now())); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to save refresh token", err)
+		}
+
+		return nil
+	}, sharedPorts.Serializable()); err != nil {
+		return nil, "", "", err
 	}
 
 	return user, accessToken, refreshTokenString, nil
 }
 
 func (s *AuthService) Register(ctx context.Context, email, password, name string) (*models.User, error) {
+	return s.CreateUser(ctx, email, password, name, models.RoleUser)
+}
+
+// CreateUser dipakai Register dan CLI (user create, seed). Aturan password dan event
+// UserRegistered sama untuk semua role.
+func (s *AuthService) CreateUser(ctx context.Context, email, password, name string, role models.Role) (*models.User, error) {
+	if !role.IsValid() {
+		return nil, errors.New(errors.ErrValidation, "invalid role")
+	}
+
 	if email == "" || password == "" {
 		return nil, errors.New(errors.ErrValidation, "email and password are required")
 	}
@@ -236,22 +282,40 @@ func (s *AuthService) Register(ctx context.Context, email, password, name string
 	}
 
 	if exists {
-		return nil, errors.New(errors.ErrConflict, "email already registered")
+		return nil, errors.NewCode(errors.CodeEmailTaken, "email already registered")
 	}
 
-	user := models.NewUser(email, password, name)
+	user := models.NewUser(email, password, name, s.clock.Now())
+	user.Role = role
+	// bahasa awal mengikuti request registrasi, bisa diganti lewat UpdateLocale
+	user.Locale = i18n.FromContext(ctx)
 	if !user.IsPasswordValid(password) {
-		return nil, errors.New(errors.ErrValidation, "password must contain uppercase, lowercase and number")
+		return nil, errors.NewCode(errors.CodeWeakPassword, "password must contain uppercase, lowercase and number")
 	}
 
-	hashedPassword, err := s.hasher.Hash(password)
+	hashedPassword, err := s.hasher.Hash(ctx, password)
 	if err != nil {
 		return nil, errors.Wrap(errors.ErrInternal, "failed to hash password", err)
 	}
 
 	user.Password = hashedPassword
 
-	if err := s.userRepo.Save(ctx, user); err != nil {
+	if err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Save(ctx, user); err != nil {
+			return err
+		}
+
+		event, err := models.NewDomainEvent(eventModels.UserRegistered, eventModels.AggregateUser, user.ID, eventModels.UserRegisteredPayload{
+			UserID: user.ID.String(),
+			Email:  user.Email,
+			Name:   user.Name,
+		}, s.clock.Now())
+		if err != nil {
+			return err
+		}
+
+		return s.publisher.Publish(ctx, event)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -270,7 +334,7 @@ func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
 
 	dbToken, err := s.refreshTokenRepo.GetByTokenHash(ctx, tokenHash)
 	if err != nil {
-		return errors.New(errors.ErrUnauthorized, "refresh token not found")
+		return errors.NewCode(errors.CodeInvalidRefreshToken, "refresh token not found")
 	}
 
 	if err := s.refreshTokenRepo.Revoke(ctx, dbToken.ID); err != nil {
@@ -285,52 +349,68 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (st
 		return "", "", errors.New(errors.ErrValidation, "refresh token is required")
 	}
 
-	user, err := s.jwtService.ValidateToken(refreshToken)
-	if err != nil {
-		return "", "", errors.New(errors.ErrUnauthorized, "invalid refresh token")
+	if _, err := s.jwtService.ValidateToken(refreshToken); err != nil {
+		return "", "", errors.NewCode(errors.CodeInvalidRefreshToken, "invalid refresh token")
 	}
 
 	tokenHash, err := s.tokenHasher.Hash(refreshToken)
 	if err != nil {
 		return "", "", errors.Wrap(errors.ErrInternal, "failed to hash refresh token", err)
 	}
-	s.logger.Info("refresh token debug (incoming)",
-		"incoming_len", len(refreshToken),
-		"hash_len", len(tokenHash),
-		"hash_prefix", tokenHash[:8],
-	)
 
-	dbToken, err := s.refreshTokenRepo.GetByTokenHash(ctx, tokenHash)
-	if err != nil {
-		return "", "", errors.New(errors.ErrUnauthorized, "refresh token not found")
-	}
+	var accessToken, newRefreshTokenString string
 
-	if !dbToken.IsValid() {
-		return "", "", errors.New(errors.ErrUnauthorized, "refresh token is expired or revoked")
-	}
+	// Cek token lama dilakukan di dalam transaksi serializable supaya dua rotasi berbarengan
+	// tidak sama-sama lolos; salah satunya kena serialization failure lalu di-retry dan ditolak
+	if err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		dbToken, err := s.refreshTokenRepo.GetByTokenHash(ctx, tokenHash)
+		if err != nil {
+			if errors.IsKind(err, errors.ErrNotFound) {
+				return errors.NewCode(errors.CodeInvalidRefreshToken, "refresh token not found")
+			}
+			return err
+		}
 
-	accessToken, err := s.jwtService.GenerateAccessToken(user)
-	if err != nil {
-		return "", "", errors.Wrap(errors.ErrInternal, "failed to generate access token", err)
-	}
+		if !dbToken.IsValid(s.clock.Now()) {
+			return errors.NewCode(errors.CodeInvalidRefreshToken, "refresh token is expired or revoked")
+		}
 
-	newRefreshTokenString, err := s.jwtService.GenerateRefreshToken(user)
-	if err != nil {
-		return "", "", errors.Wrap(errors.ErrInternal, "failed to generate refresh token", err)
-	}
+		// claim role dan locale di token lama bisa sudah basi, token baru dibangun dari database
+		user, err := s.userRepo.GetByID(ctx, dbToken.UserID.String())
+		if err != nil {
+			if errors.IsKind(err, errors.ErrNotFound) {
+				return errors.NewCode(errors.CodeInvalidRefreshToken, "user not found")
+			}
+			return err
+		}
 
-	newTokenHash, err := s.tokenHasher.Hash(newRefreshTokenString)
-	if err != nil {
-		return "", "", errors.Wrap(errors.ErrInternal, "failed to hash refresh token", err)
-	}
+		accessToken, err = s.jwtService.GenerateAccessToken(user)
+		if err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to generate access token", err)
+		}
 
-	newRefreshToken := models.NewRefreshToken(user.ID, newTokenHash, 7*24*time.Hour)
-	if err := s.refreshTokenRepo.Save(ctx, newRefreshToken); err != nil {
-		return "", "", errors.Wrap(errors.ErrInternal, "failed to save refresh token", err)
-	}
+		newRefreshTokenString, err = s.jwtService.GenerateRefreshToken(user)
+		if err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to generate refresh token", err)
+		}
 
-	if err := s.refreshTokenRepo.Revoke(ctx, dbToken.ID); err != nil {
-		s.logger.Error("failed to revoke old refresh token", "[ERROR]:", err)
+		newTokenHash, err := s.tokenHasher.Hash(newRefreshTokenString)
+		if err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to hash refresh token", err)
+		}
+
+		newRefreshToken := models.NewRefreshToken(user.ID, newTokenHash, 7*24*time.Hour, s.clock.Now())
+		if err := s.refreshTokenRepo.Save(ctx, newRefreshToken); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to save refresh token", err)
+		}
+
+		if err := s.refreshTokenRepo.Revoke(ctx, dbToken.ID); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to revoke old refresh token", err)
+		}
+
+		return nil
+	}, sharedPorts.Serializable()); err != nil {
+		return "", "", err
 	}
 
 	return accessToken, newRefreshTokenString, nil
@@ -343,7 +423,7 @@ func (s *AuthService) ValidateToken(ctx context.Context, token string) (*models.
 
 	user, err := s.jwtService.ValidateToken(token)
 	if err != nil {
-		return nil, errors.New(errors.ErrUnauthorized, "invalid token")
+		return nil, errors.NewCode(errors.CodeInvalidToken, "invalid token")
 	}
 
 	return user, nil
@@ -372,29 +452,35 @@ func (s *AuthService) RequestPasswordReset(ctx context.Context, email string) er
 		return errors.Wrap(errors.ErrInternal, "failed to hash reset token", err)
 	}
 
+	now := s.clock.Now()
 	resetToken := &models.PasswordResetToken{
 		ID:        models.NewID(),
 		UserID:    user.ID,
 		TokenHash: tokenHash,
-		ExpiresAt: time.Now().Add(s.resetTTL),
-		CreatedAt: time.Now(),
-	}
-
-	if err := s.passwordResetRepo.Save(ctx, resetToken); err != nil {
-		return errors.Wrap(errors.ErrInternal, "failed to save password reset token", err)
+		ExpiresAt: now.Add(s.resetTTL),
+		CreatedAt: now,
 	}
 
 	resetLink, err := buildResetLink(s.resetURL, rawToken)
 	if err != nil {
 		return errors.Wrap(errors.ErrInternal, "failed to build reset link", err)
 	}
-	s.logger.Info("sending reset email", "to", email, "link", resetLink)
 
-	if err := s.emailSender.SendPasswordReset(ctx, user.Email, resetLink); err != nil {
-		s.logger.Error("failed to send reset email", "to", email, "err", err)
-		return errors.Wrap(errors.ErrInternal, "failed to send reset email", err)
+	if err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.passwordResetRepo.Save(ctx, resetToken); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to save password reset token", err)
+		}
+
+		if err := s.emailSender.Send(i18n.WithLocale(ctx, user.Locale), ports.EmailPasswordReset, user.Email, map[string]any{"link": resetLink}); err != nil {
+			s.log(ctx).Error("failed to send reset email", "to", email, "err", err)
+			return errors.Wrap(errors.ErrInternal, "failed to send reset email", err)
+		}
+
+		return nil
+	}); err != nil {
+		return err
 	}
-	s.logger.Info("reset email sent", "to", email)
+	s.log(ctx).Info("reset email queued", "to", email)
 
 	return nil
 }
@@ -413,35 +499,96 @@ func (s *AuthService) ConfirmPasswordReset(ctx context.Context, token, newPasswo
 		return errors.Wrap(errors.ErrInternal, "failed to hash reset token", err)
 	}
 
-	dbToken, err := s.passwordResetRepo.GetByTokenHash(ctx, tokenHash)
-	if err != nil {
-		if errors.IsKind(err, errors.ErrNotFound) {
-			return errors.New(errors.ErrUnauthorized, "invalid reset token")
-		}
-		return err
+	if !models.NewUser("temp@mail.com", newPassword, "temp", s.clock.Now()).IsPasswordValid(newPassword) {
+		return errors.NewCode(errors.CodeWeakPassword, "password must contain uppercase, lowercase and number")
 	}
 
-	if !dbToken.IsValid() {
-		return errors.New(errors.ErrUnauthorized, "invalid reset token")
+	hashedPassword, err := s.hasher.Hash(ctx, newPassword)
+	if err != nil {
+		return errors.Wrap(errors.ErrInternal, "failed to hash password", err)
 	}
 
-	if !models.NewUser("temp@mail.com", newPassword, "temp").IsPasswordValid(newPassword) {
-		return errors.New(errors.ErrValidation, "password must contain uppercase, lowercase and number")
+	// Update password, tandai token terpakai dan publish event harus atomik;
+	// kalau salah satu gagal token tetap bisa dipakai ulang dan password tidak berubah
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		dbToken, err := s.passwordResetRepo.GetByTokenHash(ctx, tokenHash)
+		if err != nil {
+			if errors.IsKind(err, errors.ErrNotFound) {
+				return errors.NewCode(errors.CodeInvalidResetToken, "invalid reset token")
+			}
+			return err
+		}
+
+		if !dbToken.IsValid(s.clock.Now()) {
+			return errors.NewCode(errors.CodeInvalidResetToken, "invalid reset token")
+		}
+
+		if err := s.userRepo.UpdateUserPassword(ctx, dbToken.UserID, hashedPassword); err != nil {
+			return err
+		}
+
+		if err := s.passwordResetRepo.MarkUsed(ctx, dbToken.ID); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to mark reset token used", err)
+		}
+
+		event, err := models.NewDomainEvent(eventModels.PasswordChanged, eventModels.AggregateUser, dbToken.UserID, eventModels.PasswordChangedPayload{
+			UserID: dbToken.UserID.String(),
+			Reason: "password_reset",
+		}, s.clock.Now())
+		if err != nil {
+			return err
+		}
+
+		return s.publisher.Publish(ctx, event)
+	}, sharedPorts.Serializable())
+}
+
+func (s *AuthService) UpdateLocale(ctx context.Context, userID, locale string) error {
+	parsed, ok := i18n.Parse(locale)
+	if !ok {
+		appErr := errors.NewCode(errors.CodeUnsupportedLocale, "unsupported locale "+locale)
+		appErr.Params = map[string]string{"supported": i18n.SupportedList()}
+		return appErr
 	}
 
-	hashedPassword, err := s.hasher.Hash(newPassword)
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return errors.Wrap(errors.ErrInternal, "failed to hash password", err)
+		return err
 	}
 
-	if err := s.userRepo.UpdateUserPassword(ctx, dbToken.UserID, hashedPassword); err != nil {
-		return err
+	return s.userRepo.UpdateLocale(ctx, user.ID, parsed)
+}
+
+// PurgeExpiredTokens menghapus refresh token yang kedaluwarsa serta OTP 2FA dan token reset
+// password yang kedaluwarsa atau sudah terpakai
+func (s *AuthService) PurgeExpiredTokens(ctx context.Context) (ports.TokenPurgeResult, error) {
+	var result ports.TokenPurgeResult
+	var err error
+
+	if result.RefreshTokens, err = s.refreshTokenRepo.DeleteExpired(ctx); err != nil {
+		return result, err
 	}
 
-	if err := s.passwordResetRepo.MarkUsed(ctx, dbToken.ID); err != nil {
-		return errors.Wrap(errors.ErrInternal, "failed to mark reset token used", err)
+	if result.TwoFactorOTPs, err = s.twoFactorOTPRepo.DeleteExpired(ctx); err != nil {
+		return result, err
 	}
-	return nil
+
+	if result.PasswordResetTokens, err = s.passwordResetRepo.DeleteExpired(ctx); err != nil {
+		return result, err
+	}
+
+	s.log(ctx).Info("expired tokens purged",
+		"refresh_tokens", result.RefreshTokens,
+		"two_factor_otps", result.TwoFactorOTPs,
+		"password_reset_tokens", result.PasswordResetTokens,
+	)
+
+	return result, nil
+}
+
+// log mengambil logger request dari ctx (request_id, user_id, route), fallback ke logger service
+func (s *AuthService) log(ctx context.Context) *slog.Logger {
+	return logger.FromContext(ctx, s.logger)
 }
 
 func generateSecureToken(size int) (string, error) {
@@ -0,0 +1,690 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/memory"
+	eventModels "villainrsty-ecommerce-server/internal/core/events/models"
+	eventService "villainrsty-ecommerce-server/internal/core/events/service"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+	"villainrsty-ecommerce-server/pkg/clock"
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+// Repository memakai implementasi in-memory (memory.*); pembungkus di bawah hanya menambah
+// kegagalan buatan atau pencatatan yang dibutuhkan test.
+type (
+	// recordingTx mencatat hasil dan opsi setiap transaksi terluar
+	recordingTx struct {
+		*memory.TxManager
+		mu        sync.Mutex
+		depth     int
+		commits   int
+		rollbacks int
+		opts      []sharedPorts.TxOptions
+	}
+
+	refreshTokenRepo struct {
+		*memory.RefreshTokenRepository
+		revokeErr error
+	}
+
+	resetTokenRepo struct {
+		*memory.PasswordResetTokenRepository
+		markUsedErr error
+	}
+
+	outboxRepo struct {
+		*memory.OutboxRepository
+		err error
+	}
+)
+
+func (m *recordingTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error, opts ...sharedPorts.TxOption) error {
+	m.mu.Lock()
+	m.depth++
+	outer := m.depth == 1
+	m.mu.Unlock()
+
+	err := m.TxManager.WithinTx(ctx, fn, opts...)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.depth--
+	if !outer {
+		return err
+	}
+
+	m.opts = append(m.opts, sharedPorts.ApplyTxOptions(opts...))
+	if err != nil {
+		m.rollbacks++
+	} else {
+		m.commits++
+	}
+
+	return err
+}
+
+func (r *refreshTokenRepo) Revoke(ctx context.Context, tokenID models.ID) error {
+	if r.revokeErr != nil {
+		return r.revokeErr
+	}
+
+	return r.RefreshTokenRepository.Revoke(ctx, tokenID)
+}
+
+func (r *resetTokenRepo) MarkUsed(ctx context.Context, id models.ID) error {
+	if r.markUsedErr != nil {
+		return r.markUsedErr
+	}
+
+	return r.PasswordResetTokenRepository.MarkUsed(ctx, id)
+}
+
+func (r *outboxRepo) Save(ctx context.Context, event *models.DomainEvent) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	return r.OutboxRepository.Save(ctx, event)
+}
+
+// fakeEmailSender mencatat locale setiap email yang dikirim
+type fakeEmailSender struct {
+	locales []i18n.Locale
+}
+
+func (s *fakeEmailSender) Send(ctx context.Context, _, _ string, _ map[string]any) error {
+	s.locales = append(s.locales, i18n.FromContext(ctx))
+	return nil
+}
+
+type plainHasher struct{}
+
+func (plainHasher) Hash(_ context.Context, password string) (string, error) {
+	return "hashed:" + password, nil
+}
+
+func (plainHasher) Verify(_ context.Context, hash, password string) bool {
+	return hash == "hashed:"+password
+}
+
+type plainTokenHasher struct{}
+
+func (plainTokenHasher) Hash(token string) (string, error) { return "sha:" + token, nil }
+
+// fakeJWTService menerbitkan token acak dan mengingat pemiliknya
+type fakeJWTService struct {
+	issued map[string]*models.User
+}
+
+func (j *fakeJWTService) GenerateAccessToken(user *models.User) (string, error) {
+	return j.issue(user), nil
+}
+
+func (j *fakeJWTService) GenerateRefreshToken(user *models.User) (string, error) {
+	return j.issue(user), nil
+}
+
+func (j *fakeJWTService) ValidateToken(token string) (*models.User, error) {
+	u, ok := j.issued[token]
+	if !ok {
+		return nil, errors.New(errors.ErrUnauthorized, "invalid token")
+	}
+
+	return u, nil
+}
+
+func (j *fakeJWTService) issue(user *models.User) string {
+	token := "jwt-" + models.NewID().String()
+	j.issued[token] = user
+	return token
+}
+
+// fakeAuthMetrics menghitung observasi per "<method|step>:<success>"
+type fakeAuthMetrics struct {
+	logins    map[string]int
+	twoFactor map[string]int
+}
+
+func (m *fakeAuthMetrics) ObserveLogin(method string, success bool) {
+	m.logins[fmt.Sprintf("%s:%t", method, success)]++
+}
+
+func (m *fakeAuthMetrics) ObserveTwoFactor(step string, success bool) {
+	m.twoFactor[fmt.Sprintf("%s:%t", step, success)]++
+}
+
+type authFixture struct {
+	svc           *AuthService
+	users         *memory.UserRepository
+	refreshTokens *refreshTokenRepo
+	resetTokens   *resetTokenRepo
+	otps          *memory.TwoFactorOTPRepository
+	outbox        *outboxRepo
+	jwt           *fakeJWTService
+	tx            *recordingTx
+	metrics       *fakeAuthMetrics
+	emails        *fakeEmailSender
+	clock         *clock.Fake
+}
+
+func newAuthFixture() *authFixture {
+	clk := clock.NewFake(time.Now().Truncate(time.Second))
+	f := &authFixture{
+		users:         memory.NewUserRepository(clk),
+		refreshTokens: &refreshTokenRepo{RefreshTokenRepository: memory.NewRefreshTokenRepository(clk)},
+		resetTokens:   &resetTokenRepo{PasswordResetTokenRepository: memory.NewPasswordResetTokenRepository(clk)},
+		otps:          memory.NewTwoFactorOTPRepository(clk),
+		outbox:        &outboxRepo{OutboxRepository: memory.NewOutboxRepository()},
+		jwt:           &fakeJWTService{issued: map[string]*models.User{}},
+		tx:            &recordingTx{TxManager: memory.NewTxManager()},
+		metrics:       &fakeAuthMetrics{logins: map[string]int{}, twoFactor: map[string]int{}},
+		emails:        &fakeEmailSender{},
+		clock:         clk,
+	}
+
+	f.svc = NewAuthService(
+		f.users,
+		f.refreshTokens,
+		f.resetTokens,
+		f.otps,
+		f.emails,
+		plainHasher{},
+		plainTokenHasher{},
+		f.jwt,
+		f.tx,
+		f.clock,
+		eventService.NewOutboxPublisher(f.outbox),
+		f.metrics,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		"http://localhost/reset",
+		30*time.Minute,
+		5*time.Minute,
+	)
+
+	return f
+}
+
+func (f *authFixture) seedUser(t *testing.T) *models.User {
+	t.Helper()
+
+	user := models.NewUser("budi@mail.com", "hashed:OldPassw0rd", "Budi", f.clock.Now())
+	if err := f.users.Save(context.Background(), user); err != nil {
+		t.Fatalf("save user: %v", err)
+	}
+	return user
+}
+
+func (f *authFixture) seedResetToken(t *testing.T, userID models.ID, raw string, usedAt *time.Time) *models.PasswordResetToken {
+	t.Helper()
+
+	token := &models.PasswordResetToken{
+		ID:        models.NewID(),
+		UserID:    userID,
+		TokenHash: "sha:" + raw,
+		ExpiresAt: f.clock.Now().Add(time.Hour),
+		UsedAt:    usedAt,
+		CreatedAt: f.clock.Now(),
+	}
+	if err := f.resetTokens.Save(context.Background(), token); err != nil {
+		t.Fatalf("save reset token: %v", err)
+	}
+	return token
+}
+
+func (f *authFixture) seedRefreshToken(t *testing.T, token *models.RefreshToken) {
+	t.Helper()
+
+	if err := f.refreshTokens.Save(context.Background(), token); err != nil {
+		t.Fatalf("save refresh token: %v", err)
+	}
+}
+
+func (f *authFixture) password(t *testing.T, id models.ID) string {
+	t.Helper()
+
+	user, err := f.users.GetByID(context.Background(), id.String())
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	return user.Password
+}
+
+func (f *authFixture) resetUsed(t *testing.T, raw string) bool {
+	t.Helper()
+
+	token, err := f.resetTokens.GetByTokenHash(context.Background(), "sha:"+raw)
+	if err != nil {
+		t.Fatalf("get reset token: %v", err)
+	}
+	return token.UsedAt != nil
+}
+
+func (f *authFixture) refreshRevoked(t *testing.T, raw string) bool {
+	t.Helper()
+
+	token, err := f.refreshTokens.GetByTokenHash(context.Background(), "sha:"+raw)
+	if err != nil {
+		t.Fatalf("get refresh token: %v", err)
+	}
+	return token.RevokedAt != nil
+}
+
+func (f *authFixture) activeSessions(t *testing.T, userID models.ID) []*models.RefreshToken {
+	t.Helper()
+
+	tokens, err := f.refreshTokens.GetByUserID(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("list refresh tokens: %v", err)
+	}
+	return tokens
+}
+
+func (f *authFixture) saveOTP(t *testing.T, otp *models.TwoFactorOTP) {
+	t.Helper()
+
+	if err := f.otps.Save(context.Background(), otp); err != nil {
+		t.Fatalf("save otp: %v", err)
+	}
+}
+
+func TestConfirmPasswordReset_CommitsAllWrites(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+	f.seedResetToken(t, user.ID, "reset-token", nil)
+
+	if err := f.svc.ConfirmPasswordReset(context.Background(), "reset-token", "NewPassw0rd"); err != nil {
+		t.Fatalf("ConfirmPasswordReset() error = %v", err)
+	}
+
+	if got := f.password(t, user.ID); got != "hashed:NewPassw0rd" {
+		t.Errorf("password = %q, want updated hash", got)
+	}
+
+	if !f.resetUsed(t, "reset-token") {
+		t.Error("reset token should be marked used")
+	}
+
+	if events := f.outbox.All(); len(events) != 1 || events[0].Type != eventModels.PasswordChanged {
+		t.Errorf("events = %+v, want one %s", events, eventModels.PasswordChanged)
+	}
+
+	if f.tx.commits != 1 || f.tx.rollbacks != 0 {
+		t.Errorf("commits = %d, rollbacks = %d, want 1/0", f.tx.commits, f.tx.rollbacks)
+	}
+
+	if f.tx.opts[0].Isolation != sharedPorts.IsolationSerializable {
+		t.Errorf("isolation = %q, want serializable", f.tx.opts[0].Isolation)
+	}
+}
+
+func TestConfirmPasswordReset_RollsBackPasswordWhenMarkUsedFails(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+	f.seedResetToken(t, user.ID, "reset-token", nil)
+	f.resetTokens.markUsedErr = stdErrors.New("connection reset")
+
+	err := f.svc.ConfirmPasswordReset(context.Background(), "reset-token", "NewPassw0rd")
+	if !errors.IsKind(err, errors.ErrInternal) {
+		t.Fatalf("ConfirmPasswordReset() error = %v, want internal", err)
+	}
+
+	if got := f.password(t, user.ID); got != "hashed:OldPassw0rd" {
+		t.Errorf("password = %q, want old hash after rollback", got)
+	}
+
+	if f.resetUsed(t, "reset-token") {
+		t.Error("reset token should stay unused after rollback")
+	}
+
+	if len(f.outbox.All()) != 0 {
+		t.Errorf("events = %d, want none after rollback", len(f.outbox.All()))
+	}
+
+	if f.tx.rollbacks != 1 {
+		t.Errorf("rollbacks = %d, want 1", f.tx.rollbacks)
+	}
+}
+
+func TestConfirmPasswordReset_RollsBackWhenPublishFails(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+	f.seedResetToken(t, user.ID, "reset-token", nil)
+	f.outbox.err = stdErrors.New("outbox unavailable")
+
+	if err := f.svc.ConfirmPasswordReset(context.Background(), "reset-token", "NewPassw0rd"); err == nil {
+		t.Fatal("ConfirmPasswordReset() error = nil, want error")
+	}
+
+	if got := f.password(t, user.ID); got != "hashed:OldPassw0rd" {
+		t.Errorf("password = %q, want old hash after rollback", got)
+	}
+
+	if f.resetUsed(t, "reset-token") {
+		t.Error("reset token should stay unused after rollback")
+	}
+}
+
+func TestConfirmPasswordReset_RejectsUsedToken(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+	f.seedResetToken(t, user.ID, "reset-token", nil)
+
+	if err := f.svc.ConfirmPasswordReset(context.Background(), "reset-token", "NewPassw0rd"); err != nil {
+		t.Fatalf("first ConfirmPasswordReset() error = %v", err)
+	}
+
+	err := f.svc.ConfirmPasswordReset(context.Background(), "reset-token", "An0therPass")
+	if !errors.IsKind(err, errors.ErrUnauthorized) {
+		t.Fatalf("second ConfirmPasswordReset() error = %v, want unauthorized", err)
+	}
+
+	if got := f.password(t, user.ID); got != "hashed:NewPassw0rd" {
+		t.Errorf("password = %q, want first reset to stick", got)
+	}
+}
+
+func TestRegister_RollsBackUserWhenPublishFails(t *testing.T) {
+	f := newAuthFixture()
+	f.outbox.err = stdErrors.New("outbox unavailable")
+
+	if _, err := f.svc.Register(context.Background(), "siti@mail.com", "Passw0rdku", "Siti"); err == nil {
+		t.Fatal("Register() error = nil, want error")
+	}
+
+	if exists, _ := f.users.Exist(context.Background(), "siti@mail.com"); exists {
+		t.Error("user should not exist after rollback")
+	}
+}
+
+func TestRefreshToken_RollsBackNewTokenWhenRevokeFails(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+
+	raw, _ := f.jwt.GenerateRefreshToken(user)
+	old := models.NewRefreshToken(user.ID, "sha:"+raw, time.Hour, f.clock.Now())
+	f.seedRefreshToken(t, old)
+	f.refreshTokens.revokeErr = stdErrors.New("connection reset")
+
+	if _, _, err := f.svc.RefreshToken(context.Background(), raw); err == nil {
+		t.Fatal("RefreshToken() error = nil, want error")
+	}
+
+	// sesi yang aktif hanya token asli: token baru ikut di-rollback dan token lama tidak dicabut
+	if sessions := f.activeSessions(t, user.ID); len(sessions) != 1 || sessions[0].ID != old.ID {
+		t.Errorf("active sessions = %+v, want only the original after rollback", sessions)
+	}
+}
+
+func TestRefreshToken_RotatesOnce(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+
+	raw, _ := f.jwt.GenerateRefreshToken(user)
+	old := models.NewRefreshToken(user.ID, "sha:"+raw, time.Hour, f.clock.Now())
+	f.seedRefreshToken(t, old)
+
+	if _, _, err := f.svc.RefreshToken(context.Background(), raw); err != nil {
+		t.Fatalf("RefreshToken() error = %v", err)
+	}
+
+	if !f.refreshRevoked(t, raw) {
+		t.Error("original refresh token should be revoked")
+	}
+
+	_, _, err := f.svc.RefreshToken(context.Background(), raw)
+	if !errors.IsKind(err, errors.ErrUnauthorized) {
+		t.Fatalf("reused RefreshToken() error = %v, want unauthorized", err)
+	}
+}
+
+func TestRefreshToken_ReadsRoleAndLocaleFromDatabase(t *testing.T) {
+	f := newAuthFixture()
+	admin := models.NewUser("admin@mail.com", "hashed:Adm1nPassword", "Admin", f.clock.Now())
+	admin.Role = models.RoleAdmin
+	if err := f.users.Save(context.Background(), admin); err != nil {
+		t.Fatalf("save user: %v", err)
+	}
+
+	raw, _ := f.jwt.GenerateRefreshToken(admin)
+	f.seedRefreshToken(t, models.NewRefreshToken(admin.ID, "sha:"+raw, time.Hour, f.clock.Now()))
+
+	// admin diturunkan jadi user biasa dan mengganti locale setelah token diterbitkan
+	demoted := *admin
+	demoted.Role = models.RoleUser
+	if err := f.users.Delete(context.Background(), admin.ID.String()); err != nil {
+		t.Fatalf("delete user: %v", err)
+	}
+	if err := f.users.Save(context.Background(), &demoted); err != nil {
+		t.Fatalf("save user: %v", err)
+	}
+	if err := f.users.UpdateLocale(context.Background(), admin.ID, i18n.Locale("id")); err != nil {
+		t.Fatalf("update locale: %v", err)
+	}
+
+	access, refresh, err := f.svc.RefreshToken(context.Background(), raw)
+	if err != nil {
+		t.Fatalf("RefreshToken() error = %v", err)
+	}
+
+	for _, token := range []string{access, refresh} {
+		if got := f.jwt.issued[token]; got.IsAdmin() || got.Locale != "id" {
+			t.Errorf("token claims = role %s locale %s, want the demoted user with locale id", got.Role, got.Locale)
+		}
+	}
+}
+
+func TestVerifyLogin2FA_MarksOTPUsedAtomically(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+
+	otp := &models.TwoFactorOTP{
+		ID:          models.NewID(),
+		UserID:      user.ID,
+		ChallengeID: "challenge",
+		CodeHash:    "sha:123456",
+		ExpiresAt:   f.clock.Now().Add(time.Minute),
+		CreatedAt:   f.clock.Now(),
+	}
+	f.saveOTP(t, otp)
+
+	if _, _, _, err := f.svc.VerifyLogin2FA(context.Background(), "challenge", "123456", false); err != nil {
+		t.Fatalf("VerifyLogin2FA() error = %v", err)
+	}
+
+	if used, _ := f.otps.GetByChallengeID(context.Background(), "challenge"); used.UsedAt == nil {
+		t.Error("otp should be marked used")
+	}
+
+	if sessions := f.activeSessions(t, user.ID); len(sessions) != 1 {
+		t.Errorf("refresh tokens = %d, want 1", len(sessions))
+	}
+
+	_, _, _, err := f.svc.VerifyLogin2FA(context.Background(), "challenge", "123456", false)
+	if !errors.IsKind(err, errors.ErrUnauthorized) {
+		t.Fatalf("reused VerifyLogin2FA() error = %v, want unauthorized", err)
+	}
+
+	if f.metrics.twoFactor["verify:true"] != 1 || f.metrics.twoFactor["verify:false"] != 1 {
+		t.Errorf("two factor = %v, want one verified and one rejected", f.metrics.twoFactor)
+	}
+}
+
+func TestConfirmPasswordReset_ExpiresAtTTL(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+	f.seedResetToken(t, user.ID, "early", nil)
+	f.seedResetToken(t, user.ID, "late", nil)
+
+	f.clock.Advance(time.Hour - time.Second)
+	if err := f.svc.ConfirmPasswordReset(context.Background(), "early", "NewPassw0rd"); err != nil {
+		t.Fatalf("ConfirmPasswordReset() before expiry error = %v", err)
+	}
+
+	// tepat di ExpiresAt token sudah tidak berlaku
+	f.clock.Advance(time.Second)
+	err := f.svc.ConfirmPasswordReset(context.Background(), "late", "NewerPassw0rd")
+	if !errors.IsKind(err, errors.ErrUnauthorized) {
+		t.Fatalf("ConfirmPasswordReset() at expiry error = %v, want unauthorized", err)
+	}
+}
+
+func TestVerifyLogin2FA_RejectsExpiredOTP(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+
+	otp := &models.TwoFactorOTP{
+		ID:          models.NewID(),
+		UserID:      user.ID,
+		ChallengeID: "challenge",
+		CodeHash:    "sha:123456",
+		ExpiresAt:   f.clock.Now().Add(5 * time.Minute),
+		CreatedAt:   f.clock.Now(),
+	}
+	f.saveOTP(t, otp)
+
+	f.clock.Advance(5*time.Minute + time.Second)
+	_, _, _, err := f.svc.VerifyLogin2FA(context.Background(), "challenge", "123456", false)
+	if !errors.IsKind(err, errors.ErrUnauthorized) {
+		t.Fatalf("VerifyLogin2FA() error = %v, want unauthorized", err)
+	}
+
+	if stored, _ := f.otps.GetByChallengeID(context.Background(), "challenge"); stored.UsedAt != nil || len(f.activeSessions(t, user.ID)) != 0 {
+		t.Error("expired otp must not be consumed or issue a session")
+	}
+}
+
+func TestRefreshToken_RejectsExpiredToken(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+
+	raw, _ := f.jwt.GenerateRefreshToken(user)
+	f.seedRefreshToken(t, models.NewRefreshToken(user.ID, "sha:"+raw, time.Hour, f.clock.Now()))
+
+	f.clock.Advance(time.Hour + time.Second)
+	_, _, err := f.svc.RefreshToken(context.Background(), raw)
+	if !errors.IsKind(err, errors.ErrUnauthorized) {
+		t.Fatalf("RefreshToken() error = %v, want unauthorized", err)
+	}
+}
+
+func TestLogin_ObservesMetrics(t *testing.T) {
+	f := newAuthFixture()
+	f.seedUser(t)
+
+	if _, _, _, err := f.svc.Login(context.Background(), "budi@mail.com", "OldPassw0rd", false); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	if _, _, _, err := f.svc.Login(context.Background(), "budi@mail.com", "WrongPassw0rd", false); !errors.IsKind(err, errors.ErrUnauthorized) {
+		t.Fatalf("Login() error = %v, want unauthorized", err)
+	}
+
+	if f.metrics.logins["password:true"] != 1 || f.metrics.logins["password:false"] != 1 {
+		t.Errorf("logins = %v, want one success and one failure", f.metrics.logins)
+	}
+}
+
+func TestRegister_UsesRequestLocale(t *testing.T) {
+	f := newAuthFixture()
+	ctx := i18n.WithLocale(context.Background(), i18n.ID)
+
+	user, err := f.svc.Register(ctx, "sari@mail.com", "Passw0rdKuat", "Sari")
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	if stored, _ := f.users.GetByID(ctx, user.ID.String()); stored.Locale != i18n.ID {
+		t.Errorf("locale = %q, want %q", stored.Locale, i18n.ID)
+	}
+}
+
+func TestRequestPasswordReset_SendsInUserLocale(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+
+	if err := f.svc.UpdateLocale(context.Background(), user.ID.String(), "id-ID"); err != nil {
+		t.Fatalf("UpdateLocale() error = %v", err)
+	}
+
+	// bahasa request (Inggris) tidak boleh mengalahkan pilihan user untuk email
+	ctx := i18n.WithLocale(context.Background(), i18n.EN)
+	if err := f.svc.RequestPasswordReset(ctx, user.Email); err != nil {
+		t.Fatalf("RequestPasswordReset() error = %v", err)
+	}
+
+	if len(f.emails.locales) != 1 || f.emails.locales[0] != i18n.ID {
+		t.Errorf("email locales = %v, want [id]", f.emails.locales)
+	}
+}
+
+func TestUpdateLocale_RejectsUnsupported(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+
+	err := f.svc.UpdateLocale(context.Background(), user.ID.String(), "fr")
+	if appErr, ok := errors.AsAppError(err); !ok || appErr.Code != errors.CodeUnsupportedLocale {
+		t.Fatalf("UpdateLocale() error = %v, want UNSUPPORTED_LOCALE", err)
+	}
+}
+
+func TestCreateUser_Admin(t *testing.T) {
+	f := newAuthFixture()
+
+	user, err := f.svc.CreateUser(context.Background(), "admin@mail.com", "Passw0rdKuat", "Admin", models.RoleAdmin)
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	if got, _ := f.users.GetByID(context.Background(), user.ID.String()); !got.IsAdmin() || got.Password != "hashed:Passw0rdKuat" {
+		t.Errorf("user = %+v, want hashed admin", got)
+	}
+
+	if events := f.outbox.All(); len(events) != 1 || events[0].Type != eventModels.UserRegistered {
+		t.Errorf("events = %v, want one user registered", events)
+	}
+
+	if _, err := f.svc.CreateUser(context.Background(), "root@mail.com", "Passw0rdKuat", "Root", "root"); !errors.IsKind(err, errors.ErrValidation) {
+		t.Errorf("CreateUser() invalid role error = %v, want validation", err)
+	}
+}
+
+func TestPurgeExpiredTokens(t *testing.T) {
+	f := newAuthFixture()
+	user := f.seedUser(t)
+
+	expired := models.NewRefreshToken(user.ID, "sha:old", -time.Hour, f.clock.Now())
+	active := models.NewRefreshToken(user.ID, "sha:new", time.Hour, f.clock.Now())
+	f.seedRefreshToken(t, expired)
+	f.seedRefreshToken(t, active)
+
+	now := f.clock.Now()
+	f.seedResetToken(t, user.ID, "used", &now)
+	f.seedResetToken(t, user.ID, "fresh", nil)
+
+	result, err := f.svc.PurgeExpiredTokens(context.Background())
+	if err != nil {
+		t.Fatalf("PurgeExpiredTokens() error = %v", err)
+	}
+
+	if result.RefreshTokens != 1 || result.PasswordResetTokens != 1 || result.TwoFactorOTPs != 0 {
+		t.Errorf("result = %+v, want 1 refresh token and 1 reset token", result)
+	}
+
+	if sessions := f.activeSessions(t, user.ID); len(sessions) != 1 || sessions[0].ID != active.ID {
+		t.Errorf("active sessions = %+v, want the unexpired refresh token kept", sessions)
+	}
+	if f.resetUsed(t, "fresh") {
+		t.Error("fresh reset token should be kept unused")
+	}
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"villainrsty-ecommerce-server/internal/core/auth/ports"
+	eventModels "villainrsty-ecommerce-server/internal/core/events/models"
+	eventPorts "villainrsty-ecommerce-server/internal/core/events/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+// NewRevokeSessionsHandler mencabut semua refresh token aktif milik user setelah password berubah,
+// supaya sesi lama (yang mungkin dipakai penyerang) tidak bisa dipakai lagi
+func NewRevokeSessionsHandler(refreshTokenRepo ports.RefreshTokenRepository) eventPorts.EventHandler {
+	return func(ctx context.Context, event *models.DomainEvent) error {
+		var payload eventModels.PasswordChangedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return errors.Wrap(errors.ErrInternal, "failed to decode password changed event", err)
+		}
+
+		tokens, err := refreshTokenRepo.GetByUserID(ctx, models.ID(payload.UserID))
+		if err != nil {
+			return err
+		}
+
+		for _, token := range tokens {
+			if err := refreshTokenRepo.Revoke(ctx, token.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+type (
+	EmailService interface {
+		// Send mencatat email di log lalu mengantrikan pengirimannya (implementasi auth ports.EmailSender)
+		Send(ctx context.Context, templateID, toEmail string, data map[string]any) error
+		// Deliver dipanggil worker untuk satu attempt pengiriman, final kalau job tidak akan di-retry lagi
+		Deliver(ctx context.Context, id models.ID, final bool) error
+		List(ctx context.Context, status models.EmailStatus, page, limit int) ([]*models.EmailMessage, int64, error)
+		Get(ctx context.Context, id models.ID) (*models.EmailMessage, error)
+		// Resend hanya untuk pesan berstatus failed yang datanya belum dihapus
+		Resend(ctx context.Context, id models.ID) error
+		// PurgeData mengosongkan data template pesan yang sudah melewati masa retensi
+		PurgeData(ctx context.Context) (int64, error)
+	}
+)
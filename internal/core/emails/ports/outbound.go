@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+type (
+	EmailMessageRepository interface {
+		Save(ctx context.Context, msg *models.EmailMessage) error
+		GetByID(ctx context.Context, id models.ID) (*models.EmailMessage, error)
+		List(ctx context.Context, status models.EmailStatus, limit, offset int) ([]*models.EmailMessage, error)
+		Count(ctx context.Context, status models.EmailStatus) (int64, error)
+		// RecordAttempt menaikkan attempts dan menyimpan hasil attempt terakhir, data dikosongkan kalau sent
+		RecordAttempt(ctx context.Context, id models.ID, status models.EmailStatus, providerMessageID, lastError string) error
+		// Requeue mengembalikan pesan failed ke queued, ErrConflict untuk status lain atau data yang sudah dihapus
+		Requeue(ctx context.Context, id models.ID) error
+		// PurgeData mengosongkan data template pesan yang dibuat sebelum createdBefore
+		PurgeData(ctx context.Context, createdBefore time.Time) (int64, error)
+	}
+
+	// EmailTransport mengirim satu email (SMTP, mailbox lokal, log) dan mengembalikan
+	// message ID dari provider kalau ada
+	EmailTransport interface {
+		Deliver(ctx context.Context, msg *models.EmailMessage) (string, error)
+	}
+)
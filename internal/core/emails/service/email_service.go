@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/emails/ports"
+	jobPorts "villainrsty-ecommerce-server/internal/core/jobs/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+	"villainrsty-ecommerce-server/pkg/i18n"
+	"villainrsty-ecommerce-server/pkg/logger"
+)
+
+// JobDeliverEmail job untuk satu attempt pengiriman, retry dan backoff diurus job queue
+const JobDeliverEmail = "email.deliver"
+
+type DeliverPayload struct {
+	MessageID string `json:"message_id"`
+}
+
+type EmailService struct {
+	repo      ports.EmailMessageRepository
+	queue     jobPorts.JobQueue
+	txManager sharedPorts.TxManager
+	clock     sharedPorts.Clock
+	transport ports.EmailTransport
+	logger    *slog.Logger
+	// retention berapa lama data template (link reset, kode OTP) disimpan untuk pesan yang belum terkirim
+	retention time.Duration
+}
+
+func NewEmailService(
+	repo ports.EmailMessageRepository,
+	queue jobPorts.JobQueue,
+	txManager sharedPorts.TxManager,
+	clock sharedPorts.Clock,
+	transport ports.EmailTransport,
+	logger *slog.Logger,
+	retention time.Duration,
+) *EmailService {
+	return &EmailService{
+		repo:      repo,
+		queue:     queue,
+		txManager: txManager,
+		clock:     clock,
+		transport: transport,
+		logger:    logger,
+		retention: retention,
+	}
+}
+
+// Send memakai locale dari ctx. Kalau dipanggil di dalam transaksi, pesan dan job-nya ikut
+// transaksi tersebut sehingga tidak ada email tanpa data (atau sebaliknya).
+func (s *EmailService) Send(ctx context.Context, templateID, toEmail string, data map[string]any) error {
+	if toEmail == "" {
+		return errors.New(errors.ErrValidation, "email is required")
+	}
+
+	if templateID == "" {
+		return errors.New(errors.ErrValidation, "email template is required")
+	}
+
+	msg := models.NewEmailMessage(templateID, toEmail, i18n.FromContext(ctx), data, s.clock.Now())
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Save(ctx, msg); err != nil {
+			return err
+		}
+
+		_, err := s.queue.Enqueue(ctx, JobDeliverEmail, DeliverPayload{MessageID: msg.ID.String()})
+		return err
+	})
+}
+
+func (s *EmailService) Deliver(ctx context.Context, id models.ID, final bool) error {
+	msg, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// job bisa jalan ulang setelah worker crash, email yang sudah terkirim jangan dikirim lagi
+	if msg.Status == models.EmailStatusSent {
+		return nil
+	}
+
+	// link dan kode di dalamnya sudah kedaluwarsa, tidak ada gunanya dikirim
+	if msg.DataPurgedAt != nil {
+		err := errors.New(errors.ErrValidation, "email data has been purged")
+		if recordErr := s.repo.RecordAttempt(context.WithoutCancel(ctx), id, models.EmailStatusFailed, "", err.Error()); recordErr != nil {
+			s.log(ctx).Error("failed to record email attempt", "message_id", id.String(), "error", recordErr)
+		}
+		return err
+	}
+
+	providerID, sendErr := s.transport.Deliver(ctx, msg)
+
+	status, lastError := models.EmailStatusSent, ""
+	switch {
+	// data yang salah tidak akan berhasil walau di-retry, lihat queue.permanentOnValidation
+	case sendErr != nil && (final || errors.IsKind(sendErr, errors.ErrValidation)):
+		status, lastError = models.EmailStatusFailed, sendErr.Error()
+	case sendErr != nil:
+		status, lastError = models.EmailStatusRetrying, sendErr.Error()
+	}
+
+	// state disimpan walau ctx job sudah timeout
+	if err := s.repo.RecordAttempt(context.WithoutCancel(ctx), id, status, providerID, lastError); err != nil {
+		s.log(ctx).Error("failed to record email attempt", "message_id", id.String(), "error", err)
+	}
+
+	if sendErr != nil {
+		s.log(ctx).Warn("email delivery failed", "message_id", id.String(), "template", msg.Template, "attempt", msg.Attempts+1, "error", sendErr)
+		return sendErr
+	}
+
+	s.log(ctx).Info("email delivered", "message_id", id.String(), "template", msg.Template, "provider_message_id", providerID)
+	return nil
+}
+
+func (s *EmailService) List(ctx context.Context, status models.EmailStatus, page, limit int) ([]*models.EmailMessage, int64, error) {
+	if !status.IsValid() {
+		return nil, 0, errors.New(errors.ErrValidation, "invalid email status")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 20
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	msgs, err := s.repo.List(ctx, status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.Count(ctx, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return msgs, total, nil
+}
+
+func (s *EmailService) Get(ctx context.Context, id models.ID) (*models.EmailMessage, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// Resend mengantrikan ulang pesan failed dengan template, data dan locale aslinya. Pesan yang
+// masih queued/retrying sudah punya job, mengantrikan lagi bisa membuat email terkirim dua kali.
+func (s *EmailService) Resend(ctx context.Context, id models.ID) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+
+	msg, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if msg.Status != models.EmailStatusFailed {
+		return errors.New(errors.ErrConflict, "only failed email messages can be resent")
+	}
+
+	if msg.DataPurgedAt != nil {
+		return errors.New(errors.ErrConflict, "email data has expired and been purged, the message cannot be resent")
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Requeue(ctx, id); err != nil {
+			return err
+		}
+
+		_, err := s.queue.Enqueue(ctx, JobDeliverEmail, DeliverPayload{MessageID: id.String()})
+		return err
+	})
+}
+
+// PurgeData mengosongkan data pesan yang lebih tua dari retention. Pesan yang terkirim sudah
+// dikosongkan saat RecordAttempt, ini untuk pesan yang gagal atau tidak pernah terkirim.
+func (s *EmailService) PurgeData(ctx context.Context) (int64, error) {
+	purged, err := s.repo.PurgeData(ctx, s.clock.Now().Add(-s.retention))
+	if err != nil {
+		return 0, err
+	}
+
+	s.log(ctx).Info("email message data purged", "email_messages", purged)
+	return purged, nil
+}
+
+func (s *EmailService) log(ctx context.Context) *slog.Logger {
+	return logger.FromContext(ctx, s.logger)
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/memory"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/clock"
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+type fakeQueue struct {
+	payloads []DeliverPayload
+}
+
+func (q *fakeQueue) Enqueue(_ context.Context, jobType string, payload any) (*models.Job, error) {
+	q.payloads = append(q.payloads, payload.(DeliverPayload))
+	return models.NewJob(jobType, nil, 1, time.Now()), nil
+}
+
+type fakeTransport struct {
+	err   error
+	calls int
+}
+
+func (t *fakeTransport) Deliver(context.Context, *models.EmailMessage) (string, error) {
+	t.calls++
+	if t.err != nil {
+		return "", t.err
+	}
+
+	return "<abc@villainrsty.com>", nil
+}
+
+func newTestService(transport *fakeTransport) (*EmailService, *memory.EmailMessageRepository, *fakeQueue) {
+	svc, repo, queue, _ := newTestServiceWithClock(transport)
+	return svc, repo, queue
+}
+
+func newTestServiceWithClock(transport *fakeTransport) (*EmailService, *memory.EmailMessageRepository, *fakeQueue, *clock.Fake) {
+	clk := clock.NewFake(time.Now())
+	repo := memory.NewEmailMessageRepository(clk)
+	queue := &fakeQueue{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return NewEmailService(repo, queue, memory.NewTxManager(), clk, transport, logger, 30*time.Minute), repo, queue, clk
+}
+
+func getMessage(t *testing.T, repo *memory.EmailMessageRepository, id models.ID) *models.EmailMessage {
+	t.Helper()
+
+	msg, err := repo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	return msg
+}
+
+func TestSend_RecordsMessageAndEnqueuesDelivery(t *testing.T) {
+	svc, repo, queue := newTestService(&fakeTransport{})
+
+	ctx := i18n.WithLocale(context.Background(), i18n.ID)
+	if err := svc.Send(ctx, "login_otp", "budi@mail.com", map[string]any{"code": "123456"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if len(queue.payloads) != 1 {
+		t.Fatalf("enqueued = %d, want 1", len(queue.payloads))
+	}
+
+	msg := getMessage(t, repo, models.ID(queue.payloads[0].MessageID))
+	if msg.Status != models.EmailStatusQueued || msg.Locale != i18n.ID || msg.Template != "login_otp" {
+		t.Fatalf("message = %+v", msg)
+	}
+}
+
+func TestDeliver_RecordsEachAttempt(t *testing.T) {
+	transport := &fakeTransport{err: stdErrors.New("connection refused")}
+	svc, repo, queue := newTestService(transport)
+	ctx := context.Background()
+
+	_ = svc.Send(ctx, "login_otp", "budi@mail.com", map[string]any{"code": "123456"})
+	id := models.ID(queue.payloads[0].MessageID)
+
+	if err := svc.Deliver(ctx, id, false); err == nil {
+		t.Fatal("Deliver with failing transport returned nil, want error for job retry")
+	}
+	if msg := getMessage(t, repo, id); msg.Status != models.EmailStatusRetrying || msg.Attempts != 1 || msg.LastError != "connection refused" {
+		t.Fatalf("after failure = %+v", msg)
+	}
+
+	transport.err = nil
+	if err := svc.Deliver(ctx, id, false); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+	if msg := getMessage(t, repo, id); msg.Status != models.EmailStatusSent || msg.Attempts != 2 || msg.ProviderMessageID != "<abc@villainrsty.com>" {
+		t.Fatalf("after success = %+v", msg)
+	}
+
+	// kode OTP tidak disimpan lagi setelah terkirim
+	if msg := getMessage(t, repo, id); len(msg.Data) != 0 || msg.DataPurgedAt == nil {
+		t.Fatalf("data after success = %v, purged at %v, want emptied", msg.Data, msg.DataPurgedAt)
+	}
+
+	// job yang jalan ulang tidak boleh mengirim email dua kali
+	if err := svc.Deliver(ctx, id, false); err != nil || transport.calls != 2 {
+		t.Fatalf("redeliver err = %v, transport calls = %d, want 2", err, transport.calls)
+	}
+}
+
+func TestDeliver_MarksFailedWhenJobGivesUp(t *testing.T) {
+	tests := map[string]struct {
+		err   error
+		final bool
+	}{
+		"final attempt":    {err: stdErrors.New("connection refused"), final: true},
+		"validation error": {err: errors.New(errors.ErrValidation, "unknown email template: nope")},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, repo, queue := newTestService(&fakeTransport{err: tt.err})
+			ctx := context.Background()
+
+			_ = svc.Send(ctx, "login_otp", "budi@mail.com", map[string]any{"code": "123456"})
+			id := models.ID(queue.payloads[0].MessageID)
+
+			if err := svc.Deliver(ctx, id, tt.final); err == nil {
+				t.Fatal("Deliver returned nil, want transport error")
+			}
+			if msg := getMessage(t, repo, id); msg.Status != models.EmailStatusFailed {
+				t.Fatalf("status = %s, want failed", msg.Status)
+			}
+		})
+	}
+}
+
+func TestResend_RequeuesOnlyFailedMessage(t *testing.T) {
+	transport := &fakeTransport{err: stdErrors.New("connection refused")}
+	svc, repo, queue := newTestService(transport)
+	ctx := context.Background()
+
+	_ = svc.Send(ctx, "login_otp", "budi@mail.com", map[string]any{"code": "123456"})
+	id := models.ID(queue.payloads[0].MessageID)
+
+	// job masih akan di-retry, resend akan membuat job kedua
+	_ = svc.Deliver(ctx, id, false)
+	if err := svc.Resend(ctx, id); !errors.IsKind(err, errors.ErrConflict) {
+		t.Fatalf("Resend retrying err = %v, want conflict", err)
+	}
+
+	_ = svc.Deliver(ctx, id, true)
+	if err := svc.Resend(ctx, id); err != nil {
+		t.Fatalf("Resend: %v", err)
+	}
+	if msg := getMessage(t, repo, id); len(queue.payloads) != 2 || msg.Status != models.EmailStatusQueued || msg.LastError != "" {
+		t.Fatalf("payloads = %v, message = %+v", queue.payloads, msg)
+	}
+
+	transport.err = nil
+	_ = svc.Deliver(ctx, id, false)
+	if err := svc.Resend(ctx, id); !errors.IsKind(err, errors.ErrConflict) {
+		t.Fatalf("Resend sent err = %v, want conflict", err)
+	}
+
+	if err := svc.Resend(ctx, models.NewID()); !errors.IsKind(err, errors.ErrNotFound) {
+		t.Fatalf("Resend unknown err = %v, want not found", err)
+	}
+}
+
+func TestPurgeData_EmptiesExpiredMessages(t *testing.T) {
+	transport := &fakeTransport{err: stdErrors.New("connection refused")}
+	svc, repo, queue, clk := newTestServiceWithClock(transport)
+	ctx := context.Background()
+
+	_ = svc.Send(ctx, "password_reset", "budi@mail.com", map[string]any{"link": "http://localhost/reset?token=abc"})
+	old := models.ID(queue.payloads[0].MessageID)
+	_ = svc.Deliver(ctx, old, true)
+
+	clk.Advance(time.Hour)
+	_ = svc.Send(ctx, "login_otp", "budi@mail.com", map[string]any{"code": "123456"})
+	fresh := models.ID(queue.payloads[1].MessageID)
+
+	purged, err := svc.PurgeData(ctx)
+	if err != nil || purged != 1 {
+		t.Fatalf("PurgeData = %d, %v, want 1", purged, err)
+	}
+	if msg := getMessage(t, repo, old); len(msg.Data) != 0 || msg.DataPurgedAt == nil {
+		t.Fatalf("old message = %+v, want data purged", msg)
+	}
+	if msg := getMessage(t, repo, fresh); msg.Data["code"] != "123456" {
+		t.Fatalf("fresh message data = %v, want kept", msg.Data)
+	}
+
+	// link reset yang kedaluwarsa tidak boleh dikirim ulang
+	if err := svc.Resend(ctx, old); !errors.IsKind(err, errors.ErrConflict) {
+		t.Fatalf("Resend purged err = %v, want conflict", err)
+	}
+
+	clk.Advance(time.Hour)
+	_, _ = svc.PurgeData(ctx)
+	transport.err = nil
+	if err := svc.Deliver(ctx, fresh, false); !errors.IsKind(err, errors.ErrValidation) || transport.calls != 1 {
+		t.Fatalf("Deliver purged err = %v, transport calls = %d, want validation error without sending", err, transport.calls)
+	}
+	if msg := getMessage(t, repo, fresh); msg.Status != models.EmailStatusFailed {
+		t.Fatalf("status = %s, want failed", msg.Status)
+	}
+}
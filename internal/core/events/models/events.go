@@ -0,0 +1,31 @@
+package models
+
+const (
+	AggregateUser  = "user"
+	AggregateOrder = "order"
+)
+
+const (
+	UserRegistered  = "user.registered"
+	PasswordChanged = "user.password_changed"
+	OrderPlaced     = "order.placed"
+)
+
+type (
+	UserRegisteredPayload struct {
+		UserID string `json:"user_id"`
+		Email  string `json:"email"`
+		Name   string `json:"name"`
+	}
+
+	PasswordChangedPayload struct {
+		UserID string `json:"user_id"`
+		Reason string `json:"reason"`
+	}
+
+	OrderPlacedPayload struct {
+		OrderID string `json:"order_id"`
+		UserID  string `json:"user_id"`
+		Total   int64  `json:"total"`
+	}
+)
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+type (
+	// EventPublisher menulis event ke outbox, harus dipanggil di dalam transaksi yang sama
+	// dengan perubahan data supaya event dan data selalu konsisten
+	EventPublisher interface {
+		Publish(ctx context.Context, events ...*models.DomainEvent) error
+	}
+
+	EventHandler func(ctx context.Context, event *models.DomainEvent) error
+
+	EventSubscriber interface {
+		Subscribe(eventType string, handler EventHandler)
+	}
+)
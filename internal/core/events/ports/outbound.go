@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+type (
+	OutboxRepository interface {
+		Save(ctx context.Context, event *models.DomainEvent) error
+		ClaimUnpublished(ctx context.Context, now time.Time, limit, maxAttempts int) ([]*models.DomainEvent, error)
+		MarkPublished(ctx context.Context, id models.ID, at time.Time) error
+		MarkFailed(ctx context.Context, id models.ID, lastError string, retryAt time.Time) error
+	}
+)
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"sync"
+
+	"villainrsty-ecommerce-server/internal/core/events/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+// AllEvents dipakai untuk subscribe ke semua tipe event
+const AllEvents = "*"
+
+// Bus meneruskan event ke subscriber in-process
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]ports.EventHandler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]ports.EventHandler)}
+}
+
+func (b *Bus) Subscribe(eventType string, handler ports.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Dispatch memanggil semua handler untuk event, error dari tiap handler digabung
+func (b *Bus) Dispatch(ctx context.Context, event *models.DomainEvent) error {
+	b.mu.RLock()
+	handlers := append([]ports.EventHandler{}, b.handlers[event.Type]...)
+	handlers = append(handlers, b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return stdErrors.Join(errs...)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+func newTestEvent(t *testing.T, eventType string, occurredAt time.Time) *models.DomainEvent {
+	t.Helper()
+
+	event, err := models.NewDomainEvent(eventType, "user", models.NewID(), map[string]string{"email": "budi@mail.com"}, occurredAt)
+	if err != nil {
+		t.Fatalf("NewDomainEvent: %v", err)
+	}
+	return event
+}
+
+func TestBus_DispatchFansOutToTypeAndWildcardSubscribers(t *testing.T) {
+	bus := NewBus()
+	var calls []string
+	subscriber := func(name string) func(context.Context, *models.DomainEvent) error {
+		return func(context.Context, *models.DomainEvent) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+	bus.Subscribe("user.registered", subscriber("registered-1"))
+	bus.Subscribe("user.registered", subscriber("registered-2"))
+	bus.Subscribe("user.password_changed", subscriber("password"))
+	bus.Subscribe(AllEvents, subscriber("all"))
+
+	if err := bus.Dispatch(context.Background(), newTestEvent(t, "user.registered", time.Now())); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+
+	if want := []string{"registered-1", "registered-2", "all"}; len(calls) != len(want) || calls[0] != want[0] || calls[1] != want[1] || calls[2] != want[2] {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestBus_DispatchRunsEveryHandlerAndJoinsErrors(t *testing.T) {
+	bus := NewBus()
+	errA, errB := stdErrors.New("relay down"), stdErrors.New("cache down")
+	var ran int
+	bus.Subscribe("user.registered", func(context.Context, *models.DomainEvent) error { ran++; return errA })
+	bus.Subscribe("user.registered", func(context.Context, *models.DomainEvent) error { ran++; return nil })
+	bus.Subscribe(AllEvents, func(context.Context, *models.DomainEvent) error { ran++; return errB })
+
+	err := bus.Dispatch(context.Background(), newTestEvent(t, "user.registered", time.Now()))
+	if ran != 3 || !stdErrors.Is(err, errA) || !stdErrors.Is(err, errB) {
+		t.Errorf("ran = %d, err = %v, want all handlers run and both errors joined", ran, err)
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/events/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+	"villainrsty-ecommerce-server/pkg/logger"
+)
+
+type DispatcherOptions struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	// Clock sumber waktu untuk claim & jadwal retry, default jam sistem
+	Clock sharedPorts.Clock
+}
+
+// Dispatcher membaca event yang belum terkirim dari outbox lalu meneruskannya ke Bus.
+// Pengiriman bersifat at-least-once, subscriber harus idempotent.
+type Dispatcher struct {
+	repo      ports.OutboxRepository
+	txManager sharedPorts.TxManager
+	bus       *Bus
+	opts      DispatcherOptions
+	logger    *slog.Logger
+}
+
+func NewDispatcher(repo ports.OutboxRepository, txManager sharedPorts.TxManager, bus *Bus, opts DispatcherOptions, logger *slog.Logger) *Dispatcher {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 50
+	}
+
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 10
+	}
+
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = 30 * time.Second
+	}
+
+	if opts.Clock == nil {
+		opts.Clock = sharedPorts.SystemClock
+	}
+
+	return &Dispatcher{
+		repo:      repo,
+		txManager: txManager,
+		bus:       bus,
+		opts:      opts,
+		logger:    logger,
+	}
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	d.logger.Info("outbox dispatcher started", "poll_interval", d.opts.PollInterval.String())
+
+	for {
+		n, err := d.DispatchBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.logger.Error("failed to dispatch outbox events", "error", err)
+		}
+
+		// Kalau batch penuh kemungkinan masih ada sisa, langsung lanjut tanpa menunggu
+		if err == nil && n == d.opts.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Info("outbox dispatcher stopped")
+			return
+		case <-time.After(d.opts.PollInterval):
+		}
+	}
+}
+
+// DispatchBatch memproses sampai BatchSize event, satu transaksi per event
+func (d *Dispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	for processed := 0; processed < d.opts.BatchSize; processed++ {
+		found, err := d.dispatchOne(ctx)
+		if err != nil || !found {
+			return processed, err
+		}
+	}
+
+	return d.opts.BatchSize, nil
+}
+
+// dispatchOne meneruskan satu event ke subscriber. Perubahan data yang dilakukan subscriber
+// ikut transaksi yang sama dengan penandaan published, jadi kalau subscriber gagal semuanya di-rollback.
+func (d *Dispatcher) dispatchOne(ctx context.Context) (bool, error) {
+	var (
+		found       bool
+		failed      *models.DomainEvent
+		deliveryErr error
+	)
+
+	err := d.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		events, err := d.repo.ClaimUnpublished(ctx, d.opts.Clock.Now(), 1, d.opts.MaxAttempts)
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		found = true
+		event := events[0]
+
+		// Subscriber mendapat logger yang sudah berisi event_id lewat ctx
+		handlerCtx := logger.WithContext(ctx, d.logger.With("event_id", event.ID.String(), "event_type", event.Type))
+		if err := d.bus.Dispatch(handlerCtx, event); err != nil {
+			failed, deliveryErr = event, err
+			return err
+		}
+
+		return d.repo.MarkPublished(ctx, event.ID, d.opts.Clock.Now())
+	})
+
+	if failed == nil {
+		return found, err
+	}
+
+	d.logger.Warn("outbox event delivery failed",
+		"event_id", failed.ID.String(),
+		"type", failed.Type,
+		"attempts", failed.Attempts+1,
+		"error", deliveryErr,
+	)
+
+	retryAt := d.opts.Clock.Now().Add(d.opts.RetryBackoff * time.Duration(failed.Attempts+1))
+	if err := d.repo.MarkFailed(ctx, failed.ID, deliveryErr.Error(), retryAt); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
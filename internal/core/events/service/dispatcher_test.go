@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/memory"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/clock"
+)
+
+type dispatcherFixture struct {
+	dispatcher *Dispatcher
+	outbox     *memory.OutboxRepository
+	bus        *Bus
+	clock      *clock.Fake
+}
+
+func newDispatcherFixture(batchSize int) *dispatcherFixture {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	outbox := memory.NewOutboxRepository()
+	bus := NewBus()
+
+	return &dispatcherFixture{
+		dispatcher: NewDispatcher(outbox, memory.NewTxManager(), bus, DispatcherOptions{
+			BatchSize:    batchSize,
+			MaxAttempts:  2,
+			RetryBackoff: time.Minute,
+			Clock:        clk,
+		}, slog.New(slog.NewTextHandler(io.Discard, nil))),
+		outbox: outbox,
+		bus:    bus,
+		clock:  clk,
+	}
+}
+
+// publish menulis event ke outbox lewat publisher yang sama dengan service
+func (f *dispatcherFixture) publish(t *testing.T, eventType string) *models.DomainEvent {
+	t.Helper()
+
+	event := newTestEvent(t, eventType, f.clock.Now())
+	if err := NewOutboxPublisher(f.outbox).Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	f.clock.Advance(time.Second)
+	return event
+}
+
+func (f *dispatcherFixture) stored(t *testing.T, id models.ID) *models.DomainEvent {
+	t.Helper()
+
+	for _, event := range f.outbox.All() {
+		if event.ID == id {
+			return event
+		}
+	}
+	t.Fatalf("event %s not in outbox", id)
+	return nil
+}
+
+func TestDispatcher_DeliversInOrderAndMarksPublished(t *testing.T) {
+	f := newDispatcherFixture(10)
+	var got []models.ID
+	f.bus.Subscribe(AllEvents, func(_ context.Context, event *models.DomainEvent) error {
+		got = append(got, event.ID)
+		return nil
+	})
+	first, second := f.publish(t, "user.registered"), f.publish(t, "user.password_changed")
+
+	n, err := f.dispatcher.DispatchBatch(context.Background())
+	if err != nil || n != 2 {
+		t.Fatalf("DispatchBatch = %d, %v, want 2", n, err)
+	}
+
+	if len(got) != 2 || got[0] != first.ID || got[1] != second.ID {
+		t.Errorf("delivered = %v, want %s then %s", got, first.ID, second.ID)
+	}
+
+	for _, event := range f.outbox.All() {
+		if event.PublishedAt == nil {
+			t.Errorf("event %s not marked published", event.ID)
+		}
+	}
+
+	// event yang sudah terkirim tidak diantar lagi
+	if n, err := f.dispatcher.DispatchBatch(context.Background()); err != nil || n != 0 || len(got) != 2 {
+		t.Errorf("second batch = %d, %v, deliveries = %d, want nothing", n, err, len(got))
+	}
+}
+
+func TestDispatcher_StopsAtBatchSize(t *testing.T) {
+	f := newDispatcherFixture(2)
+	f.bus.Subscribe(AllEvents, func(context.Context, *models.DomainEvent) error { return nil })
+	for range 3 {
+		f.publish(t, "user.registered")
+	}
+
+	if n, _ := f.dispatcher.DispatchBatch(context.Background()); n != 2 {
+		t.Fatalf("first batch = %d, want 2", n)
+	}
+	if n, _ := f.dispatcher.DispatchBatch(context.Background()); n != 1 {
+		t.Fatalf("second batch = %d, want the remaining 1", n)
+	}
+}
+
+func TestDispatcher_FailedDeliveryRollsBackAndRetriesLater(t *testing.T) {
+	f := newDispatcherFixture(10)
+	jobs := memory.NewJobRepository(f.clock)
+	fail := true
+	f.bus.Subscribe(AllEvents, func(ctx context.Context, event *models.DomainEvent) error {
+		// perubahan subscriber ikut transaksi dispatcher
+		if err := jobs.Enqueue(ctx, models.NewJob("webhook.deliver", nil, 1, f.clock.Now())); err != nil {
+			return err
+		}
+		if fail {
+			return stdErrors.New("relay down")
+		}
+		return nil
+	})
+	event := f.publish(t, "user.registered")
+
+	if n, err := f.dispatcher.DispatchBatch(context.Background()); err != nil || n != 1 {
+		t.Fatalf("DispatchBatch = %d, %v, want the failed event counted", n, err)
+	}
+
+	got := f.stored(t, event.ID)
+	if got.PublishedAt != nil || got.Attempts != 1 || got.LastError != "relay down" {
+		t.Fatalf("event = %+v, want unpublished with one failed attempt", got)
+	}
+	if n, _ := jobs.CountByStatus(context.Background(), models.JobStatusPending); n != 0 {
+		t.Errorf("jobs = %d, want the subscriber write rolled back", n)
+	}
+
+	// belum waktunya retry (RetryBackoff * attempts)
+	if n, _ := f.dispatcher.DispatchBatch(context.Background()); n != 0 {
+		t.Fatalf("batch before backoff = %d, want 0", n)
+	}
+
+	f.clock.Advance(time.Minute)
+	fail = false
+	if n, err := f.dispatcher.DispatchBatch(context.Background()); err != nil || n != 1 {
+		t.Fatalf("retry batch = %d, %v, want 1", n, err)
+	}
+	if got := f.stored(t, event.ID); got.PublishedAt == nil {
+		t.Errorf("event = %+v, want published after retry", got)
+	}
+	if n, _ := jobs.CountByStatus(context.Background(), models.JobStatusPending); n != 1 {
+		t.Errorf("jobs = %d, want 1", n)
+	}
+}
+
+func TestDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	f := newDispatcherFixture(10)
+	var calls int
+	f.bus.Subscribe(AllEvents, func(context.Context, *models.DomainEvent) error {
+		calls++
+		return stdErrors.New("relay down")
+	})
+	event := f.publish(t, "user.registered")
+
+	for range 4 {
+		_, _ = f.dispatcher.DispatchBatch(context.Background())
+		f.clock.Advance(time.Hour)
+	}
+
+	if got := f.stored(t, event.ID); calls != 2 || got.Attempts != 2 || got.PublishedAt != nil {
+		t.Errorf("calls = %d, event = %+v, want delivery stopped after MaxAttempts", calls, got)
+	}
+}
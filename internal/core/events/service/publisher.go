@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"villainrsty-ecommerce-server/internal/core/events/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+type OutboxPublisher struct {
+	repo ports.OutboxRepository
+}
+
+func NewOutboxPublisher(repo ports.OutboxRepository) *OutboxPublisher {
+	return &OutboxPublisher{repo: repo}
+}
+
+func (p *OutboxPublisher) Publish(ctx context.Context, events ...*models.DomainEvent) error {
+	for _, event := range events {
+		if err := p.repo.Save(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+	// StatusShuttingDown dilaporkan selama graceful shutdown supaya load balancer berhenti mengirim traffic
+	StatusShuttingDown Status = "shutting_down"
+)
+
+type CheckResult struct {
+	Name      string
+	Status    Status
+	Critical  bool
+	Error     string
+	Duration  time.Duration
+	CheckedAt time.Time
+	// Cached true kalau hasil diambil dari cache, bukan dari pengecekan baru
+	Cached bool
+}
+
+type Report struct {
+	Status    Status
+	CheckedAt time.Time
+	Checks    []CheckResult
+}
+
+// Ready true kalau aplikasi boleh menerima traffic
+func (r Report) Ready() bool {
+	return r.Status == StatusUp
+}
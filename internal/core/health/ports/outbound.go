@@ -0,0 +1,17 @@
+package ports
+
+import "context"
+
+type (
+	// HealthCheck dipasang oleh adapter (database, SMTP, dll), error berarti dependency tidak sehat
+	HealthCheck interface {
+		Check(ctx context.Context) error
+	}
+
+	// CheckFunc adapter supaya fungsi biasa bisa didaftarkan sebagai HealthCheck
+	CheckFunc func(ctx context.Context) error
+)
+
+func (f CheckFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
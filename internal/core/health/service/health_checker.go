@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/health/models"
+	"villainrsty-ecommerce-server/internal/core/health/ports"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+const defaultCheckTimeout = 2 * time.Second
+
+type CheckOptions struct {
+	// Timeout batas waktu satu pengecekan, default 2 detik
+	Timeout time.Duration
+	// Critical menentukan apakah kegagalan check membuat readiness gagal.
+	// Dependency yang dipakai secara async (misal SMTP lewat job queue) sebaiknya tidak critical.
+	Critical bool
+}
+
+type registeredCheck struct {
+	name  string
+	check ports.HealthCheck
+	opts  CheckOptions
+
+	// mu juga mencegah dua probe menjalankan check yang sama bersamaan
+	mu     sync.Mutex
+	last   models.CheckResult
+	cached bool
+}
+
+// HealthChecker registry check dependency. Hasil tiap check di-cache selama cacheTTL
+// supaya probe yang sering (kubelet, load balancer) tidak membebani database/SMTP.
+type HealthChecker struct {
+	mu           sync.RWMutex
+	checks       []*registeredCheck
+	cacheTTL     time.Duration
+	shuttingDown atomic.Bool
+	clock        sharedPorts.Clock
+}
+
+// NewHealthChecker clock nil berarti jam sistem
+func NewHealthChecker(cacheTTL time.Duration, clock sharedPorts.Clock) *HealthChecker {
+	if clock == nil {
+		clock = sharedPorts.SystemClock
+	}
+
+	return &HealthChecker{
+		cacheTTL: cacheTTL,
+		clock:    clock,
+	}
+}
+
+func (h *HealthChecker) Register(name string, check ports.HealthCheck, opts CheckOptions) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultCheckTimeout
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, &registeredCheck{name: name, check: check, opts: opts})
+}
+
+// MarkShuttingDown membuat readiness gagal sejak graceful shutdown dimulai
+func (h *HealthChecker) MarkShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Check menjalankan semua check secara paralel dan merangkum hasilnya
+func (h *HealthChecker) Check(ctx context.Context) models.Report {
+	h.mu.RLock()
+	checks := append([]*registeredCheck(nil), h.checks...)
+	h.mu.RUnlock()
+
+	results := make([]models.CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = h.run(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	status := models.StatusUp
+	for _, result := range results {
+		if result.Critical && result.Status != models.StatusUp {
+			status = models.StatusDown
+		}
+	}
+
+	if h.shuttingDown.Load() {
+		status = models.StatusShuttingDown
+	}
+
+	return models.Report{
+		Status:    status,
+		CheckedAt: h.clock.Now(),
+		Checks:    results,
+	}
+}
+
+func (h *HealthChecker) run(ctx context.Context, c *registeredCheck) models.CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cached && h.clock.Now().Sub(c.last.CheckedAt) < h.cacheTTL {
+		result := c.last
+		result.Cached = true
+		return result
+	}
+
+	start := h.clock.Now()
+	err := runWithTimeout(ctx, c.check, c.opts.Timeout)
+
+	result := models.CheckResult{
+		Name:      c.name,
+		Status:    models.StatusUp,
+		Critical:  c.opts.Critical,
+		Duration:  h.clock.Now().Sub(start),
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = models.StatusDown
+		result.Error = err.Error()
+	}
+
+	c.last, c.cached = result, true
+	return result
+}
+
+// runWithTimeout tidak menunggu check yang mengabaikan ctx lebih lama dari timeout.
+// Pembatalan dari probe diabaikan supaya client yang putus tidak membuat hasil "down" ikut ter-cache.
+func runWithTimeout(ctx context.Context, check ports.HealthCheck, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("health check panic: %v", r)
+			}
+		}()
+		done <- check.Check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("health check timed out after %s: %w", timeout, ctx.Err())
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/health/models"
+	"villainrsty-ecommerce-server/internal/core/health/ports"
+	"villainrsty-ecommerce-server/pkg/clock"
+)
+
+func countingCheck(calls *atomic.Int32, err error) ports.CheckFunc {
+	return func(context.Context) error {
+		calls.Add(1)
+		return err
+	}
+}
+
+func TestHealthChecker_CriticalFailureFailsReadiness(t *testing.T) {
+	h := NewHealthChecker(0, nil)
+	var dbCalls, smtpCalls atomic.Int32
+	h.Register("postgres", countingCheck(&dbCalls, nil), CheckOptions{Critical: true})
+	h.Register("smtp", countingCheck(&smtpCalls, errors.New("connection refused")), CheckOptions{})
+
+	report := h.Check(context.Background())
+	if !report.Ready() {
+		t.Fatalf("status = %s, non-critical failure should not fail readiness", report.Status)
+	}
+
+	if report.Checks[1].Status != models.StatusDown || report.Checks[1].Error != "connection refused" {
+		t.Errorf("smtp result = %+v, want down with error", report.Checks[1])
+	}
+
+	h.Register("migrations", countingCheck(new(atomic.Int32), errors.New("schema behind")), CheckOptions{Critical: true})
+	if report := h.Check(context.Background()); report.Status != models.StatusDown {
+		t.Errorf("status = %s, want down", report.Status)
+	}
+}
+
+func TestHealthChecker_CachesResults(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	h := NewHealthChecker(time.Minute, clk)
+
+	var calls atomic.Int32
+	h.Register("postgres", countingCheck(&calls, nil), CheckOptions{Critical: true})
+
+	h.Check(context.Background())
+	report := h.Check(context.Background())
+	if calls.Load() != 1 || !report.Checks[0].Cached {
+		t.Fatalf("calls = %d, cached = %v, want second probe served from cache", calls.Load(), report.Checks[0].Cached)
+	}
+
+	clk.Advance(time.Minute)
+	h.Check(context.Background())
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want check to rerun after TTL", calls.Load())
+	}
+}
+
+func TestHealthChecker_TimesOutSlowCheck(t *testing.T) {
+	h := NewHealthChecker(0, nil)
+	block := make(chan struct{})
+	defer close(block)
+
+	h.Register("smtp", ports.CheckFunc(func(context.Context) error {
+		<-block // sengaja mengabaikan ctx
+		return nil
+	}), CheckOptions{Timeout: 10 * time.Millisecond, Critical: true})
+
+	report := h.Check(context.Background())
+	if report.Status != models.StatusDown {
+		t.Errorf("status = %s, want down after timeout", report.Status)
+	}
+}
+
+func TestHealthChecker_ShuttingDown(t *testing.T) {
+	h := NewHealthChecker(0, nil)
+	h.Register("postgres", countingCheck(new(atomic.Int32), nil), CheckOptions{Critical: true})
+	h.MarkShuttingDown()
+
+	if report := h.Check(context.Background()); report.Ready() || report.Status != models.StatusShuttingDown {
+		t.Errorf("status = %s, want shutting_down", report.Status)
+	}
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusProcessing Status = "processing"
+	StatusCompleted  Status = "completed"
+)
+
+// Response adalah response lengkap yang disimpan untuk di-replay
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Record adalah satu Idempotency-Key milik scope tertentu (user, atau IP klien untuk request anonim)
+type Record struct {
+	Scope       string
+	Key         string
+	Method      string
+	Path        string
+	Fingerprint string
+	Status      Status
+	Response    *Response
+	LockedAt    time.Time
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+func NewRecord(scope, key, method, path, fingerprint string, now time.Time, ttl time.Duration) *Record {
+	return &Record{
+		Scope:       scope,
+		Key:         key,
+		Method:      method,
+		Path:        path,
+		Fingerprint: fingerprint,
+		Status:      StatusProcessing,
+		LockedAt:    now,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+}
+
+// Fingerprint adalah hash dari method, path dan body; key yang sama dengan fingerprint beda ditolak
+func Fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{' '})
+	h.Write([]byte(path))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (r *Record) Matches(fingerprint string) bool {
+	return r.Fingerprint == fingerprint
+}
+
+func (r *Record) IsCompleted() bool {
+	return r.Status == StatusCompleted && r.Response != nil
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/idempotency/models"
+)
+
+type (
+	IdempotencyStore interface {
+		// Acquire mencoba mengklaim key. Kalau key sudah ada (dan belum expired atau stale),
+		// record yang ada dikembalikan dengan acquired=false.
+		Acquire(ctx context.Context, record *models.Record, staleBefore time.Time) (existing *models.Record, acquired bool, err error)
+		// Complete menyimpan response final untuk di-replay sampai expiresAt
+		Complete(ctx context.Context, scope, key string, resp *models.Response, completedAt, expiresAt time.Time) error
+		// Release melepas key yang masih processing supaya client boleh mencoba lagi
+		Release(ctx context.Context, scope, key string) error
+	}
+)
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+type (
+	JobQueue interface {
+		Enqueue(ctx context.Context, jobType string, payload any) (*models.Job, error)
+	}
+
+	JobService interface {
+		JobQueue
+		List(ctx context.Context, status models.JobStatus, page, limit int) ([]*models.Job, int64, error)
+		Get(ctx context.Context, id models.ID) (*models.Job, error)
+		Retry(ctx context.Context, id models.ID) error
+	}
+)
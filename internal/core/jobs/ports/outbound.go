@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+)
+
+type (
+	JobRepository interface {
+		Enqueue(ctx context.Context, job *models.Job) error
+		Claim(ctx context.Context, limit int, now, staleBefore time.Time) ([]*models.Job, error)
+		MarkSucceeded(ctx context.Context, id models.ID) error
+		Reschedule(ctx context.Context, id models.ID, runAt time.Time, lastError string) error
+		MarkDead(ctx context.Context, id models.ID, lastError string) error
+		RetryDead(ctx context.Context, id models.ID, runAt time.Time) error
+		GetByID(ctx context.Context, id models.ID) (*models.Job, error)
+		ListByStatus(ctx context.Context, status models.JobStatus, limit, offset int) ([]*models.Job, error)
+		CountByStatus(ctx context.Context, status models.JobStatus) (int64, error)
+	}
+)
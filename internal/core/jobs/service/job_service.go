@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"villainrsty-ecommerce-server/internal/core/jobs/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+type JobService struct {
+	repo        ports.JobRepository
+	maxAttempts int
+	clock       sharedPorts.Clock
+}
+
+func NewJobService(repo ports.JobRepository, maxAttempts int, clock sharedPorts.Clock) *JobService {
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	if clock == nil {
+		clock = sharedPorts.SystemClock
+	}
+
+	return &JobService{
+		repo:        repo,
+		maxAttempts: maxAttempts,
+		clock:       clock,
+	}
+}
+
+func (s *JobService) Enqueue(ctx context.Context, jobType string, payload any) (*models.Job, error) {
+	if jobType == "" {
+		return nil, errors.New(errors.ErrValidation, "job type is required")
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to encode job payload", err)
+	}
+
+	job := models.NewJob(jobType, body, s.maxAttempts, s.clock.Now())
+	if err := s.repo.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *JobService) List(ctx context.Context, status models.JobStatus, page, limit int) ([]*models.Job, int64, error) {
+	if !status.IsValid() {
+		return nil, 0, errors.New(errors.ErrValidation, "invalid job status")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 20
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	jobs, err := s.repo.ListByStatus(ctx, status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByStatus(ctx, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+func (s *JobService) Get(ctx context.Context, id models.ID) (*models.Job, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *JobService) Retry(ctx context.Context, id models.ID) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.RetryDead(ctx, id, s.clock.Now())
+}
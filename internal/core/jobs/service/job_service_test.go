@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/memory"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/clock"
+)
+
+func TestJobService_Enqueue(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	svc := NewJobService(memory.NewJobRepository(clk), 0, clk)
+	ctx := context.Background()
+
+	job, err := svc.Enqueue(ctx, "email.deliver", map[string]string{"message_id": "m-1"})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	// maxAttempts <= 0 tetap memberi satu attempt
+	if job.Status != models.JobStatusPending || job.MaxAttempts != 1 || !job.RunAt.Equal(clk.Now()) || string(job.Payload) != `{"message_id":"m-1"}` {
+		t.Errorf("job = %+v", job)
+	}
+
+	if _, err := svc.Enqueue(ctx, "", nil); !errors.IsKind(err, errors.ErrValidation) {
+		t.Errorf("Enqueue without type err = %v, want validation", err)
+	}
+
+	if _, err := svc.Enqueue(ctx, "bad", make(chan int)); !errors.IsKind(err, errors.ErrInternal) {
+		t.Errorf("Enqueue unencodable payload err = %v, want internal", err)
+	}
+}
+
+func TestJobService_RetryOnlyDeadJobs(t *testing.T) {
+	f := newWorkerFixture(1)
+	f.worker.Register("broken", func(context.Context, []byte) error { return Permanent(errors.New(errors.ErrValidation, "bad data")) })
+	ctx := context.Background()
+
+	job := f.enqueue(t, "broken")
+	if err := f.jobs.Retry(ctx, job.ID); !errors.IsKind(err, errors.ErrNotFound) {
+		t.Fatalf("Retry pending err = %v, want not found", err)
+	}
+
+	f.runOnce(t)
+	f.clock.Advance(time.Minute)
+	if err := f.jobs.Retry(ctx, job.ID); err != nil {
+		t.Fatalf("Retry: %v", err)
+	}
+
+	if got := f.get(t, job.ID); got.Status != models.JobStatusPending || got.Attempts != 0 || got.LastError != "" || !got.RunAt.Equal(f.clock.Now()) {
+		t.Errorf("job after retry = %+v, want pending with attempts reset", got)
+	}
+
+	if err := f.jobs.Retry(ctx, ""); !errors.IsKind(err, errors.ErrValidation) {
+		t.Errorf("Retry empty id err = %v, want validation", err)
+	}
+}
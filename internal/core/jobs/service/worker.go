@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/jobs/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+	"villainrsty-ecommerce-server/pkg/logger"
+)
+
+// HandlerFunc memproses payload mentah (JSON) dari sebuah job
+type HandlerFunc func(ctx context.Context, payload []byte) error
+
+type WorkerOptions struct {
+	Concurrency  int
+	PollInterval time.Duration
+	JobTimeout   time.Duration
+	LockTimeout  time.Duration
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+	// Clock sumber waktu untuk claim & jadwal retry, default jam sistem
+	Clock sharedPorts.Clock
+}
+
+type Worker struct {
+	repo     ports.JobRepository
+	handlers map[string]HandlerFunc
+	opts     WorkerOptions
+	logger   *slog.Logger
+	mu       sync.RWMutex
+}
+
+// permanentError menandakan job tidak perlu di-retry lagi
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+type finalAttemptKey struct{}
+
+// FinalAttempt apakah job yang sedang diproses langsung masuk dead-letter kalau gagal
+func FinalAttempt(ctx context.Context) bool {
+	final, _ := ctx.Value(finalAttemptKey{}).(bool)
+	return final
+}
+
+// Permanent membungkus error supaya job langsung masuk dead-letter tanpa retry
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &permanentError{err: err}
+}
+
+// IsPermanent apakah err (atau error yang dibungkusnya) dibuat lewat Permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return stdErrors.As(err, &permanent)
+}
+
+func NewWorker(repo ports.JobRepository, opts WorkerOptions, logger *slog.Logger) *Worker {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 2 * time.Second
+	}
+
+	if opts.JobTimeout <= 0 {
+		opts.JobTimeout = time.Minute
+	}
+
+	if opts.LockTimeout <= 0 {
+		opts.LockTimeout = 5 * time.Minute
+	}
+
+	if opts.BackoffBase <= 0 {
+		opts.BackoffBase = 10 * time.Second
+	}
+
+	if opts.BackoffMax <= 0 {
+		opts.BackoffMax = time.Hour
+	}
+
+	if opts.Clock == nil {
+		opts.Clock = sharedPorts.SystemClock
+	}
+
+	return &Worker{
+		repo:     repo,
+		handlers: make(map[string]HandlerFunc),
+		opts:     opts,
+		logger:   logger,
+	}
+}
+
+func (w *Worker) Register(jobType string, fn HandlerFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.handlers[jobType] = fn
+}
+
+// Handle mendaftarkan handler dengan payload bertipe, decode JSON dilakukan di sini
+func Handle[T any](w *Worker, jobType string, fn func(ctx context.Context, payload T) error) {
+	w.Register(jobType, func(ctx context.Context, raw []byte) error {
+		var payload T
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return Permanent(fmt.Errorf("decode payload %s: %w", jobType, err))
+		}
+
+		return fn(ctx, payload)
+	})
+}
+
+// Run menjalankan worker sampai ctx dibatalkan
+func (w *Worker) Run(ctx context.Context) {
+	w.logger.Info("job worker started",
+		"concurrency", w.opts.Concurrency,
+		"poll_interval", w.opts.PollInterval.String(),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx)
+		}()
+	}
+
+	wg.Wait()
+	w.logger.Info("job worker stopped")
+}
+
+func (w *Worker) loop(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		processed, err := w.processNext(ctx)
+		if err != nil && ctx.Err() == nil {
+			w.logger.Error("failed to claim job", "error", err)
+		}
+
+		if processed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.opts.PollInterval):
+		}
+	}
+}
+
+func (w *Worker) processNext(ctx context.Context) (bool, error) {
+	now := w.opts.Clock.Now()
+	jobs, err := w.repo.Claim(ctx, 1, now, now.Add(-w.opts.LockTimeout))
+	if err != nil {
+		return false, err
+	}
+
+	if len(jobs) == 0 {
+		return false, nil
+	}
+
+	for _, job := range jobs {
+		w.process(ctx, job)
+	}
+
+	return true, nil
+}
+
+func (w *Worker) process(ctx context.Context, job *models.Job) {
+	w.mu.RLock()
+	handler, ok := w.handlers[job.Type]
+	w.mu.RUnlock()
+
+	// Pakai context terpisah supaya job yang sedang jalan tetap selesai & tercatat walau worker sedang shutdown
+	stateCtx := context.WithoutCancel(ctx)
+
+	if !ok {
+		w.logger.Error("no handler registered for job", "job_id", job.ID.String(), "type", job.Type)
+		if err := w.repo.MarkDead(stateCtx, job.ID, "no handler registered for job type "+job.Type); err != nil {
+			w.logger.Error("failed to mark job dead", "job_id", job.ID.String(), "error", err)
+		}
+		return
+	}
+
+	jobCtx, cancel := context.WithTimeout(stateCtx, w.opts.JobTimeout)
+	jobCtx = logger.WithContext(jobCtx, w.logger.With("job_id", job.ID.String(), "job_type", job.Type))
+	jobCtx = context.WithValue(jobCtx, finalAttemptKey{}, !job.CanRetry())
+	err := runHandler(jobCtx, handler, job.Payload)
+	cancel()
+
+	if err == nil {
+		if err := w.repo.MarkSucceeded(stateCtx, job.ID); err != nil {
+			w.logger.Error("failed to mark job succeeded", "job_id", job.ID.String(), "error", err)
+		}
+		return
+	}
+
+	if IsPermanent(err) || !job.CanRetry() {
+		w.logger.Error("job moved to dead letter",
+			"job_id", job.ID.String(),
+			"type", job.Type,
+			"attempts", job.Attempts,
+			"error", err,
+		)
+		if err := w.repo.MarkDead(stateCtx, job.ID, err.Error()); err != nil {
+			w.logger.Error("failed to mark job dead", "job_id", job.ID.String(), "error", err)
+		}
+		return
+	}
+
+	runAt := w.opts.Clock.Now().Add(w.backoff(job.Attempts))
+	w.logger.Warn("job failed, scheduling retry",
+		"job_id", job.ID.String(),
+		"type", job.Type,
+		"attempts", job.Attempts,
+		"run_at", runAt.Format(time.RFC3339),
+		"error", err,
+	)
+	if err := w.repo.Reschedule(stateCtx, job.ID, runAt, err.Error()); err != nil {
+		w.logger.Error("failed to reschedule job", "job_id", job.ID.String(), "error", err)
+	}
+}
+
+// backoff menghitung delay exponential (base * 2^(attempt-1)) dengan jitter, dibatasi BackoffMax
+func (w *Worker) backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := w.opts.BackoffBase
+	for i := 1; i < attempt && delay < w.opts.BackoffMax; i++ {
+		delay *= 2
+	}
+
+	if delay > w.opts.BackoffMax {
+		delay = w.opts.BackoffMax
+	}
+
+	jitter := time.Duration(rand.Int64N(int64(delay)/5 + 1))
+
+	return delay + jitter
+}
+
+func runHandler(ctx context.Context, handler HandlerFunc, payload []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panic: %v", r)
+		}
+	}()
+
+	return handler(ctx, payload)
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	stdErrors "errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/memory"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/clock"
+)
+
+type workerFixture struct {
+	worker *Worker
+	jobs   *JobService
+	repo   *memory.JobRepository
+	clock  *clock.Fake
+}
+
+func newWorkerFixture(maxAttempts int) *workerFixture {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	repo := memory.NewJobRepository(clk)
+	worker := NewWorker(repo, WorkerOptions{
+		BackoffBase: 10 * time.Second,
+		BackoffMax:  time.Minute,
+		LockTimeout: 5 * time.Minute,
+		Clock:       clk,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	return &workerFixture{
+		worker: worker,
+		jobs:   NewJobService(repo, maxAttempts, clk),
+		repo:   repo,
+		clock:  clk,
+	}
+}
+
+func (f *workerFixture) enqueue(t *testing.T, jobType string) *models.Job {
+	t.Helper()
+
+	job, err := f.jobs.Enqueue(context.Background(), jobType, map[string]string{"message_id": "m-1"})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	return job
+}
+
+// runOnce memproses satu job yang sudah waktunya, gagal kalau tidak ada
+func (f *workerFixture) runOnce(t *testing.T) {
+	t.Helper()
+
+	processed, err := f.worker.processNext(context.Background())
+	if err != nil || !processed {
+		t.Fatalf("processNext = %v, %v, want a job processed", processed, err)
+	}
+}
+
+func (f *workerFixture) get(t *testing.T, id models.ID) *models.Job {
+	t.Helper()
+
+	job, err := f.repo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	return job
+}
+
+func TestWorker_RetriesWithBackoffUntilAttemptsRunOut(t *testing.T) {
+	f := newWorkerFixture(3)
+	var finals []bool
+	f.worker.Register("flaky", func(ctx context.Context, _ []byte) error {
+		finals = append(finals, FinalAttempt(ctx))
+		return stdErrors.New("smtp timeout")
+	})
+	job := f.enqueue(t, "flaky")
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		start := f.clock.Now()
+		f.runOnce(t)
+
+		got := f.get(t, job.ID)
+		if got.Status != models.JobStatusPending || got.Attempts != attempt || got.LastError != "smtp timeout" {
+			t.Fatalf("attempt %d: job = %+v, want pending for retry", attempt, got)
+		}
+
+		// base * 2^(attempt-1) ditambah jitter maksimal 20%
+		base := 10 * time.Second << (attempt - 1)
+		if delay := got.RunAt.Sub(start); delay < base || delay > base+base/5 {
+			t.Fatalf("attempt %d: retry delay = %s, want %s..%s", attempt, delay, base, base+base/5)
+		}
+
+		if processed, _ := f.worker.processNext(context.Background()); processed {
+			t.Fatalf("attempt %d: job ran again before its backoff", attempt)
+		}
+		f.clock.Set(got.RunAt)
+	}
+
+	f.runOnce(t)
+	if got := f.get(t, job.ID); got.Status != models.JobStatusDead || got.Attempts != 3 {
+		t.Fatalf("job = %+v, want dead after the last attempt", got)
+	}
+
+	if want := []bool{false, false, true}; len(finals) != 3 || finals[0] != want[0] || finals[1] != want[1] || finals[2] != want[2] {
+		t.Errorf("FinalAttempt per attempt = %v, want %v", finals, want)
+	}
+}
+
+func TestWorker_BackoffIsCappedAtMax(t *testing.T) {
+	f := newWorkerFixture(1)
+
+	for _, attempt := range []int{0, 1, 3, 10, 64} {
+		delay := f.worker.backoff(attempt)
+		if delay < 10*time.Second || delay > time.Minute+time.Minute/5 {
+			t.Errorf("backoff(%d) = %s, want between base and max plus jitter", attempt, delay)
+		}
+	}
+}
+
+func TestWorker_DeadLetter(t *testing.T) {
+	tests := map[string]struct {
+		register func(w *Worker)
+		wantErr  string
+	}{
+		"permanent error": {
+			register: func(w *Worker) {
+				w.Register("known", func(context.Context, []byte) error { return Permanent(stdErrors.New("unknown template")) })
+			},
+			wantErr: "unknown template",
+		},
+		"undecodable payload": {
+			// payload berupa object, tidak bisa di-decode ke int
+			register: func(w *Worker) {
+				Handle(w, "known", func(context.Context, int) error { return nil })
+			},
+			wantErr: "decode payload known",
+		},
+		"unknown type": {
+			register: func(*Worker) {},
+			wantErr:  "no handler registered for job type known",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := newWorkerFixture(5)
+			tt.register(f.worker)
+			job := f.enqueue(t, "known")
+
+			f.runOnce(t)
+
+			got := f.get(t, job.ID)
+			if got.Status != models.JobStatusDead || got.Attempts != 1 || !strings.Contains(got.LastError, tt.wantErr) {
+				t.Fatalf("job = %+v, want dead after one attempt with %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorker_RecoversHandlerPanic(t *testing.T) {
+	f := newWorkerFixture(3)
+	f.worker.Register("buggy", func(context.Context, []byte) error { panic("nil map") })
+	job := f.enqueue(t, "buggy")
+
+	// panic diperlakukan seperti error biasa, worker tetap hidup dan job di-retry
+	f.runOnce(t)
+
+	if got := f.get(t, job.ID); got.Status != models.JobStatusPending || got.LastError != "job handler panic: nil map" {
+		t.Fatalf("job = %+v, want rescheduled with the panic as last error", got)
+	}
+}
+
+func TestWorker_ReclaimsStaleLocks(t *testing.T) {
+	f := newWorkerFixture(3)
+	var runs int
+	f.worker.Register("slow", func(context.Context, []byte) error {
+		runs++
+		return nil
+	})
+	job := f.enqueue(t, "slow")
+
+	// worker lain mengklaim job lalu mati tanpa menandai hasilnya
+	if claimed, err := f.repo.Claim(context.Background(), 1, f.clock.Now(), f.clock.Now().Add(-5*time.Minute)); err != nil || len(claimed) != 1 {
+		t.Fatalf("Claim = %v, %v", claimed, err)
+	}
+
+	f.clock.Advance(4 * time.Minute)
+	if processed, _ := f.worker.processNext(context.Background()); processed {
+		t.Fatal("job with a fresh lock was claimed again")
+	}
+
+	f.clock.Advance(2 * time.Minute)
+	f.runOnce(t)
+
+	if got := f.get(t, job.ID); runs != 1 || got.Status != models.JobStatusSucceeded || got.Attempts != 2 || got.LockedAt != nil {
+		t.Fatalf("runs = %d, job = %+v, want the stale job reclaimed and succeeded", runs, got)
+	}
+}
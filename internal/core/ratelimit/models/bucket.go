@@ -0,0 +1,126 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit mendefinisikan token bucket: Requests token terisi ulang setiap Per, kapasitas Burst
+type Limit struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+// Bucket adalah state token bucket untuk satu key
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// ParseLimit membaca format "<requests>/<durasi>" dengan burst opsional, misal "5/1m" atau "5/1m:10"
+func ParseLimit(s string) (Limit, error) {
+	spec, burstPart, hasBurst := strings.Cut(strings.TrimSpace(s), ":")
+
+	reqPart, perPart, ok := strings.Cut(spec, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected <requests>/<duration>", s)
+	}
+
+	requests, err := strconv.Atoi(reqPart)
+	if err != nil || requests <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", s)
+	}
+
+	per, err := time.ParseDuration(perPart)
+	if err != nil || per <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: duration must be positive", s)
+	}
+
+	limit := Limit{Requests: requests, Per: per}
+	if hasBurst {
+		burst, err := strconv.Atoi(burstPart)
+		if err != nil || burst <= 0 {
+			return Limit{}, fmt.Errorf("invalid rate limit %q: burst must be a positive integer", s)
+		}
+		limit.Burst = burst
+	}
+
+	return limit, nil
+}
+
+func (l Limit) IsZero() bool {
+	return l.Requests <= 0 || l.Per <= 0
+}
+
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+
+	return l.Requests
+}
+
+// String dipakai untuk header RateLimit-Policy, contoh "5;w=60"
+func (l Limit) String() string {
+	return fmt.Sprintf("%d;w=%d", l.Capacity(), int(math.Ceil(l.Per.Seconds())))
+}
+
+// ratePerSecond kecepatan isi ulang token
+func (l Limit) ratePerSecond() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// NewBucket membuat bucket penuh
+func NewBucket(limit Limit, now time.Time) Bucket {
+	return Bucket{Tokens: float64(limit.Capacity()), UpdatedAt: now}
+}
+
+// Take mengisi ulang bucket sesuai waktu yang lewat lalu mencoba mengambil satu token
+func (b Bucket) Take(limit Limit, now time.Time) (Bucket, Result) {
+	capacity := float64(limit.Capacity())
+	rate := limit.ratePerSecond()
+
+	tokens := b.Tokens
+	if elapsed := now.Sub(b.UpdatedAt).Seconds(); elapsed > 0 {
+		tokens = math.Min(capacity, tokens+elapsed*rate)
+	}
+
+	result := Result{Limit: limit.Capacity()}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+
+	result.Remaining = int(math.Floor(tokens))
+	result.ResetAfter = secondsToDuration((capacity - tokens) / rate)
+
+	return Bucket{Tokens: tokens, UpdatedAt: now}, result
+}
+
+// ExpiresAt adalah waktu bucket kembali penuh, setelah itu state-nya boleh dibuang
+func (b Bucket) ExpiresAt(limit Limit) time.Time {
+	missing := float64(limit.Capacity()) - b.Tokens
+	return b.UpdatedAt.Add(secondsToDuration(missing / limit.ratePerSecond()))
+}
+
+func secondsToDuration(s float64) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Limit
+		wantErr bool
+	}{
+		{in: "5/1m", want: Limit{Requests: 5, Per: time.Minute}},
+		{in: "10/1h:20", want: Limit{Requests: 10, Per: time.Hour, Burst: 20}},
+		{in: "5", wantErr: true},
+		{in: "0/1m", wantErr: true},
+		{in: "5/abc", wantErr: true},
+		{in: "5/1m:0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseLimit(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLimit(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseLimit(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBucketTake(t *testing.T) {
+	limit := Limit{Requests: 2, Per: time.Minute}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	bucket := NewBucket(limit, now)
+
+	var result Result
+	for i := 0; i < 2; i++ {
+		bucket, result = bucket.Take(limit, now)
+		if !result.Allowed {
+			t.Fatalf("take %d denied, want allowed", i+1)
+		}
+	}
+
+	if result.Remaining != 0 {
+		t.Errorf("remaining = %d, want 0", result.Remaining)
+	}
+
+	bucket, result = bucket.Take(limit, now)
+	if result.Allowed {
+		t.Fatal("third take allowed, want denied")
+	}
+
+	if result.RetryAfter != 30*time.Second {
+		t.Errorf("retry after = %s, want 30s", result.RetryAfter)
+	}
+
+	if result.ResetAfter != time.Minute {
+		t.Errorf("reset after = %s, want 1m", result.ResetAfter)
+	}
+
+	// setelah 30 detik satu token terisi lagi
+	_, result = bucket.Take(limit, now.Add(30*time.Second))
+	if !result.Allowed {
+		t.Fatal("take after refill denied, want allowed")
+	}
+}
+
+func TestBucketRefillCappedAtBurst(t *testing.T) {
+	limit := Limit{Requests: 1, Per: time.Second, Burst: 3}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	bucket := Bucket{Tokens: 0, UpdatedAt: now}
+
+	_, result := bucket.Take(limit, now.Add(time.Hour))
+	if !result.Allowed || result.Remaining != 2 {
+		t.Errorf("result = %+v, want allowed with 2 remaining", result)
+	}
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/ratelimit/models"
+)
+
+type (
+	// BucketStore menyimpan state token bucket per key. Take harus atomik untuk key yang sama,
+	// termasuk antar replika kalau store-nya dipakai bersama.
+	BucketStore interface {
+		Take(ctx context.Context, key string, limit models.Limit, now time.Time) (models.Result, error)
+	}
+)
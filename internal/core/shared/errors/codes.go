@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Code kode error yang stabil dan bisa dipakai client untuk branching.
+// Jangan ganti nilai kode yang sudah rilis, tambahkan kode baru saja.
+type Code string
+
+type CodeInfo struct {
+	Code  Code
+	Kind  Kind
+	Title string
+}
+
+var registry = map[Code]CodeInfo{}
+
+func register(code Code, kind Kind, title string) Code {
+	if _, exists := registry[code]; exists {
+		panic(fmt.Sprintf("errors: duplicate code %q", code))
+	}
+
+	registry[code] = CodeInfo{Code: code, Kind: kind, Title: title}
+	return code
+}
+
+// Kode default per Kind, dipakai kalau AppError tidak punya Code
+var (
+	CodeValidation   = register("VALIDATION_ERROR", ErrValidation, "Validation failed")
+	CodeNotFound     = register("NOT_FOUND", ErrNotFound, "Resource not found")
+	CodeUnauthorized = register("UNAUTHORIZED", ErrUnauthorized, "Unauthorized")
+	CodeForbidden    = register("FORBIDDEN", ErrForbidden, "Access denied")
+	CodeConflict     = register("CONFLICT", ErrConflict, "Resource conflict")
+	CodeInternal     = register("INTERNAL_ERROR", ErrInternal, "Internal server error")
+	CodeRateLimited  = register("RATE_LIMITED", ErrRateLimited, "Too many requests")
+	CodeTooLarge     = register("BODY_TOO_LARGE", ErrTooLarge, "Request body too large")
+)
+
+// Request & transport
+var (
+	CodeInvalidJSON           = register("INVALID_JSON", ErrValidation, "Invalid JSON body")
+	CodeInvalidBody           = register("INVALID_BODY", ErrValidation, "Invalid request body")
+	CodeRouteNotFound         = register("ROUTE_NOT_FOUND", ErrNotFound, "Route not found")
+	CodeInvalidIdempotencyKey = register("INVALID_IDEMPOTENCY_KEY", ErrValidation, "Invalid idempotency key")
+	CodeIdempotencyKeyInUse   = register("IDEMPOTENCY_KEY_IN_USE", ErrConflict, "Idempotency key in use")
+	CodeIdempotencyMismatch   = register("IDEMPOTENCY_KEY_MISMATCH", ErrConflict, "Idempotency key reused with a different request")
+)
+
+// Auth
+var (
+	CodeMissingToken        = register("MISSING_AUTHORIZATION_TOKEN", ErrUnauthorized, "Missing authorization token")
+	CodeInvalidToken        = register("INVALID_TOKEN", ErrUnauthorized, "Invalid token")
+	CodeInvalidCredentials  = register("INVALID_CREDENTIALS", ErrUnauthorized, "Invalid email or password")
+	CodeInvalidOTP          = register("INVALID_OTP", ErrUnauthorized, "Invalid or expired OTP")
+	CodeInvalidRefreshToken = register("INVALID_REFRESH_TOKEN", ErrUnauthorized, "Invalid refresh token")
+	CodeInvalidResetToken   = register("INVALID_RESET_TOKEN", ErrUnauthorized, "Invalid reset token")
+	CodeEmailTaken          = register("EMAIL_ALREADY_REGISTERED", ErrConflict, "Email already registered")
+	CodeWeakPassword        = register("WEAK_PASSWORD", ErrValidation, "Password too weak")
+	CodeUnsupportedLocale   = register("UNSUPPORTED_LOCALE", ErrValidation, "Unsupported locale")
+)
+
+var defaultCodes = map[Kind]Code{
+	ErrValidation:   CodeValidation,
+	ErrNotFound:     CodeNotFound,
+	ErrUnauthorized: CodeUnauthorized,
+	ErrForbidden:    CodeForbidden,
+	ErrConflict:     CodeConflict,
+	ErrInternal:     CodeInternal,
+	ErrRateLimited:  CodeRateLimited,
+	ErrTooLarge:     CodeTooLarge,
+}
+
+// DefaultCode kode umum untuk Kind, kind tidak dikenal dianggap internal
+func DefaultCode(kind Kind) Code {
+	if code, ok := defaultCodes[kind]; ok {
+		return code
+	}
+
+	return CodeInternal
+}
+
+// Kind kategori kode dari registry, kode tidak terdaftar dianggap internal
+func (c Code) Kind() Kind {
+	if info, ok := registry[c]; ok {
+		return info.Kind
+	}
+
+	return ErrInternal
+}
+
+func LookupCode(code Code) (CodeInfo, bool) {
+	info, ok := registry[code]
+	return info, ok
+}
+
+// Codes semua kode terdaftar, urut berdasarkan kode
+func Codes() []CodeInfo {
+	infos := make([]CodeInfo, 0, len(registry))
+	for _, info := range registry {
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Code < infos[j].Code })
+	return infos
+}
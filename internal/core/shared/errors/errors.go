@@ -3,6 +3,8 @@ package errors
 import (
 	"errors"
 	"fmt"
+
+	"villainrsty-ecommerce-server/pkg/i18n"
 )
 
 type Kind string
@@ -14,13 +16,21 @@ const (
 	ErrForbidden    Kind = "forbidden"
 	ErrConflict     Kind = "conflict"
 	ErrInternal     Kind = "internal"
+	ErrRateLimited  Kind = "rate_limited"
+	ErrTooLarge     Kind = "too_large"
 )
 
 type AppError struct {
-	Kind    Kind
+	Kind Kind
+	// Code kode stabil untuk client, kosong berarti kode default dari Kind
+	Code    Code
 	Message string
 	Cause   error
 	Fields  map[string]string
+	// FieldMessages versi terjemahan Fields (key sama), diisi oleh validator
+	FieldMessages map[string]i18n.Message
+	// Params nilai placeholder untuk pesan terjemahan error.<Code>.detail
+	Params map[string]string
 }
 
 func (e *AppError) Error() string {
@@ -31,7 +41,7 @@ func (e *AppError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Kind, e.Message)
 }
 
-func (e *AppError) UnWrap() error { return e.Cause }
+func (e *AppError) Unwrap() error { return e.Cause }
 
 // New bikin AppError tanpa Cause
 func New(kind Kind, msg string) *AppError {
@@ -43,6 +53,25 @@ func Wrap(kind Kind, msg string, cause error) *AppError {
 	return &AppError{Kind: kind, Message: msg, Cause: cause}
 }
 
+// NewCode bikin AppError dari kode terdaftar, Kind diambil dari registry
+func NewCode(code Code, msg string) *AppError {
+	return &AppError{Kind: code.Kind(), Code: code, Message: msg}
+}
+
+// WrapCode sama seperti NewCode tapi menyimpan cause
+func WrapCode(code Code, msg string, cause error) *AppError {
+	return &AppError{Kind: code.Kind(), Code: code, Message: msg, Cause: cause}
+}
+
+// ErrorCode kode yang dikirim ke client
+func (e *AppError) ErrorCode() Code {
+	if e.Code != "" {
+		return e.Code
+	}
+
+	return DefaultCode(e.Kind)
+}
+
 // IsKind buat ngecek kategori error
 func IsKind(err error, kind Kind) bool {
 	var ae *AppError
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+)
+
+// DomainEvent adalah event yang disimpan ke outbox lalu di-relay oleh dispatcher
+type DomainEvent struct {
+	ID            ID
+	AggregateType string
+	AggregateID   ID
+	Type          string
+	Payload       []byte
+	OccurredAt    time.Time
+	PublishedAt   *time.Time
+	Attempts      int
+	LastError     string
+}
+
+func NewDomainEvent(eventType, aggregateType string, aggregateID ID, payload any, occurredAt time.Time) (*DomainEvent, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "failed to encode event payload", err)
+	}
+
+	return &DomainEvent{
+		ID:            NewID(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          eventType,
+		Payload:       body,
+		OccurredAt:    occurredAt,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+type EmailStatus string
+
+const (
+	EmailStatusQueued EmailStatus = "queued"
+	// EmailStatusRetrying attempt terakhir gagal dan job-nya masih akan di-retry
+	EmailStatusRetrying EmailStatus = "retrying"
+	EmailStatusSent     EmailStatus = "sent"
+	// EmailStatusFailed job-nya sudah menyerah, hanya status ini yang bisa dikirim ulang
+	EmailStatusFailed EmailStatus = "failed"
+)
+
+// EmailMessage satu email transaksional beserta riwayat pengirimannya
+type EmailMessage struct {
+	ID                ID
+	Template          string
+	ToEmail           string
+	Locale            i18n.Locale
+	Data              map[string]any
+	Status            EmailStatus
+	Attempts          int
+	ProviderMessageID string
+	LastError         string
+	SentAt            *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	// DataPurgedAt data template sudah dikosongkan (terkirim atau kedaluwarsa), pesan tidak bisa dikirim ulang
+	DataPurgedAt *time.Time
+}
+
+func NewEmailMessage(template, toEmail string, locale i18n.Locale, data map[string]any, now time.Time) *EmailMessage {
+	return &EmailMessage{
+		ID:        NewID(),
+		Template:  template,
+		ToEmail:   toEmail,
+		Locale:    locale,
+		Data:      data,
+		Status:    EmailStatusQueued,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsValid status kosong juga valid untuk filter "semua status"
+func (s EmailStatus) IsValid() bool {
+	switch s {
+	case "", EmailStatusQueued, EmailStatusRetrying, EmailStatusSent, EmailStatusFailed:
+		return true
+	}
+
+	return false
+}
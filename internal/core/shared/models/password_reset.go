@@ -11,9 +11,9 @@ type PasswordResetToken struct {
 	CreatedAt time.Time
 }
 
-func (t *PasswordResetToken) IsValid() bool {
+func (t *PasswordResetToken) IsValid(now time.Time) bool {
 	if t.UsedAt != nil {
 		return false
 	}
-	return time.Now().Before(t.ExpiresAt)
+	return now.Before(t.ExpiresAt)
 }
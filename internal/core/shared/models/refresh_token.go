@@ -12,8 +12,7 @@ type RefreshToken struct {
 	UpdatedAt time.Time
 }
 
-func NewRefreshToken(userID ID, tokenHash string, expiryDuration time.Duration) *RefreshToken {
-	now := time.Now()
+func NewRefreshToken(userID ID, tokenHash string, expiryDuration time.Duration, now time.Time) *RefreshToken {
 	return &RefreshToken{
 		ID:        NewID(),
 		UserID:    userID,
@@ -25,14 +24,14 @@ func NewRefreshToken(userID ID, tokenHash string, expiryDuration time.Duration)
 	}
 }
 
-func (rt *RefreshToken) IsExpired() bool {
-	return time.Now().After(rt.ExpiresAt)
+func (rt *RefreshToken) IsExpired(now time.Time) bool {
+	return now.After(rt.ExpiresAt)
 }
 
 func (rt *RefreshToken) IsRevoked() bool {
 	return rt.RevokedAt != nil
 }
 
-func (rt *RefreshToken) IsValid() bool {
-	return !rt.IsExpired() && !rt.IsRevoked()
+func (rt *RefreshToken) IsValid(now time.Time) bool {
+	return !rt.IsExpired(now) && !rt.IsRevoked()
 }
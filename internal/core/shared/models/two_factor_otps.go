@@ -12,10 +12,10 @@ type TwoFactorOTP struct {
 	CreatedAt   time.Time
 }
 
-func (o *TwoFactorOTP) IsValid() bool {
+func (o *TwoFactorOTP) IsValid(now time.Time) bool {
 	if o.UsedAt != nil {
 		return false
 	}
 
-	return time.Now().Before(o.ExpiresAt)
+	return now.Before(o.ExpiresAt)
 }
@@ -3,25 +3,42 @@ package models
 import (
 	"time"
 
+	"villainrsty-ecommerce-server/pkg/i18n"
 	"villainrsty-ecommerce-server/pkg/validator"
 )
 
+// Role menentukan akses user, admin boleh memakai endpoint /admin
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+func (r Role) IsValid() bool {
+	return r == RoleUser || r == RoleAdmin
+}
+
 type User struct {
-	ID        ID
-	Email     string
-	Password  string
-	Name      string
+	ID       ID
+	Email    string
+	Password string
+	Name     string
+	// Locale bahasa pilihan user, dipakai untuk email
+	Locale    i18n.Locale
+	Role      Role
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
-func NewUser(email, password, name string) *User {
-	now := time.Now()
+func NewUser(email, password, name string, now time.Time) *User {
 	return &User{
 		ID:        NewID(),
 		Email:     email,
 		Password:  password,
 		Name:      name,
+		Locale:    i18n.Default,
+		Role:      RoleUser,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -49,6 +66,10 @@ func (u *User) Validate() error {
 	return nil
 }
 
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
 func (u *User) IsPasswordValid(plainPassword string) bool {
 	if len(plainPassword) < 8 {
 		return false
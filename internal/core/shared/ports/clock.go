@@ -0,0 +1,21 @@
+package ports
+
+import "time"
+
+type (
+	// Clock sumber waktu untuk model dan service. Test memakai jam palsu supaya TTL dan
+	// expiry bisa diuji tanpa menunggu.
+	Clock interface {
+		Now() time.Time
+	}
+
+	// ClockFunc adapter fungsi biasa menjadi Clock
+	ClockFunc func() time.Time
+)
+
+// SystemClock jam sistem, default kalau Clock tidak diisi
+var SystemClock Clock = ClockFunc(time.Now)
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
@@ -0,0 +1,41 @@
+package ports
+
+import "context"
+
+type IsolationLevel string
+
+const (
+	IsolationDefault      IsolationLevel = ""
+	IsolationSerializable IsolationLevel = "serializable"
+)
+
+type (
+	TxOptions struct {
+		Isolation IsolationLevel
+	}
+
+	TxOption func(*TxOptions)
+
+	// TxManager menjalankan fn dalam satu transaksi database. Repository yang dipanggil
+	// dengan ctx dari fn otomatis ikut transaksi tersebut. fn bisa dijalankan ulang
+	// (retry serialization failure), jadi jangan taruh side effect non-database di dalamnya.
+	TxManager interface {
+		WithinTx(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error
+	}
+)
+
+// Serializable dipakai untuk alur read-then-write yang tidak boleh balapan (misal rotasi token)
+func Serializable() TxOption {
+	return func(o *TxOptions) {
+		o.Isolation = IsolationSerializable
+	}
+}
+
+func ApplyTxOptions(opts ...TxOption) TxOptions {
+	var o TxOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
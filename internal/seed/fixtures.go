@@ -0,0 +1,93 @@
+package seed
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/i18n"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed fixtures/*.yaml
+var files embed.FS
+
+// DefaultFixture fixture bawaan binary, dipakai `api seed` tanpa -file
+const DefaultFixture = "fixtures/dev.yaml"
+
+type (
+	Fixture struct {
+		Users []UserFixture `yaml:"users"`
+	}
+
+	// UserFixture Role dan Locale boleh kosong (user, bahasa default), Password kosong memakai
+	// password default dari Seeder
+	UserFixture struct {
+		Email    string      `yaml:"email"`
+		Name     string      `yaml:"name"`
+		Password string      `yaml:"password,omitempty"`
+		Role     models.Role `yaml:"role,omitempty"`
+		Locale   i18n.Locale `yaml:"locale,omitempty"`
+	}
+)
+
+// LoadFile membaca fixture dari disk, atau dari fixture bawaan kalau path-nya DefaultFixture
+func LoadFile(path string) (*Fixture, error) {
+	data, err := files.ReadFile(path)
+	if err != nil {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read fixture: %w", err)
+	}
+
+	fixture, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return fixture, nil
+}
+
+// document bagian categories, products dan orders sudah dikenali supaya pesan errornya jelas,
+// tapi belum bisa dimuat karena tabelnya belum ada di schema
+type document struct {
+	Users      []UserFixture `yaml:"users"`
+	Categories yaml.Node     `yaml:"categories"`
+	Products   yaml.Node     `yaml:"products"`
+	Orders     yaml.Node     `yaml:"orders"`
+}
+
+// Parse menolak key yang tidak dikenal supaya typo di fixture tidak diam-diam diabaikan
+func Parse(r io.Reader) (*Fixture, error) {
+	var doc document
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	for name, node := range map[string]yaml.Node{"categories": doc.Categories, "products": doc.Products, "orders": doc.Orders} {
+		if !node.IsZero() {
+			return nil, fmt.Errorf("section %q is not supported yet, the schema has no %s table", name, name)
+		}
+	}
+
+	fixture := &Fixture{Users: doc.Users}
+	for i, u := range fixture.Users {
+		if u.Role != "" && !u.Role.IsValid() {
+			return nil, fmt.Errorf("users[%d] %s: invalid role %q", i, u.Email, u.Role)
+		}
+
+		if u.Locale != "" && !slices.Contains(i18n.Supported, u.Locale) {
+			return nil, fmt.Errorf("users[%d] %s: unsupported locale %q", i, u.Email, u.Locale)
+		}
+	}
+
+	return fixture, nil
+}
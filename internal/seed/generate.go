@@ -0,0 +1,53 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/i18n"
+)
+
+// GeneratedDomain domain email user palsu, .test tidak akan pernah menerima email sungguhan
+const GeneratedDomain = "example.test"
+
+var (
+	firstNames = []string{
+		"Budi", "Sari", "Agus", "Dewi", "Rizky", "Putri", "Andi", "Nur", "Fajar", "Intan",
+		"Yoga", "Ayu", "Bayu", "Rina", "Dimas", "Lestari", "Hendra", "Maya", "Eko", "Wulan",
+		"Arif", "Citra", "Bima", "Nadia", "Galih", "Fitri", "Reza", "Tika", "Joko", "Laras",
+	}
+	lastNames = []string{
+		"Santoso", "Wijaya", "Saputra", "Pratama", "Hidayat", "Lestari", "Nugroho", "Kusuma",
+		"Siregar", "Simanjuntak", "Wibowo", "Setiawan", "Gunawan", "Halim", "Putra", "Rahmawati",
+		"Sihombing", "Hakim", "Susanto", "Purnomo", "Maharani", "Utami", "Firmansyah", "Tanjung",
+	}
+)
+
+// Generate membuat n user palsu. Hasilnya sama untuk seed yang sama, jadi menjalankan ulang
+// dengan seed dan n yang sama tidak menambah user baru (email-nya sudah ada).
+func Generate(n int, seed uint64) []UserFixture {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	users := make([]UserFixture, n)
+
+	for i := range users {
+		first := firstNames[rng.IntN(len(firstNames))]
+		last := lastNames[rng.IntN(len(lastNames))]
+
+		// mayoritas user Indonesia, sisanya memilih bahasa Inggris
+		locale := i18n.ID
+		if rng.IntN(10) < 3 {
+			locale = i18n.EN
+		}
+
+		users[i] = UserFixture{
+			Email:  fmt.Sprintf("%s.%s.%d@%s", strings.ToLower(first), strings.ToLower(last), i+1, GeneratedDomain),
+			Name:   first + " " + last,
+			Role:   models.RoleUser,
+			Locale: locale,
+		}
+	}
+
+	return users
+}
@@ -0,0 +1,141 @@
+package seed
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"villainrsty-ecommerce-server/internal/adapters/persistence/memory"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+// countingTx menghitung transaksi yang dibuka seeder di atas memory.TxManager
+type countingTx struct {
+	*memory.TxManager
+	calls int
+}
+
+func (m *countingTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error, opts ...sharedPorts.TxOption) error {
+	m.calls++
+	return m.TxManager.WithinTx(ctx, fn, opts...)
+}
+
+// countingHasher menghitung berapa kali hash dihitung
+type countingHasher struct{ calls int }
+
+func (h *countingHasher) Hash(_ context.Context, password string) (string, error) {
+	h.calls++
+	return "hashed:" + password, nil
+}
+
+func (h *countingHasher) Verify(_ context.Context, hash, password string) bool {
+	return hash == "hashed:"+password
+}
+
+func newTestSeeder() (*Seeder, *memory.UserRepository, *countingHasher, *countingTx) {
+	repo := memory.NewUserRepository(sharedPorts.SystemClock)
+	hasher := &countingHasher{}
+	tx := &countingTx{TxManager: memory.NewTxManager()}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return NewSeeder(repo, hasher, tx, sharedPorts.SystemClock, logger), repo, hasher, tx
+}
+
+func TestLoadFile_DefaultFixture(t *testing.T) {
+	fixture, err := LoadFile(DefaultFixture)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+
+	var admins int
+	for _, u := range fixture.Users {
+		if u.Role == models.RoleAdmin {
+			admins++
+		}
+	}
+
+	if len(fixture.Users) == 0 || admins == 0 {
+		t.Errorf("users = %+v, want at least one admin", fixture.Users)
+	}
+}
+
+func TestParse_RejectsUnknownData(t *testing.T) {
+	tests := map[string]string{
+		"unsupported section": "products:\n  - name: Sepatu\n",
+		"unknown section":     "userz:\n  - email: a@b.com\n",
+		"invalid role":        "users:\n  - email: a@b.com\n    name: A\n    role: root\n",
+		"unsupported locale":  "users:\n  - email: a@b.com\n    name: A\n    locale: fr\n",
+		"unknown field":       "users:\n  - email: a@b.com\n    nama: A\n",
+	}
+
+	for name, doc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(doc)); err == nil {
+				t.Fatal("Parse() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestGenerate_IsDeterministic(t *testing.T) {
+	a, b := Generate(50, 42), Generate(50, 42)
+
+	emails := map[string]bool{}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("user %d differs: %+v vs %+v", i, a[i], b[i])
+		}
+
+		if emails[a[i].Email] || !strings.HasSuffix(a[i].Email, "@"+GeneratedDomain) {
+			t.Fatalf("email %q duplicated or outside %s", a[i].Email, GeneratedDomain)
+		}
+		emails[a[i].Email] = true
+	}
+}
+
+func TestSeedUsers_IsIdempotent(t *testing.T) {
+	s, repo, hasher, tx := newTestSeeder()
+	users := append([]UserFixture{
+		{Email: "admin@villainrsty.local", Name: "Admin", Role: models.RoleAdmin, Password: "Adm1nPassword"},
+	}, Generate(batchSize+10, 7)...)
+
+	got, err := s.SeedUsers(context.Background(), users, "Passw0rd123")
+	if err != nil {
+		t.Fatalf("SeedUsers() error = %v", err)
+	}
+
+	if got.Created != len(users) || got.Skipped != 0 {
+		t.Errorf("first run = %+v, want %d created", got, len(users))
+	}
+
+	// satu hash per password berbeda, bukan per user
+	if hasher.calls != 2 || tx.calls != 2 {
+		t.Errorf("hash calls = %d, tx = %d, want 2 and 2", hasher.calls, tx.calls)
+	}
+
+	if admin, err := repo.GetByEmail(context.Background(), "admin@villainrsty.local"); err != nil || !admin.IsAdmin() || admin.Password != "hashed:Adm1nPassword" {
+		t.Errorf("admin = %+v", admin)
+	}
+
+	got, err = s.SeedUsers(context.Background(), users, "Passw0rd123")
+	if err != nil || got.Created != 0 || got.Skipped != len(users) {
+		t.Errorf("second run = %+v, %v, want everything skipped", got, err)
+	}
+}
+
+func TestSeedUsers_RejectsWeakPassword(t *testing.T) {
+	s, repo, _, _ := newTestSeeder()
+
+	_, err := s.SeedUsers(context.Background(), []UserFixture{{Email: "a@b.com", Name: "A"}}, "lemah")
+	if appErr, ok := errors.AsAppError(err); !ok || appErr.Code != errors.CodeWeakPassword {
+		t.Fatalf("SeedUsers() error = %v, want WEAK_PASSWORD", err)
+	}
+
+	if exists, _ := repo.Exist(context.Background(), "a@b.com"); exists {
+		t.Error("user a@b.com was saved, want none")
+	}
+}
@@ -0,0 +1,118 @@
+package seed
+
+import (
+	"context"
+	"log/slog"
+
+	"villainrsty-ecommerce-server/internal/core/auth/ports"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+// batchSize jumlah user per transaksi, cukup besar untuk ribuan user tanpa satu transaksi raksasa
+const batchSize = 500
+
+type Result struct {
+	Created int
+	Skipped int
+}
+
+// Seeder menulis fixture lewat repository yang sama dengan aplikasi. Tidak lewat AuthService
+// supaya tidak ada event UserRegistered (webhook) untuk data palsu dan password yang sama
+// cukup di-hash sekali.
+type Seeder struct {
+	users     ports.UserRepository
+	hasher    ports.PasswordHasher
+	txManager sharedPorts.TxManager
+	clock     sharedPorts.Clock
+	logger    *slog.Logger
+}
+
+func NewSeeder(users ports.UserRepository, hasher ports.PasswordHasher, txManager sharedPorts.TxManager, clock sharedPorts.Clock, logger *slog.Logger) *Seeder {
+	return &Seeder{
+		users:     users,
+		hasher:    hasher,
+		txManager: txManager,
+		clock:     clock,
+		logger:    logger,
+	}
+}
+
+// SeedUsers idempotent: user dengan email yang sudah terdaftar dilewati tanpa diubah
+func (s *Seeder) SeedUsers(ctx context.Context, users []UserFixture, defaultPassword string) (Result, error) {
+	var result Result
+	hashes := map[string]string{}
+
+	for start := 0; start < len(users); start += batchSize {
+		batch := users[start:min(start+batchSize, len(users))]
+
+		var batchResult Result
+		err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			batchResult = Result{}
+			for _, fixture := range batch {
+				created, err := s.seedUser(ctx, fixture, defaultPassword, hashes)
+				if err != nil {
+					return err
+				}
+
+				if created {
+					batchResult.Created++
+				} else {
+					batchResult.Skipped++
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result.Created += batchResult.Created
+		result.Skipped += batchResult.Skipped
+		s.logger.Info("seeded users", "done", start+len(batch), "total", len(users), "created", result.Created)
+	}
+
+	return result, nil
+}
+
+func (s *Seeder) seedUser(ctx context.Context, fixture UserFixture, defaultPassword string, hashes map[string]string) (bool, error) {
+	exists, err := s.users.Exist(ctx, fixture.Email)
+	if err != nil {
+		return false, err
+	}
+
+	if exists {
+		return false, nil
+	}
+
+	password := fixture.Password
+	if password == "" {
+		password = defaultPassword
+	}
+
+	user := models.NewUser(fixture.Email, password, fixture.Name, s.clock.Now())
+	if !user.IsPasswordValid(password) {
+		return false, errors.NewCode(errors.CodeWeakPassword, "password for "+fixture.Email+" must contain uppercase, lowercase and number")
+	}
+
+	if fixture.Role != "" {
+		user.Role = fixture.Role
+	}
+
+	if fixture.Locale != "" {
+		user.Locale = fixture.Locale
+	}
+
+	hash, ok := hashes[password]
+	if !ok {
+		if hash, err = s.hasher.Hash(ctx, password); err != nil {
+			return false, err
+		}
+		hashes[password] = hash
+	}
+
+	user.Password = hash
+	return true, s.users.Save(ctx, user)
+}
@@ -0,0 +1,258 @@
+// Package apiclient client Go typed untuk API ini. Method per operation beserta DTO-nya ada di
+// api.gen.go (hasil generate dari openapi/v1.yml), file ini berisi runtime-nya: penyimpanan token
+// dan refresh otomatis, retry, serta Idempotency-Key.
+package apiclient
+
+//go:generate go run ../../cmd/apiclient-gen -spec ../../openapi/v1.yml -out api.gen.go
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/pkg/httpclient"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Options struct {
+		// Timeout per request, default 30 detik
+		Timeout time.Duration
+		// MaxRetries jumlah retry untuk error jaringan dan status 429/502/503/504, default 2,
+		// negatif mematikan retry. Hanya request yang aman diulang (GET/PUT/DELETE atau yang
+		// membawa Idempotency-Key) yang di-retry, lihat httpclient.RetryPolicy.
+		MaxRetries int
+		// RetryBackoff jeda sebelum retry pertama, berlipat dua setiap retry, default 200ms
+		RetryBackoff time.Duration
+		// MaxRetryWait batas Retry-After yang masih ditunggu, default 30 detik
+		MaxRetryWait time.Duration
+		// Logger mencatat setiap request (token disensor), nil berarti tanpa log
+		Logger *slog.Logger
+		// OnTokens dipanggil setiap pasangan token berganti (login, refresh), misal untuk disimpan
+		OnTokens func(Tokens)
+	}
+
+	// Tokens pasangan access dan refresh token user yang sedang login
+	Tokens struct {
+		Access  string
+		Refresh string
+	}
+
+	Client struct {
+		http *httpclient.HTTPClient
+		opts Options
+
+		mu     sync.Mutex
+		tokens Tokens
+		// refreshMu memastikan hanya satu refresh yang jalan untuk request 401 yang bersamaan
+		refreshMu sync.Mutex
+	}
+
+	// Envelope response standar API (httpx.BaseResponse)
+	Envelope[T any] struct {
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+		Data    T      `json:"data"`
+		Meta    *Meta  `json:"meta,omitempty"`
+	}
+
+	// Response response mentah, untuk operation dengan beberapa content type (misal preview email)
+	Response struct {
+		StatusCode int
+		Header     http.Header
+		Body       []byte
+	}
+
+	RequestOption func(*request)
+
+	request struct {
+		headers        map[string]string
+		idempotencyKey string
+	}
+
+	// call deskripsi satu operation, diisi oleh method di api.gen.go
+	call struct {
+		method     string
+		path       string
+		query      url.Values
+		body       any
+		auth       bool
+		idempotent bool
+	}
+)
+
+func New(baseURL string, opts Options) *Client {
+	if opts.Timeout == 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	switch {
+	case opts.MaxRetries == 0:
+		opts.MaxRetries = 2
+	case opts.MaxRetries < 0:
+		opts.MaxRetries = 0
+	}
+	if opts.RetryBackoff == 0 {
+		opts.RetryBackoff = 200 * time.Millisecond
+	}
+	if opts.MaxRetryWait == 0 {
+		opts.MaxRetryWait = 30 * time.Second
+	}
+
+	return &Client{
+		http: httpclient.New(baseURL, httpclient.Options{
+			Timeout: opts.Timeout,
+			Retry: httpclient.RetryPolicy{
+				MaxAttempts: opts.MaxRetries + 1,
+				BaseDelay:   opts.RetryBackoff,
+				MaxDelay:    opts.MaxRetryWait,
+			},
+			Logger: opts.Logger,
+		}),
+		opts: opts,
+	}
+}
+
+// WithIdempotencyKey memakai key sendiri, misal supaya retry dari pemanggil tetap dianggap
+// request yang sama. Tanpa opsi ini operation idempotent mendapat key acak per pemanggilan.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(r *request) { r.idempotencyKey = key }
+}
+
+func WithHeader(key, value string) RequestOption {
+	return func(r *request) { r.headers[key] = value }
+}
+
+func (c *Client) SetTokens(tokens Tokens) {
+	c.mu.Lock()
+	c.tokens = tokens
+	c.mu.Unlock()
+
+	if c.opts.OnTokens != nil {
+		c.opts.OnTokens(tokens)
+	}
+}
+
+func (c *Client) Tokens() Tokens {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.tokens
+}
+
+// Decode membaca body JSON response mentah
+func (r *Response) Decode(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+func (e *Envelope[T]) tokens() (Tokens, bool) {
+	data, ok := any(e.Data).(interface{ tokens() Tokens })
+	if !ok {
+		return Tokens{}, false
+	}
+
+	return data.tokens(), true
+}
+
+func (c *Client) do(ctx context.Context, call call, out any, opts []RequestOption) error {
+	req := request{headers: map[string]string{}}
+	for _, opt := range opts {
+		opt(&req)
+	}
+	if call.idempotent && req.idempotencyKey == "" {
+		req.idempotencyKey = uuid.NewString()
+	}
+	if req.idempotencyKey != "" {
+		req.headers["Idempotency-Key"] = req.idempotencyKey
+	}
+
+	path := call.path
+	if len(call.query) > 0 {
+		path += "?" + call.query.Encode()
+	}
+
+	for refreshed := false; ; refreshed = true {
+		headers := maps.Clone(req.headers)
+		access := c.Tokens().Access
+		if call.auth && access != "" {
+			headers["Authorization"] = "Bearer " + access
+		}
+
+		// retry (error jaringan, 429/5xx) ditangani httpclient, di sini cukup refresh token
+		resp, err := c.http.DoContext(ctx, httpclient.Request{Method: call.method, Path: path, Headers: headers, Body: call.body})
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && call.auth && !refreshed {
+			ok, err := c.refresh(ctx, access)
+			if err != nil {
+				return err
+			}
+			if ok {
+				continue
+			}
+		}
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			return newError(resp)
+		}
+
+		return c.decode(resp, out)
+	}
+}
+
+// refresh menukar refresh token dengan pasangan baru. used access token yang ditolak server,
+// kalau sudah berbeda berarti request lain sudah melakukan refresh.
+func (c *Client) refresh(ctx context.Context, used string) (bool, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	tokens := c.Tokens()
+	if tokens.Access != used {
+		return true, nil
+	}
+	if tokens.Refresh == "" {
+		return false, nil
+	}
+
+	if _, err := c.RefreshToken(ctx, RefreshTokenRequest{RefreshToken: tokens.Refresh}); err != nil {
+		if errors.IsKind(err, errors.ErrUnauthorized) {
+			c.SetTokens(Tokens{})
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *Client) decode(resp *httpclient.Response, out any) error {
+	switch v := out.(type) {
+	case nil:
+		return nil
+	case *Response:
+		*v = Response{StatusCode: resp.StatusCode, Header: resp.Headers, Body: resp.Body}
+		return nil
+	}
+
+	if len(resp.Body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return errors.Wrap(errors.ErrInternal, "Failed to decode response body", err)
+	}
+
+	if carrier, ok := out.(interface{ tokens() (Tokens, bool) }); ok {
+		if tokens, ok := carrier.tokens(); ok {
+			c.SetTokens(tokens)
+		}
+	}
+
+	return nil
+}
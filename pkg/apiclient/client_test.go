@@ -0,0 +1,178 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/openapi"
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+)
+
+func TestGeneratedClientUpToDate(t *testing.T) {
+	spec, err := os.ReadFile("../../openapi/v1.yml")
+	if err != nil {
+		t.Fatalf("read openapi/v1.yml: %v", err)
+	}
+
+	generated, err := openapi.GenerateClient(spec, "apiclient")
+	if err != nil {
+		t.Fatalf("GenerateClient() error = %v", err)
+	}
+
+	committed, err := os.ReadFile("api.gen.go")
+	if err != nil {
+		t.Fatalf("read api.gen.go: %v", err)
+	}
+
+	if !bytes.Equal(committed, generated) {
+		t.Fatal("api.gen.go is stale, run: go generate ./pkg/apiclient")
+	}
+}
+
+func TestClient_RefreshesExpiredAccessToken(t *testing.T) {
+	var refreshes atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/refresh":
+			refreshes.Add(1)
+			var body RefreshTokenRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.RefreshToken != "refresh-1" {
+				problem(w, http.StatusUnauthorized, "INVALID_REFRESH_TOKEN")
+				return
+			}
+			writeJSON(w, http.StatusOK, Envelope[RefreshTokenResponse]{Success: true, Data: RefreshTokenResponse{Token: "access-2", RefreshToken: "refresh-2"}})
+		case "/v1/auth/locale":
+			if r.Header.Get("Authorization") != "Bearer access-2" {
+				problem(w, http.StatusUnauthorized, "INVALID_TOKEN")
+				return
+			}
+			writeJSON(w, http.StatusOK, Envelope[string]{Success: true, Message: "Locale updated"})
+		}
+	}))
+	defer srv.Close()
+
+	var saved Tokens
+	c := New(srv.URL, Options{OnTokens: func(t Tokens) { saved = t }})
+	c.SetTokens(Tokens{Access: "access-1", Refresh: "refresh-1"})
+
+	resp, err := c.UpdateLocale(context.Background(), UpdateLocaleRequest{Locale: "id"})
+	if err != nil {
+		t.Fatalf("UpdateLocale() error = %v", err)
+	}
+	if resp.Message != "Locale updated" {
+		t.Errorf("message = %q", resp.Message)
+	}
+	if want := (Tokens{Access: "access-2", Refresh: "refresh-2"}); c.Tokens() != want || saved != want {
+		t.Errorf("tokens = %+v, saved = %+v, want %+v", c.Tokens(), saved, want)
+	}
+
+	// refresh token ditolak: token dihapus dan error refresh dikembalikan
+	c.SetTokens(Tokens{Access: "access-1", Refresh: "stale"})
+	_, err = c.UpdateLocale(context.Background(), UpdateLocaleRequest{Locale: "id"})
+	if !errors.IsKind(err, errors.ErrUnauthorized) {
+		t.Fatalf("error = %v, want unauthorized", err)
+	}
+	if c.Tokens() != (Tokens{}) {
+		t.Errorf("tokens = %+v, want cleared after rejected refresh", c.Tokens())
+	}
+	if got := refreshes.Load(); got != 2 {
+		t.Errorf("refreshes = %d, want 2", got)
+	}
+}
+
+func TestClient_RetriesOnlyIdempotentRequests(t *testing.T) {
+	var (
+		keys  []string
+		calls atomic.Int32
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path == "/v1/auth/register" {
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			if len(keys) == 1 {
+				w.Header().Set("Retry-After", "0")
+				problem(w, http.StatusTooManyRequests, "RATE_LIMITED")
+				return
+			}
+			writeJSON(w, http.StatusOK, Envelope[RegisterResponse]{Success: true, Data: RegisterResponse{User: User{ID: "u1"}}})
+			return
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, Options{RetryBackoff: time.Millisecond})
+
+	resp, err := c.Register(context.Background(), RegisterRequest{Email: "a@mail.com", Password: "Passw0rd123", Name: "A"})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if resp.Data.User.ID != "u1" {
+		t.Errorf("user = %+v", resp.Data.User)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("idempotency keys = %q, want the same generated key on retry", keys)
+	}
+
+	// login tidak idempotent, 503 langsung dikembalikan tanpa retry
+	calls.Store(0)
+	_, err = c.Login(context.Background(), LoginRequest{Email: "a@mail.com", Password: "Passw0rd123"})
+	if apiErr, ok := err.(*Error); !ok || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("error = %v, want *Error with status 503", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("login calls = %d, want 1", got)
+	}
+
+	// GET aman diulang: 1 percobaan + 2 retry default
+	calls.Store(0)
+	if _, err := c.Livez(context.Background()); err == nil {
+		t.Fatal("Livez() error = nil, want 503")
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("livez calls = %d, want 3", got)
+	}
+}
+
+func TestClient_DecodesProblem(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(Problem{
+			Type: "/problems/validation-error", Title: "Validation failed", Status: http.StatusBadRequest, Code: "VALIDATION_ERROR",
+			Errors: []ProblemFieldError{{Field: "email", Message: "Invalid email format"}},
+		})
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, Options{}).ForgotPassword(context.Background(), ForgotPasswordRequest{Email: "x"})
+
+	appErr, ok := errors.AsAppError(err)
+	if !ok {
+		t.Fatalf("error = %v, want wrapped *AppError", err)
+	}
+	if appErr.Kind != errors.ErrValidation || appErr.Code != errors.CodeValidation || appErr.Fields["email"] != "Invalid email format" {
+		t.Errorf("app error = %+v", appErr)
+	}
+}
+
+func problem(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(Problem{Status: status, Code: code, Title: http.StatusText(status)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,86 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/pkg/httpclient"
+)
+
+// Error response gagal dari API. Unwrap mengembalikan *errors.AppError dengan Kind sesuai kode
+// error, jadi errors.IsKind(err, errors.ErrUnauthorized) bisa langsung dipakai pemanggil.
+type Error struct {
+	StatusCode int
+	// Problem body problem+json, kosong kalau response bukan dari API (misal dari proxy)
+	Problem Problem
+	// RetryAfter dari header Retry-After, nol kalau tidak ada
+	RetryAfter time.Duration
+
+	app *errors.AppError
+}
+
+func newError(resp *httpclient.Response) *Error {
+	e := &Error{StatusCode: resp.StatusCode}
+	e.RetryAfter, _ = httpclient.RetryAfter(resp.Headers, time.Now())
+	_ = json.Unmarshal(resp.Body, &e.Problem)
+
+	code := errors.Code(e.Problem.Code)
+	kind := code.Kind()
+	if _, ok := errors.LookupCode(code); !ok {
+		kind = kindForStatus(resp.StatusCode)
+	}
+
+	message := e.Problem.Detail
+	if message == "" {
+		message = e.Problem.Title
+	}
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+
+	var fields map[string]string
+	if len(e.Problem.Errors) > 0 {
+		fields = make(map[string]string, len(e.Problem.Errors))
+		for _, fe := range e.Problem.Errors {
+			fields[fe.Field] = fe.Message
+		}
+	}
+
+	e.app = &errors.AppError{Kind: kind, Code: code, Message: message, Fields: fields}
+	return e
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("api: %d %s: %s", e.StatusCode, e.app.ErrorCode(), e.app.Message)
+}
+
+func (e *Error) Unwrap() error { return e.app }
+
+func (e *Error) Kind() errors.Kind { return e.app.Kind }
+
+// Code kode error dari server, kode default Kind kalau body tidak membawa kode
+func (e *Error) Code() errors.Code { return e.app.ErrorCode() }
+
+func kindForStatus(status int) errors.Kind {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return errors.ErrValidation
+	case http.StatusUnauthorized:
+		return errors.ErrUnauthorized
+	case http.StatusForbidden:
+		return errors.ErrForbidden
+	case http.StatusNotFound:
+		return errors.ErrNotFound
+	case http.StatusConflict:
+		return errors.ErrConflict
+	case http.StatusRequestEntityTooLarge:
+		return errors.ErrTooLarge
+	case http.StatusTooManyRequests:
+		return errors.ErrRateLimited
+	default:
+		return errors.ErrInternal
+	}
+}
@@ -0,0 +1,40 @@
+// Package clock jam palsu untuk test. Memenuhi ports.Clock tanpa perlu mengimpornya.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake jam yang hanya bergerak kalau di-Set atau di-Advance, aman dipakai lintas goroutine
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (c *Fake) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *Fake) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
+
+// Advance memajukan jam sebesar d dan mengembalikan waktu yang baru
+func (c *Fake) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	return c.now
+}
@@ -0,0 +1,113 @@
+package httpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+)
+
+// ErrCircuitOpen request ditolak tanpa dikirim karena host sedang dianggap down
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+type BreakerOptions struct {
+	// FailureThreshold kegagalan beruntun (error jaringan atau 5xx) sebelum circuit terbuka, default 5
+	FailureThreshold int
+	// OpenTimeout lama circuit terbuka sebelum satu request percobaan (half-open) dilepas, default 30 detik
+	OpenTimeout time.Duration
+}
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+type (
+	// breakers satu circuit per host, supaya provider yang down tidak menahan host lain
+	breakers struct {
+		opts  BreakerOptions
+		clock sharedPorts.Clock
+
+		mu    sync.Mutex
+		hosts map[string]*breaker
+	}
+
+	breaker struct {
+		state    breakerState
+		failures int
+		openedAt time.Time
+	}
+)
+
+func newBreakers(opts BreakerOptions, clock sharedPorts.Clock) *breakers {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 5
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = 30 * time.Second
+	}
+
+	return &breakers{opts: opts, clock: clock, hosts: map[string]*breaker{}}
+}
+
+// allow menolak request selama circuit terbuka. Setelah OpenTimeout satu request dilepas
+// (half-open), request lain tetap ditolak sampai hasilnya dicatat.
+func (b *breakers) allow(host string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := b.host(host)
+	switch br.state {
+	case stateOpen:
+		if b.clock.Now().Sub(br.openedAt) < b.opts.OpenTimeout {
+			return ErrCircuitOpen
+		}
+		br.state = stateHalfOpen
+		return nil
+	case stateHalfOpen:
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+func (b *breakers) record(host string, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br := b.host(host)
+	if success {
+		br.state, br.failures = stateClosed, 0
+		return
+	}
+
+	br.failures++
+	if br.state == stateHalfOpen || br.failures >= b.opts.FailureThreshold {
+		br.state, br.openedAt = stateOpen, b.clock.Now()
+	}
+}
+
+// release mengembalikan slot half-open tanpa mencatat hasil, misal saat ctx pemanggil selesai.
+// Circuit kembali terbuka dengan openedAt lama sehingga request berikutnya langsung jadi percobaan.
+func (b *breakers) release(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if br := b.host(host); br.state == stateHalfOpen {
+		br.state = stateOpen
+	}
+}
+
+func (b *breakers) host(host string) *breaker {
+	br, ok := b.hosts[host]
+	if !ok {
+		br = &breaker{}
+		b.hosts[host] = br
+	}
+
+	return br
+}
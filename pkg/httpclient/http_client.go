@@ -1,19 +1,60 @@
+// Package httpclient client HTTP keluar untuk integrasi pihak ketiga (payment, shipping, SMS):
+// body JSON, trace context, retry dengan jitter yang menghormati Retry-After, circuit breaker
+// per host, log request/response yang disensor dan tanda tangan HMAC opsional.
 package httpclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"time"
 
 	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	sharedPorts "villainrsty-ecommerce-server/internal/core/shared/ports"
+	"villainrsty-ecommerce-server/pkg/logger"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("villainrsty-ecommerce-server/pkg/httpclient")
+
 type (
+	Options struct {
+		// Timeout per percobaan (bukan total semua retry), default 30 detik
+		Timeout time.Duration
+		// Transport default http.DefaultTransport, test bisa mengganti dengan transport palsu
+		Transport http.RoundTripper
+		// Retry kebijakan retry, nilai nol berarti tanpa retry (lihat DefaultRetryPolicy)
+		Retry RetryPolicy
+		// Breaker circuit breaker per host, nil berarti tidak dipakai
+		Breaker *BreakerOptions
+		// Signer menandatangani setiap percobaan, misal HMACSigner
+		Signer Signer
+		// Logger mencatat setiap percobaan dengan header dan body yang disensor, nil berarti tanpa log.
+		// Logger dari ctx (lihat logger.WithContext) didahulukan supaya request_id ikut tercatat.
+		Logger *slog.Logger
+		// LogBodies ikut mencatat body request dan response (dipotong, field rahasia disensor)
+		LogBodies bool
+		// Redact nama header, field JSON atau query param tambahan yang disensor di log
+		Redact []string
+		// Clock sumber waktu untuk breaker, Retry-After dan tanda tangan, default jam sistem
+		Clock sharedPorts.Clock
+	}
+
 	HTTPClient struct {
-		client  *http.Client
-		baseURL string
+		client   *http.Client
+		baseURL  string
+		opts     Options
+		breakers *breakers
+		redactor redactor
 	}
 
 	Request struct {
@@ -27,44 +68,162 @@ type (
 		StatusCode int
 		Body       []byte
 		Headers    http.Header
+		// Attempts jumlah percobaan sampai response ini didapat
+		Attempts int
 	}
 )
 
+// NewHTTPClient client tanpa retry, breaker maupun log
 func NewHTTPClient(baseURL string, timeout time.Duration) *HTTPClient {
-	return &HTTPClient{
+	return New(baseURL, Options{Timeout: timeout})
+}
+
+func New(baseURL string, opts Options) *HTTPClient {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	if opts.Clock == nil {
+		opts.Clock = sharedPorts.SystemClock
+	}
+	opts.Retry = opts.Retry.withDefaults()
+
+	c := &HTTPClient{
 		client: &http.Client{
-			Timeout: timeout,
+			Timeout:   opts.Timeout,
+			Transport: opts.Transport,
 		},
-		baseURL: baseURL,
+		baseURL:  baseURL,
+		opts:     opts,
+		redactor: newRedactor(opts.Redact),
+	}
+	if opts.Breaker != nil {
+		c.breakers = newBreakers(*opts.Breaker, opts.Clock)
 	}
+
+	return c
 }
 
 func (c *HTTPClient) Do(req Request) (*Response, error) {
-	url := c.baseURL + req.Path
+	return c.DoContext(context.Background(), req)
+}
+
+// DoContext mengirim request dengan ctx; trace aktif di ctx diteruskan lewat header traceparent.
+// Response 4xx/5xx tetap dikembalikan sebagai Response, error hanya untuk kegagalan jaringan,
+// ctx selesai atau circuit breaker terbuka (errors.Is(err, ErrCircuitOpen)).
+func (c *HTTPClient) DoContext(ctx context.Context, req Request) (resp *Response, err error) {
+	target, err := url.Parse(c.baseURL + req.Path)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrInternal, "Failed to create request", err)
+	}
+
+	ctx, span := tracer.Start(ctx, "HTTP "+req.Method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(c.redactor.url(target)),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
 
-	var body io.Reader
-	if req.Body == nil {
-		bodyBytes, err := json.Marshal(req.Body)
+	// body di-marshal sekali supaya bisa dikirim ulang di setiap percobaan
+	var body []byte
+	if req.Body != nil {
+		body, err = json.Marshal(req.Body)
 		if err != nil {
 			return nil, errors.Wrap(errors.ErrInternal, "Failed to marshal request body", err)
 		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		var sent bool
+		resp, sent, err = c.attempt(ctx, target, req, body, attempt)
+		if !sent || ctx.Err() != nil {
+			break
+		}
 
-		body = bytes.NewReader(bodyBytes)
+		retry, delay := c.opts.Retry.next(req, resp, err, attempt, c.opts.Clock.Now())
+		if !retry {
+			break
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, errors.Wrap(errors.ErrInternal, "Request canceled while waiting to retry", err)
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	httpReq, err := http.NewRequest(req.Method, url, body)
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	return resp, nil
+}
+
+// attempt satu percobaan: cek breaker, tanda tangan, kirim, lalu catat hasilnya. sent false
+// berarti request tidak sampai dikirim sehingga tidak perlu di-retry.
+func (c *HTTPClient) attempt(ctx context.Context, target *url.URL, req Request, body []byte, attempt int) (*Response, bool, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, target.String(), reader)
 	if err != nil {
-		return nil, errors.Wrap(errors.ErrInternal, "Failed to create request", err)
+		return nil, false, errors.Wrap(errors.ErrInternal, "Failed to create request", err)
 	}
 
-	httpReq.Header.Set("Content-Type", "application/json")
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
 	for key, value := range req.Headers {
 		httpReq.Header.Set(key, value)
 	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
+
+	if c.opts.Signer != nil {
+		if err := c.opts.Signer.Sign(httpReq, body); err != nil {
+			return nil, false, errors.Wrap(errors.ErrInternal, "Failed to sign request", err)
+		}
+	}
 
+	// breaker baru dicek setelah request siap dikirim, supaya slot half-open tidak terpakai oleh
+	// request yang gagal dibuat dan tidak pernah dicatat hasilnya
+	if c.breakers != nil {
+		if err := c.breakers.allow(target.Host); err != nil {
+			return nil, false, errors.Wrap(errors.ErrInternal, "Circuit breaker open for "+target.Host, err)
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.send(httpReq)
+	if resp != nil {
+		resp.Attempts = attempt
+	}
+
+	if c.breakers != nil {
+		switch {
+		case err != nil && ctx.Err() != nil:
+			// dibatalkan pemanggil, bukan tanda host bermasalah
+			c.breakers.release(target.Host)
+		default:
+			c.breakers.record(target.Host, err == nil && resp.StatusCode < http.StatusInternalServerError)
+		}
+	}
+	c.log(ctx, httpReq, body, resp, err, attempt, time.Since(start))
+
+	return resp, true, err
+}
+
+func (c *HTTPClient) send(httpReq *http.Request) (*Response, error) {
 	httpResp, err := c.client.Do(httpReq)
 	if err != nil {
-		return nil, errors.Wrap(errors.ErrInternal, "Failed to do reques", err)
+		return nil, errors.Wrap(errors.ErrInternal, "Failed to do request", err)
 	}
 
 	defer httpResp.Body.Close()
@@ -81,16 +240,53 @@ func (c *HTTPClient) Do(req Request) (*Response, error) {
 	}, nil
 }
 
-func (c *HTTPClient) Get(path string, headers map[string]string) (*Response, error) {
-	return c.Do(Request{
+func (c *HTTPClient) log(ctx context.Context, req *http.Request, body []byte, resp *Response, err error, attempt int, duration time.Duration) {
+	if c.opts.Logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", c.redactor.url(req.URL)),
+		slog.Int("attempt", attempt),
+		slog.Float64("duration_ms", float64(duration.Microseconds())/1000),
+		slog.Any("request_headers", c.redactor.headers(req.Header)),
+	}
+	if c.opts.LogBodies && body != nil {
+		attrs = append(attrs, slog.String("request_body", c.redactor.body(body)))
+	}
+
+	level := slog.LevelInfo
+	switch {
+	case err != nil:
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	default:
+		if resp.StatusCode >= http.StatusInternalServerError {
+			level = slog.LevelWarn
+		}
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode),
+			slog.Any("response_headers", c.redactor.headers(resp.Headers)),
+		)
+		if c.opts.LogBodies && len(resp.Body) > 0 {
+			attrs = append(attrs, slog.String("response_body", c.redactor.body(resp.Body)))
+		}
+	}
+
+	logger.FromContext(ctx, c.opts.Logger).LogAttrs(ctx, level, "outbound http request", attrs...)
+}
+
+func (c *HTTPClient) Get(ctx context.Context, path string, headers map[string]string) (*Response, error) {
+	return c.DoContext(ctx, Request{
 		Method:  http.MethodGet,
 		Path:    path,
 		Headers: headers,
 	})
 }
 
-func (c *HTTPClient) Post(path string, body interface{}, headers map[string]string) (*Response, error) {
-	return c.Do(Request{
+func (c *HTTPClient) Post(ctx context.Context, path string, body interface{}, headers map[string]string) (*Response, error) {
+	return c.DoContext(ctx, Request{
 		Method:  http.MethodPost,
 		Path:    path,
 		Body:    body,
@@ -98,8 +294,8 @@ func (c *HTTPClient) Post(path string, body interface{}, headers map[string]stri
 	})
 }
 
-func (c *HTTPClient) Put(path string, body interface{}, headers map[string]string) (*Response, error) {
-	return c.Do(Request{
+func (c *HTTPClient) Put(ctx context.Context, path string, body interface{}, headers map[string]string) (*Response, error) {
+	return c.DoContext(ctx, Request{
 		Method:  http.MethodPut,
 		Path:    path,
 		Body:    body,
@@ -107,8 +303,8 @@ func (c *HTTPClient) Put(path string, body interface{}, headers map[string]strin
 	})
 }
 
-func (c *HTTPClient) Patch(path string, body interface{}, headers map[string]string) (*Response, error) {
-	return c.Do(Request{
+func (c *HTTPClient) Patch(ctx context.Context, path string, body interface{}, headers map[string]string) (*Response, error) {
+	return c.DoContext(ctx, Request{
 		Method:  http.MethodPatch,
 		Path:    path,
 		Body:    body,
@@ -116,8 +312,8 @@ func (c *HTTPClient) Patch(path string, body interface{}, headers map[string]str
 	})
 }
 
-func (c *HTTPClient) Delete(path string, headers map[string]string) (*Response, error) {
-	return c.Do(Request{
+func (c *HTTPClient) Delete(ctx context.Context, path string, headers map[string]string) (*Response, error) {
+	return c.DoContext(ctx, Request{
 		Method:  http.MethodDelete,
 		Path:    path,
 		Headers: headers,
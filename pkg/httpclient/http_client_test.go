@@ -0,0 +1,232 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/pkg/clock"
+	"villainrsty-ecommerce-server/pkg/httpclient/httpclienttest"
+)
+
+func TestHTTPClient_EncodesBodyOnlyWhenSet(t *testing.T) {
+	srv := httpclienttest.NewServer(t, nil)
+	c := NewHTTPClient(srv.URL, time.Second)
+
+	if _, err := c.Post(context.Background(), "/", map[string]string{"email": "a@mail.com"}, nil); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if _, err := c.Get(context.Background(), "/", nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	reqs := srv.Requests()
+	if got := reqs[0]; got.Header.Get("Content-Type") != "application/json" || string(got.Body) != `{"email":"a@mail.com"}` {
+		t.Errorf("post = %s %q, want JSON body", got.Header.Get("Content-Type"), got.Body)
+	}
+	if got := reqs[1]; got.Header.Get("Content-Type") != "" || len(got.Body) != 0 {
+		t.Errorf("get = %s %q, want no body and no Content-Type", got.Header.Get("Content-Type"), got.Body)
+	}
+}
+
+func TestHTTPClient_Retries(t *testing.T) {
+	srv := httpclienttest.NewServer(t, nil)
+	c := New(srv.URL, Options{Retry: RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}})
+	ctx := context.Background()
+
+	srv.Enqueue(httpclienttest.RetryAfter(http.StatusServiceUnavailable, 0), httpclienttest.Drop())
+	resp, err := c.Get(ctx, "/rates", nil)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Attempts != 3 {
+		t.Errorf("status = %d, attempts = %d, want 200 after 3 attempts", resp.StatusCode, resp.Attempts)
+	}
+
+	// POST tanpa Idempotency-Key tidak diulang, dengan key diulang
+	srv.Enqueue(httpclienttest.Status(http.StatusBadGateway))
+	if resp, _ := c.Post(ctx, "/charges", map[string]int{"amount": 1}, nil); resp.StatusCode != http.StatusBadGateway || resp.Attempts != 1 {
+		t.Errorf("unsafe post = %d after %d attempts, want 502 without retry", resp.StatusCode, resp.Attempts)
+	}
+	srv.Enqueue(httpclienttest.Status(http.StatusBadGateway))
+	if resp, _ := c.Post(ctx, "/charges", map[string]int{"amount": 1}, map[string]string{"Idempotency-Key": "k1"}); resp.StatusCode != http.StatusOK || resp.Attempts != 2 {
+		t.Errorf("idempotent post = %d after %d attempts, want 200 on retry", resp.StatusCode, resp.Attempts)
+	}
+
+	// Retry-After melebihi MaxDelay tidak ditunggu
+	srv.Enqueue(httpclienttest.RetryAfter(http.StatusTooManyRequests, time.Hour))
+	if resp, _ := c.Get(ctx, "/rates", nil); resp.StatusCode != http.StatusTooManyRequests || resp.Attempts != 1 {
+		t.Errorf("long Retry-After = %d after %d attempts, want 429 returned immediately", resp.StatusCode, resp.Attempts)
+	}
+
+	if got := len(srv.Requests()); got != 7 {
+		t.Errorf("server saw %d requests, want 7", got)
+	}
+}
+
+func TestHTTPClient_CircuitBreakerPerHost(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	srv := httpclienttest.NewServer(t, nil)
+	c := New(srv.URL, Options{Clock: clk, Breaker: &BreakerOptions{FailureThreshold: 2, OpenTimeout: time.Minute}})
+	ctx := context.Background()
+
+	srv.Enqueue(httpclienttest.Status(http.StatusInternalServerError), httpclienttest.Status(http.StatusInternalServerError))
+	for range 2 {
+		if resp, err := c.Get(ctx, "/", nil); err != nil || resp.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("Get() = %v, %v, want 500", resp, err)
+		}
+	}
+
+	if _, err := c.Get(ctx, "/", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("error = %v, want ErrCircuitOpen", err)
+	}
+	if got := len(srv.Requests()); got != 2 {
+		t.Errorf("server saw %d requests, want 2 because the open circuit fails fast", got)
+	}
+
+	// setelah OpenTimeout satu request percobaan dilepas dan sukses menutup circuit
+	clk.Advance(time.Minute)
+	for range 2 {
+		if _, err := c.Get(ctx, "/", nil); err != nil {
+			t.Fatalf("Get() after open timeout error = %v", err)
+		}
+	}
+}
+
+// signerFunc Signer yang bisa dibuat gagal dari test
+type signerFunc func(req *http.Request, body []byte) error
+
+func (f signerFunc) Sign(req *http.Request, body []byte) error { return f(req, body) }
+
+func TestHTTPClient_CircuitBreakerIgnoresLocalFailures(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	srv := httpclienttest.NewServer(t, nil)
+
+	signErr := errors.New("signing key unavailable")
+	var failSign bool
+	c := New(srv.URL, Options{
+		Clock:   clk,
+		Breaker: &BreakerOptions{FailureThreshold: 1, OpenTimeout: time.Minute},
+		Signer: signerFunc(func(*http.Request, []byte) error {
+			if failSign {
+				return signErr
+			}
+			return nil
+		}),
+	})
+	ctx := context.Background()
+
+	srv.Enqueue(httpclienttest.Status(http.StatusInternalServerError))
+	if _, err := c.Get(ctx, "/", nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	clk.Advance(time.Minute)
+
+	// request yang gagal ditandatangani tidak memakai slot half-open
+	failSign = true
+	if _, err := c.Get(ctx, "/", nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("error = %v, want the signer error", err)
+	}
+	failSign = false
+
+	// request yang dibatalkan pemanggil juga tidak dihitung sebagai kegagalan host
+	srv.Enqueue(httpclienttest.Slow(time.Second))
+	canceled, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.Get(canceled, "/", nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("error = %v, want a context error", err)
+	}
+
+	if _, err := c.Get(ctx, "/", nil); err != nil {
+		t.Fatalf("Get() after local failures error = %v, want the half-open probe to go through", err)
+	}
+	if _, err := c.Get(ctx, "/", nil); err != nil {
+		t.Fatalf("Get() error = %v, want the circuit closed again", err)
+	}
+}
+
+func TestHTTPClient_LogsWithRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	srv := httpclienttest.NewServer(t, httpclienttest.JSON(http.StatusOK, map[string]string{"access_token": "tok-from-provider", "status": "ok"}))
+	c := New(srv.URL, Options{
+		Logger:    slog.New(slog.NewJSONHandler(&buf, nil)),
+		LogBodies: true,
+		Redact:    []string{"msisdn"},
+	})
+
+	_, err := c.Post(context.Background(), "/sms?api_key=key-in-query", map[string]any{
+		"msisdn":  "628123456789",
+		"message": "hello",
+		"card":    map[string]string{"cvv": "123", "card_number": "4111111111111111"},
+	}, map[string]string{"Authorization": "Bearer secret-bearer", "X-Api-Key": "secret-key"})
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, secret := range []string{"secret-bearer", "secret-key", "key-in-query", "628123456789", "4111111111111111", `"123"`, "tok-from-provider"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log contains %q: %s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "hello") || !strings.Contains(out, `"status":200`) {
+		t.Errorf("log misses non-secret fields: %s", out)
+	}
+}
+
+func TestHMACSigner_SignsEveryAttempt(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	signer := HMACSigner{Secret: "shh", Clock: clk}
+
+	var verified int
+	srv := httpclienttest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := signer.Verify(r, body, time.Minute); err != nil {
+			t.Errorf("Verify() error = %v", err)
+		}
+		if err := signer.Verify(r, append(body, ' '), time.Minute); err == nil {
+			t.Error("Verify() accepts a tampered body")
+		}
+		verified++
+	}))
+	srv.Enqueue(httpclienttest.Status(http.StatusServiceUnavailable))
+
+	c := New(srv.URL, Options{Signer: signer, Clock: clk, Retry: RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}})
+	if _, err := c.Put(context.Background(), "/shipments/1?notify=1", map[string]string{"status": "sent"}, nil); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	reqs := srv.Requests()
+	if verified != 1 || len(reqs) != 2 || reqs[0].Header.Get(HeaderSignature) == "" {
+		t.Errorf("verified = %d, requests = %d, want both attempts signed", verified, len(reqs))
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: ""},
+		{value: "120", want: 2 * time.Minute, wantOK: true},
+		{value: "-1"},
+		{value: now.Add(30 * time.Second).Format(http.TimeFormat), want: 30 * time.Second, wantOK: true},
+		{value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		{value: "besok"},
+	}
+
+	for _, tt := range tests {
+		got, ok := RetryAfter(http.Header{"Retry-After": {tt.value}}, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("RetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
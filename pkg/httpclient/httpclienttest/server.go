@@ -0,0 +1,163 @@
+// Package httpclienttest server palsu untuk mensimulasikan provider (payment, shipping, SMS)
+// yang gagal: status error, Retry-After, koneksi putus atau response lambat, lalu mencatat
+// setiap request yang masuk supaya retry dan header-nya bisa dicek.
+package httpclienttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type (
+	Server struct {
+		*httptest.Server
+
+		mu       sync.Mutex
+		handler  http.Handler
+		replies  []Reply
+		requests []Request
+	}
+
+	// Reply satu response yang sudah dijadwalkan lewat Enqueue
+	Reply struct {
+		Status int
+		Header http.Header
+		Body   string
+		// Delay menahan response, untuk mensimulasikan provider lambat atau timeout
+		Delay time.Duration
+		// Drop memutus koneksi di tengah response
+		Drop bool
+	}
+
+	// Request salinan request yang diterima server
+	Request struct {
+		Method string
+		URI    string
+		Header http.Header
+		Body   []byte
+	}
+)
+
+// NewServer menjalankan server yang ditutup otomatis di akhir test. Request yang tidak punya
+// Reply terjadwal diteruskan ke handler, nil berarti 200 dengan body {}.
+func NewServer(t testing.TB, handler http.Handler) *Server {
+	t.Helper()
+
+	if handler == nil {
+		handler = JSON(http.StatusOK, map[string]any{})
+	}
+
+	s := &Server{handler: handler}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Enqueue menjadwalkan response untuk request berikutnya secara berurutan
+func (s *Server) Enqueue(replies ...Reply) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.replies = append(s.replies, replies...)
+}
+
+// Requests semua request yang sudah diterima, urut sesuai kedatangan
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, URI: r.URL.RequestURI(), Header: r.Header.Clone(), Body: body})
+	var (
+		reply     Reply
+		scheduled bool
+	)
+	if len(s.replies) > 0 {
+		reply, s.replies, scheduled = s.replies[0], s.replies[1:], true
+	}
+	s.mu.Unlock()
+
+	if !scheduled {
+		s.handler.ServeHTTP(w, r)
+		return
+	}
+
+	reply.ServeHTTP(w, r)
+}
+
+func (rp Reply) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if rp.Delay > 0 {
+		select {
+		case <-time.After(rp.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if rp.Drop {
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				// response terpotong supaya http.Transport tidak diam-diam mengulang request
+				_, _ = io.WriteString(conn, "HTTP/1.1 200 OK\r\nContent-Length: 64\r\n\r\n{")
+				_ = conn.Close()
+				return
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+
+	for key, values := range rp.Header {
+		w.Header()[key] = values
+	}
+	status := rp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, rp.Body)
+}
+
+// Status response kosong dengan status code
+func Status(code int) Reply {
+	return Reply{Status: code}
+}
+
+// RetryAfter response (biasanya 429 atau 503) dengan header Retry-After dalam detik
+func RetryAfter(code int, after time.Duration) Reply {
+	return Reply{Status: code, Header: http.Header{"Retry-After": {strconv.Itoa(int(after / time.Second))}}}
+}
+
+// Drop memutus koneksi di tengah response, client menerima error jaringan
+func Drop() Reply {
+	return Reply{Drop: true}
+}
+
+// Slow response 200 yang baru dikirim setelah d
+func Slow(d time.Duration) Reply {
+	return Reply{Delay: d}
+}
+
+// JSON response JSON dengan status code
+func JSON(code int, v any) Reply {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return Reply{Status: code, Header: http.Header{"Content-Type": {"application/json"}}, Body: string(body)}
+}
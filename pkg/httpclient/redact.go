@@ -0,0 +1,118 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	redacted = "REDACTED"
+	// maxLoggedBody batas panjang body yang dicatat di log
+	maxLoggedBody = 2048
+)
+
+// sensitiveParts nama header, field JSON atau query param yang mengandung salah satu kata ini
+// selalu disensor, misal Authorization, X-Api-Key atau refresh_token
+var sensitiveParts = []string{"authorization", "cookie", "password", "secret", "token", "signature", "apikey", "api_key", "api-key"}
+
+// sensitiveNames nama yang hanya disensor kalau cocok utuh, "pin" misalnya ada di "shipping"
+var sensitiveNames = []string{"pin", "cvv", "cvc", "otp", "otp_code", "card_number", "pan"}
+
+// redactor menyensor data rahasia sebelum ditulis ke log dan trace
+type redactor struct {
+	names map[string]bool
+}
+
+func newRedactor(extra []string) redactor {
+	r := redactor{names: map[string]bool{}}
+	for _, name := range append(append([]string{}, sensitiveNames...), extra...) {
+		r.names[strings.ToLower(name)] = true
+	}
+
+	return r
+}
+
+func (r redactor) sensitive(name string) bool {
+	name = strings.ToLower(name)
+	if r.names[name] {
+		return true
+	}
+
+	for _, part := range sensitiveParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r redactor) headers(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for key, values := range h {
+		if r.sensitive(key) {
+			out[key] = redacted
+			continue
+		}
+		out[key] = strings.Join(values, ", ")
+	}
+
+	return out
+}
+
+func (r redactor) url(u *url.URL) string {
+	out := *u
+	out.User = nil
+
+	query := out.Query()
+	for key := range query {
+		if r.sensitive(key) {
+			query.Set(key, redacted)
+		}
+	}
+	out.RawQuery = query.Encode()
+
+	return out.String()
+}
+
+// body menyensor field rahasia di body JSON. Body selain JSON tidak dicatat isinya karena
+// field rahasianya tidak bisa dikenali.
+func (r redactor) body(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("<%d bytes, not JSON>", len(body))
+	}
+
+	out, err := json.Marshal(r.value(v))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+
+	if len(out) > maxLoggedBody {
+		return string(out[:maxLoggedBody]) + "...(truncated)"
+	}
+
+	return string(out)
+}
+
+func (r redactor) value(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for key, item := range t {
+			if r.sensitive(key) {
+				t[key] = redacted
+				continue
+			}
+			t[key] = r.value(item)
+		}
+	case []any:
+		for i, item := range t {
+			t[i] = r.value(item)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,137 @@
+package httpclient
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy kapan dan berapa lama menunggu sebelum request diulang. Hanya request yang aman
+// diulang (GET, HEAD, OPTIONS, PUT, DELETE atau yang membawa Idempotency-Key) yang di-retry,
+// kecuali RetryUnsafe diaktifkan.
+type RetryPolicy struct {
+	// MaxAttempts total percobaan termasuk yang pertama, 0 atau 1 berarti tanpa retry
+	MaxAttempts int
+	// BaseDelay jeda sebelum retry pertama, berlipat dua setiap retry, default 100ms
+	BaseDelay time.Duration
+	// MaxDelay batas jeda, default 10 detik. Retry-After yang lebih lama tidak ditunggu,
+	// response-nya langsung dikembalikan ke pemanggil.
+	MaxDelay time.Duration
+	// Statuses status response yang di-retry, default 429, 502, 503 dan 504
+	Statuses []int
+	// RetryUnsafe ikut me-retry POST/PATCH tanpa Idempotency-Key, hanya untuk provider yang
+	// menjamin request yang sama tidak diproses dua kali
+	RetryUnsafe bool
+}
+
+// DefaultRetryPolicy 3 percobaan dengan jeda 100ms, 200ms (+ jitter)
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3}.withDefaults()
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = 100 * time.Millisecond
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 10 * time.Second
+	}
+	if p.Statuses == nil {
+		p.Statuses = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+	}
+
+	return p
+}
+
+// next menentukan apakah percobaan ke-attempt perlu diulang dan berapa lama menunggu. err hanya
+// berisi kegagalan jaringan, error sebelum request terkirim (breaker, signer) tidak di-retry.
+func (p RetryPolicy) next(req Request, resp *Response, err error, attempt int, now time.Time) (bool, time.Duration) {
+	if attempt >= p.MaxAttempts || !p.retryable(req) {
+		return false, 0
+	}
+	if err == nil && !slices.Contains(p.Statuses, resp.StatusCode) {
+		return false, 0
+	}
+
+	delay := p.backoff(attempt)
+	if resp != nil {
+		if after, ok := RetryAfter(resp.Headers, now); ok {
+			delay = after
+		}
+	}
+	if delay > p.MaxDelay {
+		return false, 0
+	}
+
+	return true, delay
+}
+
+func (p RetryPolicy) retryable(req Request) bool {
+	if p.RetryUnsafe {
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	for key := range req.Headers {
+		if strings.EqualFold(key, "Idempotency-Key") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff delay exponential (base * 2^(attempt-1)) dengan jitter sampai 20%, dibatasi MaxDelay
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	jitter := time.Duration(rand.Int64N(int64(delay)/5 + 1))
+
+	return min(delay+jitter, p.MaxDelay)
+}
+
+// RetryAfter membaca header Retry-After dalam detik atau HTTP-date. HTTP-date dihitung
+// relatif terhadap now, tanggal yang sudah lewat menjadi nol.
+func RetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,179 @@
+// Package i18n katalog pesan (error, validasi, email) dalam bahasa Indonesia dan Inggris.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Locale string
+
+const (
+	EN Locale = "en"
+	ID Locale = "id"
+
+	// Default dipakai kalau request tidak menyebut bahasa yang didukung
+	Default = EN
+)
+
+// Supported urutan di sini juga urutan prioritas kalau q-value sama
+var Supported = []Locale{EN, ID}
+
+// SupportedList daftar locale untuk pesan error, misal "en, id"
+func SupportedList() string {
+	names := make([]string, len(Supported))
+	for i, locale := range Supported {
+		names[i] = string(locale)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Message pesan yang belum diterjemahkan, diterjemahkan saat locale sudah diketahui (di HTTP adapter)
+type Message struct {
+	Key  string
+	Args map[string]string
+}
+
+//go:embed locales/*.yaml
+var files embed.FS
+
+var catalog = mustLoad()
+
+func mustLoad() map[Locale]map[string]string {
+	out := make(map[Locale]map[string]string, len(Supported))
+	for _, locale := range Supported {
+		data, err := files.ReadFile("locales/" + string(locale) + ".yaml")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: %v", err))
+		}
+
+		var doc map[string]any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			panic(fmt.Sprintf("i18n: locales/%s.yaml: %v", locale, err))
+		}
+
+		messages := map[string]string{}
+		flatten("", doc, messages)
+		out[locale] = messages
+	}
+
+	return out
+}
+
+func flatten(prefix string, node map[string]any, out map[string]string) {
+	for k, v := range node {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		if child, ok := v.(map[string]any); ok {
+			flatten(key, child, out)
+			continue
+		}
+
+		out[key] = fmt.Sprint(v)
+	}
+}
+
+// T menerjemahkan key dengan placeholder {nama}. Urutan fallback: locale, Default, lalu key itu sendiri.
+func T(locale Locale, key string, args map[string]string) string {
+	msg, ok := Lookup(locale, key, args)
+	if !ok {
+		return key
+	}
+
+	return msg
+}
+
+// Lookup seperti T tapi memberi tahu kalau key tidak ada di locale maupun Default
+func Lookup(locale Locale, key string, args map[string]string) (string, bool) {
+	msg, ok := catalog[locale][key]
+	if !ok {
+		msg, ok = catalog[Default][key]
+	}
+
+	if !ok {
+		return "", false
+	}
+
+	for name, value := range args {
+		msg = strings.ReplaceAll(msg, "{"+name+"}", value)
+	}
+
+	return msg, true
+}
+
+func (m Message) In(locale Locale) string {
+	return T(locale, m.Key, m.Args)
+}
+
+// Parse menerima tag seperti "id", "id-ID" atau "en_US"
+func Parse(tag string) (Locale, bool) {
+	base := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(base, "-_"); i >= 0 {
+		base = base[:i]
+	}
+
+	for _, locale := range Supported {
+		if Locale(base) == locale {
+			return locale, true
+		}
+	}
+
+	return "", false
+}
+
+// Negotiate memilih locale terbaik dari header Accept-Language (RFC 9110, q-value dihormati)
+func Negotiate(acceptLanguage string) (Locale, bool) {
+	type candidate struct {
+		locale Locale
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		if locale, ok := Parse(tag); ok && q > 0 {
+			candidates = append(candidates, candidate{locale, q})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale, true
+}
+
+type contextKey struct{}
+
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// FromContext locale yang sudah dinegosiasikan, Default kalau belum ada
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(contextKey{}).(Locale); ok && locale != "" {
+		return locale
+	}
+
+	return Default
+}
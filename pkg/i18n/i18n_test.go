@@ -0,0 +1,59 @@
+package i18n
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Locale
+		wantOK bool
+	}{
+		{"id-ID,id;q=0.9,en-US;q=0.8,en;q=0.7", ID, true},
+		{"fr-FR, en;q=0.5, id;q=0.8", ID, true},
+		{"en-GB", EN, true},
+		{"id;q=0, en;q=0.1", EN, true},
+		{"fr, de", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, ok := Negotiate(tt.header)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Negotiate(%q) = %q, %v; want %q, %v", tt.header, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestT_FallsBackAndFillsPlaceholders(t *testing.T) {
+	if got := T(ID, "validation.min", map[string]string{"param": "8"}); got != "Minimal 8 karakter" {
+		t.Errorf("T(id) = %q", got)
+	}
+
+	if got := T(Locale("fr"), "validation.required", nil); got != "This field is required" {
+		t.Errorf("T(fr) = %q, want English fallback", got)
+	}
+
+	if got := T(EN, "does.not.exist", nil); got != "does.not.exist" {
+		t.Errorf("T(missing) = %q, want key", got)
+	}
+}
+
+func TestCatalog_LocalesHaveSameKeys(t *testing.T) {
+	for key := range catalog[Default] {
+		for _, locale := range Supported {
+			if _, ok := catalog[locale][key]; !ok {
+				t.Errorf("locale %s is missing %q", locale, key)
+			}
+		}
+	}
+
+	for _, locale := range Supported {
+		for key := range catalog[locale] {
+			if _, ok := catalog[Default][key]; !ok {
+				t.Errorf("locale %s has %q which is missing in %s", locale, key, Default)
+			}
+		}
+	}
+}
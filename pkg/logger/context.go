@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+type contextKey struct{}
+
+// New memilih format log: JSON satu baris untuk production, PrettyHandler berwarna untuk lokal
+func New(w io.Writer, env string, level slog.Level) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+
+	if env == "production" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+
+	return slog.New(NewPrettyHandler(w, opts))
+}
+
+// WithContext menyimpan logger (biasanya sudah berisi request_id dkk) ke dalam ctx
+func WithContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext mengambil logger dari ctx. Kalau tidak ada, pakai fallback lalu slog.Default().
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*slog.Logger); ok && l != nil {
+		return l
+	}
+
+	if fallback != nil {
+		return fallback
+	}
+
+	return slog.Default()
+}
@@ -25,14 +25,18 @@ const (
 // PrettyHandler adalah custom writer untuk slog
 type PrettyHandler struct {
 	handler slog.Handler
-	w       io.Writer
-	mu      *sync.Mutex
+	opts    *slog.HandlerOptions
+	// ops menyimpan WithAttrs/WithGroup supaya ikut diterapkan ke handler JSON per record
+	ops []func(slog.Handler) slog.Handler
+	w   io.Writer
+	mu  *sync.Mutex
 }
 
 func NewPrettyHandler(w io.Writer, opts *slog.HandlerOptions) *PrettyHandler {
 	// Kita gunakan JSONHandler asli sebagai basis, tapi kita akan "bajak" outputnya nanti
 	return &PrettyHandler{
 		handler: slog.NewJSONHandler(w, opts),
+		opts:    opts,
 		w:       w,
 		mu:      &sync.Mutex{},
 	}
@@ -45,7 +49,10 @@ func (h *PrettyHandler) Enabled(ctx context.Context, level slog.Level) bool {
 func (h *PrettyHandler) Handle(ctx context.Context, r slog.Record) error {
 	// 1. Ambil data JSON asli dari slog (yang satu baris itu)
 	buf := bytes.NewBuffer(nil)
-	subHandler := slog.NewJSONHandler(buf, nil)
+	var subHandler slog.Handler = slog.NewJSONHandler(buf, h.opts)
+	for _, op := range h.ops {
+		subHandler = op(subHandler)
+	}
 	if err := subHandler.Handle(ctx, r); err != nil {
 		return err
 	}
@@ -74,11 +81,18 @@ func (h *PrettyHandler) Handle(ctx context.Context, r slog.Record) error {
 }
 
 func (h *PrettyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &PrettyHandler{handler: h.handler.WithAttrs(attrs), w: h.w, mu: h.mu}
+	return h.with(h.handler.WithAttrs(attrs), func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
 }
 
 func (h *PrettyHandler) WithGroup(name string) slog.Handler {
-	return &PrettyHandler{handler: h.handler.WithGroup(name), w: h.w, mu: h.mu}
+	return h.with(h.handler.WithGroup(name), func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+func (h *PrettyHandler) with(handler slog.Handler, op func(slog.Handler) slog.Handler) *PrettyHandler {
+	ops := make([]func(slog.Handler) slog.Handler, len(h.ops), len(h.ops)+1)
+	copy(ops, h.ops)
+
+	return &PrettyHandler{handler: handler, opts: h.opts, ops: append(ops, op), w: h.w, mu: h.mu}
 }
 
 // Fungsi sederhana untuk mewarnai string JSON
@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestPrettyHandler_KeepsWithAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(NewPrettyHandler(&buf, nil)).With("request_id", "req-1").WithGroup("http")
+
+	l.Info("handled", "status", 200)
+
+	out := buf.String()
+	for _, want := range []string{"request_id", "req-1", "http", "status", "handled"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrettyHandler_RespectsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(NewPrettyHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	l.Info("hidden")
+
+	if buf.Len() != 0 {
+		t.Errorf("info log written below warn level: %s", buf.String())
+	}
+}
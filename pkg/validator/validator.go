@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/pkg/i18n"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -30,21 +31,35 @@ func NewValidate() *Validator {
 	}
 }
 
-func fieldError(field, message string) error {
+// fieldError pesan default (Inggris) disimpan di Fields, versi terjemahannya di FieldMessages
+func fieldError(field string, msg i18n.Message) error {
 	return &errors.AppError{
-		Kind:    errors.ErrValidation,
-		Message: "Validation failed",
-		Fields:  map[string]string{field: message},
+		Kind:          errors.ErrValidation,
+		Message:       "Validation failed",
+		Fields:        map[string]string{field: msg.In(i18n.Default)},
+		FieldMessages: map[string]i18n.Message{field: msg},
 	}
 }
 
+func message(key string, args ...string) i18n.Message {
+	msg := i18n.Message{Key: key}
+	if len(args) > 0 {
+		msg.Args = map[string]string{}
+		for i := 0; i+1 < len(args); i += 2 {
+			msg.Args[args[i]] = args[i+1]
+		}
+	}
+
+	return msg
+}
+
 func (v *Validator) ValidateEmail(email string) error {
 	if email == "" {
-		return fieldError("email", "Email is required")
+		return fieldError("email", message("validation.required"))
 	}
 
 	if err := v.validate.Var(email, "email"); err != nil {
-		return fieldError("email", "Invalid email format")
+		return fieldError("email", message("validation.email"))
 	}
 
 	return nil
@@ -52,11 +67,11 @@ func (v *Validator) ValidateEmail(email string) error {
 
 func (v *Validator) ValidatePassword(password string) error {
 	if password == "" {
-		return fieldError("password", "Password is required")
+		return fieldError("password", message("validation.required"))
 	}
 
 	if err := v.validate.Var(password, "min=8"); err != nil {
-		return fieldError("password", "Minimum 8 characters required")
+		return fieldError("password", message("validation.min", "param", "8"))
 	}
 
 	hasUpper := false
@@ -78,7 +93,7 @@ func (v *Validator) ValidatePassword(password string) error {
 	}
 
 	if !hasLower || !hasNumber || !hasUpper {
-		return fieldError("password", "Password must contain Uppercase, Lowercase and Number")
+		return fieldError("password", message("validation.password_weak"))
 	}
 
 	return nil
@@ -86,11 +101,11 @@ func (v *Validator) ValidatePassword(password string) error {
 
 func (v *Validator) ValidatePhone(phone string) error {
 	if phone == "" {
-		return fieldError("phone", "Phone is required")
+		return fieldError("phone", message("validation.required"))
 	}
 
 	if err := v.validate.Var(phone, "e164"); err != nil {
-		return fieldError("phone", "Invalid phone format")
+		return fieldError("phone", message("validation.phone"))
 	}
 
 	return nil
@@ -98,11 +113,11 @@ func (v *Validator) ValidatePhone(phone string) error {
 
 func (v *Validator) ValidateName(name string) error {
 	if name == "" {
-		return fieldError("name", "Name is require")
+		return fieldError("name", message("validation.required"))
 	}
 
 	if err := v.validate.Var(name, "min=1,max=100"); err != nil {
-		return fieldError("name", "Name must be between 1 and 100 characters")
+		return fieldError("name", message("validation.name_length"))
 	}
 
 	return nil
@@ -110,11 +125,11 @@ func (v *Validator) ValidateName(name string) error {
 
 func (v *Validator) ValidateURL(url string) error {
 	if url == "" {
-		return fieldError("url", "URL is required")
+		return fieldError("url", message("validation.required"))
 	}
 
 	if err := v.validate.Var(url, "url"); err != nil {
-		return fieldError("url", "Invalid URL format")
+		return fieldError("url", message("validation.url"))
 	}
 
 	return nil
@@ -122,7 +137,7 @@ func (v *Validator) ValidateURL(url string) error {
 
 func (v *Validator) ValidateRequired(field, value string) error {
 	if err := v.validate.Var(value, "required"); err != nil {
-		return fieldError(field, field+" is required")
+		return fieldError(field, message("validation.field_required", "field", field))
 	}
 
 	return nil
@@ -131,37 +146,34 @@ func (v *Validator) ValidateRequired(field, value string) error {
 func (v *Validator) ValidateStruct(data interface{}) error {
 	if err := v.validate.Struct(data); err != nil {
 		errorFields := make(map[string]string)
+		fieldMessages := make(map[string]i18n.Message)
 
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
 			for _, fieldErr := range validationErrors {
-				errorFields[fieldErr.Field()] = msgForTag(
-					fieldErr.Tag(),
-					fieldErr.Param(),
-				)
+				msg := msgForTag(fieldErr.Tag(), fieldErr.Param())
+				errorFields[fieldErr.Field()] = msg.In(i18n.Default)
+				fieldMessages[fieldErr.Field()] = msg
 			}
 		}
 
 		return &errors.AppError{
-			Kind:    errors.ErrValidation,
-			Message: "Validation failed",
-			Fields:  errorFields,
+			Kind:          errors.ErrValidation,
+			Message:       "Validation failed",
+			Fields:        errorFields,
+			FieldMessages: fieldMessages,
 		}
 	}
 
 	return nil
 }
 
-// Helper untuk mengubah pesan error validator menjadi text user-friendly
-func msgForTag(tag string, param string) string {
+// msgForTag mengubah tag validator menjadi pesan katalog i18n (validation.<tag>)
+func msgForTag(tag string, param string) i18n.Message {
 	switch tag {
-	case "required":
-		return "This field is required"
-	case "email":
-		return "Invalid email format"
-	case "min":
-		return "Minimum " + param + " characters required"
-	case "max":
-		return "Maximum " + param + " characters allowed"
-	}
-	return "Invalid value"
+	case "required", "email":
+		return message("validation." + tag)
+	case "min", "max", "len":
+		return message("validation."+tag, "param", param)
+	}
+	return message("validation.invalid")
 }
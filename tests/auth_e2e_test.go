@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/config"
+	authPorts "villainrsty-ecommerce-server/internal/core/auth/ports"
+	eventModels "villainrsty-ecommerce-server/internal/core/events/models"
+)
+
+func TestRegisterLoginRefresh(t *testing.T) {
+	h := New(t)
+	h.Register(t, "budi@mail.com", "Passw0rd123", "Budi")
+
+	access, refresh := h.Login(t, "budi@mail.com", "Passw0rd123")
+	h.Do(t, http.MethodPut, "/v1/auth/locale", map[string]any{"locale": "en"}, access).Expect(t, http.StatusOK)
+
+	var rotated struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	h.Do(t, http.MethodPost, "/v1/auth/refresh", map[string]any{"refresh_token": refresh}, "").
+		Expect(t, http.StatusOK).Data(t, &rotated)
+
+	if rotated.Token == "" || rotated.RefreshToken == refresh {
+		t.Fatalf("refresh did not rotate tokens: %+v", rotated)
+	}
+
+	// refresh token lama sudah di-revoke saat rotasi
+	h.Do(t, http.MethodPost, "/v1/auth/refresh", map[string]any{"refresh_token": refresh}, "").Expect(t, http.StatusUnauthorized)
+
+	h.Do(t, http.MethodPost, "/v1/auth/login", map[string]any{"email": "budi@mail.com", "password": "Salah12345"}, "").
+		Expect(t, http.StatusUnauthorized)
+}
+
+func TestPasswordReset(t *testing.T) {
+	h := New(t)
+	h.Register(t, "siti@mail.com", "Passw0rd123", "Siti")
+
+	h.Do(t, http.MethodPost, "/v1/auth/forgot-password", map[string]any{"email": "siti@mail.com"}, "").Expect(t, http.StatusOK)
+
+	msg, ok := h.Emails.Last(authPorts.EmailPasswordReset, "siti@mail.com")
+	if !ok {
+		t.Fatalf("no password reset email, got %+v", h.Emails.Messages())
+	}
+
+	link, err := url.Parse(msg.Data["link"].(string))
+	if err != nil {
+		t.Fatalf("parse reset link: %v", err)
+	}
+	token := link.Query().Get("token")
+
+	h.Do(t, http.MethodPost, "/v1/auth/reset-password", map[string]any{"token": token, "new_password": "NewPassw0rd1"}, "").
+		Expect(t, http.StatusOK)
+
+	// token sekali pakai
+	h.Do(t, http.MethodPost, "/v1/auth/reset-password", map[string]any{"token": token, "new_password": "OtherPassw0rd1"}, "").
+		Expect(t, http.StatusUnauthorized)
+
+	h.Login(t, "siti@mail.com", "NewPassw0rd1")
+
+	var changed bool
+	for _, event := range h.Outbox.All() {
+		changed = changed || event.Type == eventModels.PasswordChanged
+	}
+	if !changed {
+		t.Error("no password changed event in the outbox")
+	}
+}
+
+func TestPasswordResetExpires(t *testing.T) {
+	h := New(t)
+	h.Register(t, "siti@mail.com", "Passw0rd123", "Siti")
+
+	h.Do(t, http.MethodPost, "/v1/auth/forgot-password", map[string]any{"email": "siti@mail.com"}, "").Expect(t, http.StatusOK)
+
+	msg, ok := h.Emails.Last(authPorts.EmailPasswordReset, "siti@mail.com")
+	if !ok {
+		t.Fatalf("no password reset email, got %+v", h.Emails.Messages())
+	}
+
+	link, err := url.Parse(msg.Data["link"].(string))
+	if err != nil {
+		t.Fatalf("parse reset link: %v", err)
+	}
+
+	h.Clock.Advance(h.Config.Auth.ResetPasswordTTL)
+	h.Do(t, http.MethodPost, "/v1/auth/reset-password", map[string]any{"token": link.Query().Get("token"), "new_password": "NewPassw0rd1"}, "").
+		Expect(t, http.StatusUnauthorized)
+
+	h.Login(t, "siti@mail.com", "Passw0rd123")
+}
+
+func TestAdminRoutes(t *testing.T) {
+	h := New(t)
+	h.Register(t, "budi@mail.com", "Passw0rd123", "Budi")
+	user, _ := h.Login(t, "budi@mail.com", "Passw0rd123")
+	admin := h.CreateAdmin(t, "admin@mail.com", "Adm1nPassword")
+
+	h.Do(t, http.MethodGet, "/v1/admin/jobs?status=pending", nil, "").Expect(t, http.StatusUnauthorized)
+	h.Do(t, http.MethodGet, "/v1/admin/jobs?status=pending", nil, user).Expect(t, http.StatusForbidden)
+	h.Do(t, http.MethodGet, "/v1/admin/jobs?status=pending", nil, admin).Expect(t, http.StatusOK)
+	h.Do(t, http.MethodGet, "/v1/admin/emails", nil, admin).Expect(t, http.StatusOK)
+}
+
+func TestPurgeExpiredTokens(t *testing.T) {
+	h := New(t)
+	h.Register(t, "budi@mail.com", "Passw0rd123", "Budi")
+	h.Login(t, "budi@mail.com", "Passw0rd123")
+
+	ctx := context.Background()
+	result, err := h.Container.AuthService.PurgeExpiredTokens(ctx)
+	if err != nil || result.RefreshTokens != 0 {
+		t.Fatalf("purge before expiry = %+v, %v, want nothing deleted", result, err)
+	}
+
+	h.Clock.Advance(h.Config.Auth.RefreshTTL + time.Minute)
+
+	result, err = h.Container.AuthService.PurgeExpiredTokens(ctx)
+	if err != nil || result.RefreshTokens != 1 {
+		t.Fatalf("purge after expiry = %+v, %v, want 1 refresh token deleted", result, err)
+	}
+}
+
+// Replay lewat router lengkap: header dari middleware luar (CORS, security header, locale)
+// tidak boleh dobel karena ikut tersimpan di response idempoten
+func TestIdempotentReplayThroughRouter(t *testing.T) {
+	h := New(t)
+	register := map[string]any{"email": "budi@mail.com", "password": "Passw0rd123", "name": "Budi"}
+	headers := []string{"Idempotency-Key", "register-budi", "Origin", "http://localhost:5500"}
+
+	first := h.Do(t, http.MethodPost, "/v1/auth/register", register, "", headers...).Expect(t, http.StatusOK)
+	replay := h.Do(t, http.MethodPost, "/v1/auth/register", register, "", headers...).Expect(t, http.StatusOK)
+
+	if replay.Header.Get("Idempotent-Replayed") != "true" || string(replay.Body) != string(first.Body) {
+		t.Fatalf("second request was not replayed: %v %s", replay.Header, replay.Body)
+	}
+
+	if got := replay.Header.Values("Access-Control-Allow-Origin"); len(got) != 1 || got[0] != "http://localhost:5500" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want one value", got)
+	}
+
+	for name, values := range replay.Header {
+		if name != "Idempotent-Replayed" && len(values) != len(first.Header[name]) {
+			t.Errorf("%s = %q on replay, want %q", name, values, first.Header[name])
+		}
+	}
+}
+
+// Tebakan OTP dibatasi per challenge, jadi berganti IP tidak menambah jatah percobaan
+func TestVerifyLogin2FA_LimitsGuessesPerChallenge(t *testing.T) {
+	h := New(t, func(cfg *config.Config) {
+		cfg.App.TrustProxy = true
+	})
+	h.Register(t, "budi@mail.com", "Passw0rd123", "Budi")
+
+	credentials := map[string]any{"email": "budi@mail.com", "password": "Passw0rd123"}
+	var challenge struct {
+		ChallengeID string `json:"challenge_id"`
+	}
+	h.Do(t, http.MethodPost, "/v1/auth/login-2fa", credentials, "").Expect(t, http.StatusOK).Data(t, &challenge)
+
+	guess := map[string]any{"challenge_id": challenge.ChallengeID, "otp_code": "000000"}
+	for i := range h.Config.RateLimit.OTP.Requests {
+		ip := fmt.Sprintf("203.0.113.%d", i+1)
+		h.Do(t, http.MethodPost, "/v1/auth/verify-login-2fa", guess, "", "X-Forwarded-For", ip).Expect(t, http.StatusUnauthorized)
+	}
+	h.Do(t, http.MethodPost, "/v1/auth/verify-login-2fa", guess, "", "X-Forwarded-For", "198.51.100.1").Expect(t, http.StatusTooManyRequests)
+
+	// challenge lain punya jatah sendiri
+	h.Do(t, http.MethodPost, "/v1/auth/login-2fa", credentials, "").Expect(t, http.StatusOK).Data(t, &challenge)
+	h.Do(t, http.MethodPost, "/v1/auth/verify-login-2fa", map[string]any{"challenge_id": challenge.ChallengeID, "otp_code": "000000"}, "").
+		Expect(t, http.StatusUnauthorized)
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/core/shared/errors"
+	"villainrsty-ecommerce-server/pkg/apiclient"
+)
+
+// TestClient_AgainstRouter menjalankan SDK hasil generate terhadap router lengkap
+func TestClient_AgainstRouter(t *testing.T) {
+	h := New(t)
+	srv := httptest.NewServer(h.Router)
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := apiclient.New(srv.URL, apiclient.Options{RetryBackoff: time.Millisecond})
+
+	register := apiclient.RegisterRequest{Email: "budi@mail.com", Password: "Passw0rd123", Name: "Budi"}
+	registered, err := c.Register(ctx, register, apiclient.WithIdempotencyKey("register-budi"))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	// key yang sama memutar ulang response pertama, key baru ditolak karena email sudah dipakai
+	replayed, err := c.Register(ctx, register, apiclient.WithIdempotencyKey("register-budi"))
+	if err != nil || replayed.Data.User.ID != registered.Data.User.ID {
+		t.Fatalf("replayed register = %+v, %v, want the first user", replayed, err)
+	}
+	_, err = c.Register(ctx, register)
+	if appErr, ok := errors.AsAppError(err); !ok || appErr.Kind != errors.ErrConflict || appErr.Code != errors.CodeEmailTaken {
+		t.Fatalf("second register error = %v, want EMAIL_ALREADY_REGISTERED", err)
+	}
+
+	_, err = c.Login(ctx, apiclient.LoginRequest{Email: "budi@mail.com", Password: "Salah12345"})
+	if !errors.IsKind(err, errors.ErrUnauthorized) {
+		t.Fatalf("login with wrong password error = %v, want unauthorized", err)
+	}
+
+	if _, err := c.Login(ctx, apiclient.LoginRequest{Email: "budi@mail.com", Password: "Passw0rd123"}); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	refresh := c.Tokens().Refresh
+	if c.Tokens().Access == "" || refresh == "" {
+		t.Fatalf("tokens = %+v, want stored after login", c.Tokens())
+	}
+
+	// access token rusak diganti lewat /auth/refresh lalu request diulang
+	c.SetTokens(apiclient.Tokens{Access: "broken", Refresh: refresh})
+	if _, err := c.UpdateLocale(ctx, apiclient.UpdateLocaleRequest{Locale: "id"}); err != nil {
+		t.Fatalf("UpdateLocale() error = %v", err)
+	}
+	if c.Tokens().Access == "broken" || c.Tokens().Refresh == refresh {
+		t.Errorf("tokens = %+v, want rotated by refresh", c.Tokens())
+	}
+
+	_, err = c.ListJobs(ctx, apiclient.ListJobsParams{})
+	if !errors.IsKind(err, errors.ErrForbidden) {
+		t.Fatalf("ListJobs() as user error = %v, want forbidden", err)
+	}
+
+	c.SetTokens(apiclient.Tokens{Access: h.CreateAdmin(t, "admin@mail.com", "Passw0rd123")})
+	jobs, err := c.ListJobs(ctx, apiclient.ListJobsParams{Limit: 5})
+	if err != nil {
+		t.Fatalf("ListJobs() error = %v", err)
+	}
+	if jobs.Meta == nil || jobs.Meta.Limit != 5 {
+		t.Errorf("meta = %+v, want limit 5", jobs.Meta)
+	}
+}
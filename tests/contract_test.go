@@ -0,0 +1,294 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/openapi"
+	"villainrsty-ecommerce-server/internal/config"
+	authPorts "villainrsty-ecommerce-server/internal/core/auth/ports"
+	rateLimitModels "villainrsty-ecommerce-server/internal/core/ratelimit/models"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/i18n"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// undocumented route yang sengaja tidak ada di dokumen OpenAPI
+var undocumented = []string{"/docs"}
+
+// apiVersions versi yang dipasang router, legacyVersion versi yang dilayani route lama tanpa prefix
+var (
+	apiVersions   = []string{"v1"}
+	legacyVersion = "v1"
+)
+
+// contract menjalankan request lewat router lalu mencocokkan response dengan operation di dokumen
+// versi yang disajikan server di /docs/<versi>/openapi.yml
+type contract struct {
+	h       *Harness
+	spec    *openapi.Spec
+	covered map[string]bool
+}
+
+func newContract(t *testing.T, h *Harness, version string) *contract {
+	t.Helper()
+
+	spec, err := openapi.Load(h.Do(t, http.MethodGet, "/docs/"+version+"/openapi.yml", nil, "").Expect(t, http.StatusOK).Body)
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+
+	return &contract{h: h, spec: spec, covered: map[string]bool{}}
+}
+
+// call memastikan request jatuh ke operationID, status sesuai want dan response sesuai dokumen
+func (c *contract) call(t *testing.T, operationID, method, target string, body any, token string, want int, header ...string) *Response {
+	t.Helper()
+
+	u, err := url.Parse(target)
+	if err != nil {
+		t.Fatalf("parse %s: %v", target, err)
+	}
+
+	op, _, ok := c.spec.Find(method, u.Path)
+	if !ok || op.ID != operationID {
+		t.Fatalf("%s %s resolves to %q, want operation %q", method, u.Path, op.ID, operationID)
+	}
+	c.covered[op.ID] = true
+
+	resp := c.h.Do(t, method, target, body, token, header...).Expect(t, want)
+	if err := op.ValidateResponse(resp.Code, resp.Header, resp.Body); err != nil {
+		t.Errorf("%s %s response does not match spec: %v\nbody: %s", method, target, err, resp.Body)
+	}
+
+	return resp
+}
+
+func TestContract_RoutesMatchSpec(t *testing.T) {
+	h := New(t)
+
+	documented := map[string]bool{}
+	for _, version := range apiVersions {
+		for _, op := range newContract(t, h, version).spec.Operations() {
+			documented[op.Method+" "+op.Path] = true
+		}
+	}
+
+	routed := map[string]bool{}
+	err := chi.Walk(h.Router.(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+		for _, prefix := range undocumented {
+			if strings.HasPrefix(route, prefix) {
+				return nil
+			}
+		}
+
+		routed[method+" "+route] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk routes: %v", err)
+	}
+
+	for route := range routed {
+		method, path, _ := strings.Cut(route, " ")
+		// route lama tanpa prefix versi hanya alias, yang didokumentasikan versi aslinya
+		if !documented[route] && !documented[method+" /"+legacyVersion+path] {
+			t.Errorf("%s is routed but not documented in any openapi document", route)
+		}
+	}
+
+	for route := range documented {
+		if !routed[route] {
+			t.Errorf("%s is documented but not routed", route)
+		}
+	}
+}
+
+// TestContract_Operations memanggil setiap operation di dokumen setiap versi minimal sekali.
+// Operation baru di dokumen tanpa pemanggilan di sini membuat test gagal.
+func TestContract_Operations(t *testing.T) {
+	for _, version := range apiVersions {
+		t.Run(version, func(t *testing.T) {
+			testContractOperations(t, version)
+		})
+	}
+}
+
+func testContractOperations(t *testing.T, version string) {
+	h := New(t, func(cfg *config.Config) {
+		unlimited := rateLimitModels.Limit{Requests: 1000, Per: time.Minute}
+		cfg.RateLimit.Auth, cfg.RateLimit.Register, cfg.RateLimit.Email, cfg.RateLimit.OTP, cfg.RateLimit.Admin = unlimited, unlimited, unlimited, unlimited, unlimited
+	})
+	c := newContract(t, h, version)
+	v := "/" + version
+	ctx := context.Background()
+
+	c.call(t, "healthCheck", http.MethodGet, "/", nil, "", http.StatusOK)
+	c.call(t, "livez", http.MethodGet, "/livez", nil, "", http.StatusOK)
+	c.call(t, "readyz", http.MethodGet, "/readyz", nil, "", http.StatusOK)
+	c.call(t, "health", http.MethodGet, "/health", nil, "", http.StatusOK)
+	c.call(t, "listProblemTypes", http.MethodGet, "/problems", nil, "", http.StatusOK)
+	c.call(t, "getProblemType", http.MethodGet, "/problems/invalid-token", nil, "", http.StatusOK)
+	c.call(t, "getProblemType", http.MethodGet, "/problems/no-such-problem", nil, "", http.StatusNotFound)
+
+	// auth
+	register := map[string]any{"email": "budi@mail.com", "password": "Passw0rd123", "name": "Budi"}
+	c.call(t, "register", http.MethodPost, v+"/auth/register", register, "", http.StatusOK, "Idempotency-Key", "register-budi")
+	c.call(t, "register", http.MethodPost, v+"/auth/register", map[string]any{"email": "budi@mail.com", "password": "Passw0rd123", "name": "Budi"}, "", http.StatusConflict)
+	c.call(t, "register", http.MethodPost, v+"/auth/register", map[string]any{"email": "siti@mail.com"}, "", http.StatusBadRequest)
+
+	credentials := map[string]any{"email": "budi@mail.com", "password": "Passw0rd123"}
+	var login struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	c.call(t, "login", http.MethodPost, v+"/auth/login", credentials, "", http.StatusOK).Data(t, &login)
+	c.call(t, "login", http.MethodPost, v+"/auth/login", map[string]any{"email": "budi@mail.com", "password": "Salah12345"}, "", http.StatusUnauthorized)
+
+	var challenge struct {
+		ChallengeID string `json:"challenge_id"`
+	}
+	c.call(t, "login2FA", http.MethodPost, v+"/auth/login-2fa", credentials, "", http.StatusOK).Data(t, &challenge)
+	otp, ok := h.Emails.Last(authPorts.EmailLoginOTP, "budi@mail.com")
+	if !ok {
+		t.Fatal("no login otp email")
+	}
+	c.call(t, "verifyLogin2FA", http.MethodPost, v+"/auth/verify-login-2fa", map[string]any{"challenge_id": challenge.ChallengeID, "otp_code": "000000"}, "", http.StatusUnauthorized)
+	c.call(t, "verifyLogin2FA", http.MethodPost, v+"/auth/verify-login-2fa", map[string]any{"challenge_id": challenge.ChallengeID, "otp_code": otp.Data["code"]}, "", http.StatusOK)
+
+	var rotated struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	c.call(t, "refreshToken", http.MethodPost, v+"/auth/refresh", map[string]any{"refresh_token": login.RefreshToken}, "", http.StatusOK).Data(t, &rotated)
+	c.call(t, "refreshToken", http.MethodPost, v+"/auth/refresh", map[string]any{"refresh_token": login.RefreshToken}, "", http.StatusUnauthorized)
+	c.call(t, "logout", http.MethodPost, v+"/auth/logout", map[string]any{"refresh_token": rotated.RefreshToken}, "", http.StatusOK)
+
+	c.call(t, "updateLocale", http.MethodPut, v+"/auth/locale", map[string]any{"locale": "id"}, login.Token, http.StatusOK)
+	c.call(t, "updateLocale", http.MethodPut, v+"/auth/locale", map[string]any{"locale": "id"}, "", http.StatusUnauthorized)
+
+	c.call(t, "forgotPassword", http.MethodPost, v+"/auth/forgot-password", map[string]any{"email": "budi@mail.com"}, "", http.StatusOK)
+	reset, ok := h.Emails.Last(authPorts.EmailPasswordReset, "budi@mail.com")
+	if !ok {
+		t.Fatal("no password reset email")
+	}
+	link, _ := url.Parse(reset.Data["link"].(string))
+	c.call(t, "resetPassword", http.MethodPost, v+"/auth/reset-password", map[string]any{"token": link.Query().Get("token"), "new_password": "NewPassw0rd1"}, "", http.StatusOK)
+	c.call(t, "resetPassword", http.MethodPost, v+"/auth/reset-password", map[string]any{"token": link.Query().Get("token"), "new_password": "NewPassw0rd1"}, "", http.StatusUnauthorized)
+
+	// admin
+	user, _ := h.Login(t, "budi@mail.com", "NewPassw0rd1")
+	admin := h.CreateAdmin(t, "admin@mail.com", "Adm1nPassword")
+
+	job := models.NewJob("email.deliver", []byte(`{"message_id":"m-1"}`), 1, h.Clock.Now())
+	if err := h.Jobs.Enqueue(ctx, job); err != nil {
+		t.Fatalf("enqueue job: %v", err)
+	}
+	if err := h.Jobs.MarkDead(ctx, job.ID, "smtp timeout"); err != nil {
+		t.Fatalf("mark job dead: %v", err)
+	}
+
+	c.call(t, "listJobs", http.MethodGet, v+"/admin/jobs?status=dead&page=1&limit=20", nil, admin, http.StatusOK)
+	c.call(t, "listJobs", http.MethodGet, v+"/admin/jobs", nil, user, http.StatusForbidden)
+	c.call(t, "listJobs", http.MethodGet, v+"/admin/jobs", nil, "", http.StatusUnauthorized)
+	c.call(t, "getJob", http.MethodGet, v+"/admin/jobs/"+job.ID.String(), nil, admin, http.StatusOK)
+	c.call(t, "getJob", http.MethodGet, v+"/admin/jobs/"+models.NewID().String(), nil, admin, http.StatusNotFound)
+	c.call(t, "retryJob", http.MethodPost, v+"/admin/jobs/"+job.ID.String()+"/retry", nil, admin, http.StatusOK)
+
+	msg := models.NewEmailMessage("password_reset", "budi@mail.com", i18n.EN, map[string]any{"link": "http://localhost/reset?token=abc"}, h.Clock.Now())
+	msg.Status = models.EmailStatusFailed
+	if err := h.EmailMessages.Save(ctx, msg); err != nil {
+		t.Fatalf("save email message: %v", err)
+	}
+
+	c.call(t, "listEmailMessages", http.MethodGet, v+"/admin/emails?status=failed", nil, admin, http.StatusOK)
+	c.call(t, "getEmailMessage", http.MethodGet, v+"/admin/emails/"+msg.ID.String(), nil, admin, http.StatusOK)
+	c.call(t, "getEmailMessage", http.MethodGet, v+"/admin/emails/"+models.NewID().String(), nil, admin, http.StatusNotFound)
+	c.call(t, "resendEmailMessage", http.MethodPost, v+"/admin/emails/"+msg.ID.String()+"/resend", nil, admin, http.StatusOK, "Idempotency-Key", "resend-1")
+	c.call(t, "resendEmailMessage", http.MethodPost, v+"/admin/emails/"+msg.ID.String()+"/resend", nil, admin, http.StatusConflict, "Idempotency-Key", "resend-2")
+	c.call(t, "getHealthReport", http.MethodGet, v+"/admin/health", nil, admin, http.StatusOK)
+	c.call(t, "getHealthReport", http.MethodGet, v+"/admin/health", nil, user, http.StatusForbidden)
+	c.call(t, "getHealthReport", http.MethodGet, v+"/admin/health", nil, "", http.StatusUnauthorized)
+	c.call(t, "listEmailTemplates", http.MethodGet, v+"/admin/emails/templates", nil, admin, http.StatusOK)
+	c.call(t, "previewEmailTemplate", http.MethodGet, v+"/admin/emails/templates/password_reset/preview?format=json&locale=id", nil, admin, http.StatusOK)
+	c.call(t, "previewEmailTemplate", http.MethodGet, v+"/admin/emails/templates/password_reset/preview", nil, admin, http.StatusOK)
+	c.call(t, "previewEmailTemplate", http.MethodGet, v+"/admin/emails/templates/no_such_template/preview", nil, admin, http.StatusNotFound)
+
+	for _, op := range c.spec.Operations() {
+		if !c.covered[op.ID] {
+			t.Errorf("operation %s (%s %s) is not exercised by the contract test", op.ID, op.Method, op.Path)
+		}
+	}
+}
+
+func TestContract_RejectsRequestsOutsideSpec(t *testing.T) {
+	h := New(t)
+
+	resp := h.Do(t, http.MethodPost, "/v1/auth/register", map[string]any{"email": "not-an-email", "password": "short", "name": "Budi"}, "").
+		Expect(t, http.StatusBadRequest)
+
+	var problem struct {
+		Code   string `json:"code"`
+		Errors []struct {
+			Field string `json:"field"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(resp.Body, &problem); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+
+	fields := map[string]bool{}
+	for _, e := range problem.Errors {
+		fields[e.Field] = true
+	}
+	if problem.Code != "VALIDATION_ERROR" || !fields["email"] || !fields["password"] {
+		t.Errorf("problem = %s, want VALIDATION_ERROR for email and password", resp.Body)
+	}
+
+	h.Do(t, http.MethodGet, "/v1/admin/jobs?status=unknown", nil, "").Expect(t, http.StatusBadRequest)
+}
+
+var updateOpenAPI = flag.Bool("update", false, "rewrite ../openapi/<version>.yml from the generated documents")
+
+// TestContract_OpenAPIFileUpToDate memastikan openapi/<versi>.yml di repo (dipakai klien dan
+// generator SDK tanpa menjalankan server) sama dengan dokumen yang dibangun dari route.
+// Perbarui dengan: go test ./tests -run TestContract_OpenAPIFileUpToDate -update
+func TestContract_OpenAPIFileUpToDate(t *testing.T) {
+	h := New(t)
+
+	for _, version := range apiVersions {
+		generated := h.Do(t, http.MethodGet, "/docs/"+version+"/openapi.yml", nil, "").Expect(t, http.StatusOK).Body
+
+		file := filepath.Join("..", "openapi", version+".yml")
+		if *updateOpenAPI {
+			if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+				t.Fatalf("create %s: %v", filepath.Dir(file), err)
+			}
+			if err := os.WriteFile(file, generated, 0o644); err != nil {
+				t.Fatalf("write %s: %v", file, err)
+			}
+			continue
+		}
+
+		committed, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+
+		if !bytes.Equal(committed, generated) {
+			t.Fatalf("%s is stale, run: go test ./tests -run TestContract_OpenAPIFileUpToDate -update", file)
+		}
+	}
+}
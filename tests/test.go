@@ -1 +1,194 @@
+// Package tests harness end-to-end: container dan router lengkap di atas repository in-memory,
+// sehingga alur HTTP bisa dites tanpa Postgres, SMTP, worker maupun dispatcher.
 package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"villainrsty-ecommerce-server/internal/adapters/http/router"
+	"villainrsty-ecommerce-server/internal/adapters/notifications/capture"
+	"villainrsty-ecommerce-server/internal/adapters/persistence/memory"
+	"villainrsty-ecommerce-server/internal/adapters/security/password"
+	"villainrsty-ecommerce-server/internal/app"
+	"villainrsty-ecommerce-server/internal/config"
+	"villainrsty-ecommerce-server/internal/core/shared/models"
+	"villainrsty-ecommerce-server/pkg/clock"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type (
+	Harness struct {
+		Config    config.Config
+		Clock     *clock.Fake
+		Emails    *capture.EmailSender
+		Container *app.Container
+		Router    http.Handler
+
+		Users          *memory.UserRepository
+		RefreshTokens  *memory.RefreshTokenRepository
+		PasswordResets *memory.PasswordResetTokenRepository
+		TwoFactorOTPs  *memory.TwoFactorOTPRepository
+		Jobs           *memory.JobRepository
+		Outbox         *memory.OutboxRepository
+		EmailMessages  *memory.EmailMessageRepository
+	}
+
+	// Response hasil satu request lewat Harness.Do
+	Response struct {
+		Code   int
+		Header http.Header
+		Body   []byte
+	}
+)
+
+// New membangun harness baru per test. configure (opsional) mengubah config sebelum
+// container dibuat, misalnya menurunkan limit rate limiter.
+func New(t testing.TB, configure ...func(*config.Config)) *Harness {
+	t.Helper()
+
+	cfg := config.Defaults()
+	cfg.App.Env = "test"
+	cfg.Auth.Secret = "test-secret"
+	cfg.Auth.ResetPasswordURL = "http://localhost:5500/reset-password"
+	cfg.Mail.Driver = config.MailDriverLog
+	for _, fn := range configure {
+		fn(&cfg)
+	}
+
+	clk := clock.NewFake(time.Now().Truncate(time.Second))
+	h := &Harness{
+		Config:         cfg,
+		Clock:          clk,
+		Emails:         capture.NewEmailSender(),
+		Users:          memory.NewUserRepository(clk),
+		RefreshTokens:  memory.NewRefreshTokenRepository(clk),
+		PasswordResets: memory.NewPasswordResetTokenRepository(clk),
+		TwoFactorOTPs:  memory.NewTwoFactorOTPRepository(clk),
+		Jobs:           memory.NewJobRepository(clk),
+		Outbox:         memory.NewOutboxRepository(),
+		EmailMessages:  memory.NewEmailMessageRepository(clk),
+	}
+
+	h.Container = app.NewWithPorts(cfg, app.Ports{
+		Users:          h.Users,
+		RefreshTokens:  h.RefreshTokens,
+		PasswordResets: h.PasswordResets,
+		TwoFactorOTPs:  h.TwoFactorOTPs,
+		Jobs:           h.Jobs,
+		Outbox:         h.Outbox,
+		EmailMessages:  h.EmailMessages,
+		Idempotency:    memory.NewIdempotencyStore(),
+		TxManager:      memory.NewTxManager(),
+		Hasher:         password.NewBcryptHasherWithCost(bcrypt.MinCost),
+		EmailSender:    h.Emails,
+		Clock:          clk,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	h.Router = router.New(h.Container)
+
+	return h
+}
+
+// Do mengirim request JSON ke router. body nil berarti tanpa body, token kosong berarti anonim.
+func (h *Harness) Do(t testing.TB, method, path string, body any, token string, header ...string) *Response {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("encode %s %s body: %v", method, path, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+
+	rec := httptest.NewRecorder()
+	h.Router.ServeHTTP(rec, req)
+
+	return &Response{Code: rec.Code, Header: rec.Header(), Body: rec.Body.Bytes()}
+}
+
+// Data men-decode field "data" dari envelope sukses ke dst
+func (r *Response) Data(t testing.TB, dst any) {
+	t.Helper()
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(r.Body, &envelope); err != nil {
+		t.Fatalf("decode response %s: %v", r.Body, err)
+	}
+
+	if err := json.Unmarshal(envelope.Data, dst); err != nil {
+		t.Fatalf("decode data %s: %v", envelope.Data, err)
+	}
+}
+
+// Expect menghentikan test kalau status code tidak sesuai
+func (r *Response) Expect(t testing.TB, code int) *Response {
+	t.Helper()
+
+	if r.Code != code {
+		t.Fatalf("status = %d, want %d, body = %s", r.Code, code, r.Body)
+	}
+
+	return r
+}
+
+// Register mendaftarkan user lewat HTTP
+func (h *Harness) Register(t testing.TB, email, password, name string) {
+	t.Helper()
+
+	h.Do(t, http.MethodPost, "/v1/auth/register", map[string]any{
+		"email":    email,
+		"password": password,
+		"name":     name,
+	}, "").Expect(t, http.StatusOK)
+}
+
+// Login mengembalikan access token dan refresh token
+func (h *Harness) Login(t testing.TB, email, password string) (string, string) {
+	t.Helper()
+
+	var resp struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	h.Do(t, http.MethodPost, "/v1/auth/login", map[string]any{
+		"email":    email,
+		"password": password,
+	}, "").Expect(t, http.StatusOK).Data(t, &resp)
+
+	return resp.Token, resp.RefreshToken
+}
+
+// CreateAdmin membuat user dengan role admin (seperti `api user create -admin`) lalu login
+func (h *Harness) CreateAdmin(t testing.TB, email, password string) string {
+	t.Helper()
+
+	if _, err := h.Container.AuthService.CreateUser(context.Background(), email, password, "Admin", models.RoleAdmin); err != nil {
+		t.Fatalf("create admin: %v", err)
+	}
+
+	token, _ := h.Login(t, email, password)
+	return token
+}
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestVersioning_LegacyRoutesAreDeprecatedAliases(t *testing.T) {
+	h := New(t)
+	h.Register(t, "budi@mail.com", "Passw0rd123", "Budi")
+	credentials := map[string]any{"email": "budi@mail.com", "password": "Passw0rd123"}
+
+	// route lama tetap dilayani tapi memberi tahu klien versi penggantinya
+	legacy := h.Do(t, http.MethodPost, "/auth/login", credentials, "").Expect(t, http.StatusOK)
+	deprecation, sunset := legacy.Header.Get("Deprecation"), legacy.Header.Get("Sunset")
+	if len(deprecation) < 2 || deprecation[0] != '@' {
+		t.Errorf("Deprecation = %q, want @<unix seconds>", deprecation)
+	}
+	if _, err := time.Parse(http.TimeFormat, sunset); err != nil {
+		t.Errorf("Sunset = %q, want an HTTP date: %v", sunset, err)
+	}
+	if got, want := legacy.Header.Get("Link"), `</v1/auth/login>; rel="successor-version"`; got != want {
+		t.Errorf("Link = %q, want %q", got, want)
+	}
+
+	// header juga ikut di response error
+	failed := h.Do(t, http.MethodPost, "/auth/login", map[string]any{"email": "budi@mail.com", "password": "Salah12345"}, "").
+		Expect(t, http.StatusUnauthorized)
+	if failed.Header.Get("Deprecation") == "" {
+		t.Error("error response from a legacy route has no Deprecation header")
+	}
+
+	resp := h.Do(t, http.MethodPost, "/v1/auth/login", credentials, "").Expect(t, http.StatusOK)
+	if resp.Header.Get("Deprecation") != "" || resp.Header.Get("Sunset") != "" {
+		t.Errorf("/v1/auth/login is marked deprecated: %v", resp.Header)
+	}
+
+	// route umum tidak ikut versi
+	h.Do(t, http.MethodGet, "/health", nil, "").Expect(t, http.StatusOK)
+	h.Do(t, http.MethodGet, "/v1/health", nil, "").Expect(t, http.StatusNotFound)
+}
+
+func TestVersioning_DocsPerVersion(t *testing.T) {
+	h := New(t)
+
+	for _, version := range apiVersions {
+		h.Do(t, http.MethodGet, "/docs/"+version, nil, "").Expect(t, http.StatusOK)
+		h.Do(t, http.MethodGet, "/docs/"+version+"/openapi.yml", nil, "").Expect(t, http.StatusOK)
+	}
+
+	latest := apiVersions[len(apiVersions)-1]
+	if got := h.Do(t, http.MethodGet, "/docs", nil, "").Expect(t, http.StatusFound).Header.Get("Location"); got != "/docs/"+latest {
+		t.Errorf("/docs redirects to %q, want /docs/%s", got, latest)
+	}
+}